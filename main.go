@@ -15,7 +15,7 @@ func main() {
 	p := printer.NewSvc(true)
 
 	rawDmiSvc := dmi.NewRawSvc("/")
-	sm := dmi.NewSvc(p, rawDmiSvc)
+	sm := dmi.NewSvc(p, rawDmiSvc, dmi.NewDeviceTreeSvc(p, "/"))
 	data, err := sm.GetData()
 	if err != nil {
 		p.Err(err)
@@ -12,18 +12,22 @@ import (
 	"github.com/onmetal/inventory/pkg/block"
 	"github.com/onmetal/inventory/pkg/cpu"
 	"github.com/onmetal/inventory/pkg/crd"
+	"github.com/onmetal/inventory/pkg/cxl"
 	"github.com/onmetal/inventory/pkg/distro"
 	"github.com/onmetal/inventory/pkg/dmi"
+	"github.com/onmetal/inventory/pkg/edac"
 	"github.com/onmetal/inventory/pkg/flags"
 	"github.com/onmetal/inventory/pkg/gatherer"
 	"github.com/onmetal/inventory/pkg/host"
 	"github.com/onmetal/inventory/pkg/ipmi"
+	"github.com/onmetal/inventory/pkg/kernel"
 	"github.com/onmetal/inventory/pkg/lldp"
 	"github.com/onmetal/inventory/pkg/lldp/frame"
 	"github.com/onmetal/inventory/pkg/mem"
 	"github.com/onmetal/inventory/pkg/netlink"
 	"github.com/onmetal/inventory/pkg/nic"
 	"github.com/onmetal/inventory/pkg/numa"
+	"github.com/onmetal/inventory/pkg/nvdimm"
 	"github.com/onmetal/inventory/pkg/pci"
 	"github.com/onmetal/inventory/pkg/printer"
 	"github.com/onmetal/inventory/pkg/redis"
@@ -36,6 +40,11 @@ type InventoryApp struct {
 	crdBuilderSvc *crd.BuilderSvc
 	crdSaverSvc   crd.SaverSvc
 	crdSaverPatch bool
+	rawDMISvc     *dmi.RawSvc
+	dmiDumpOut    string
+	// offline is set if data of another machine is read, e.g. SMBIOS dump,
+	// resource is printed then instead of saving it over the one of this or other machine
+	offline bool
 }
 
 func NewInventoryApp() (*InventoryApp, int) {
@@ -44,15 +53,35 @@ func NewInventoryApp() (*InventoryApp, int) {
 
 	crdBuilderSvc := crd.NewBuilderSvc(p)
 
-	crdSvcConstructor := func() (crd.SaverSvc, error) {
-		return crd.NewKubeAPISaverSvc(f.Kubeconfig, f.KubeNamespace)
+	oemStringRules, err := parseOEMStringRules(f)
+	if err != nil {
+		p.Err(errors.Wrapf(err, "unable to parse OEM string rules"))
+		return nil, CErrRetCode
 	}
+	crdBuilderSvc.SetOEMStringRules(oemStringRules)
 
-	crdSaverSvc, err := crdSvcConstructor()
+	identityResolver, err := newIdentityResolver(f.IdentitySources, f.BogusUUIDs)
 	if err != nil {
-		p.Err(errors.Wrapf(err, "unable to create k8s resorce saver svc"))
+		p.Err(errors.Wrapf(err, "unable to create identity resolver"))
 		return nil, CErrRetCode
 	}
+	crdBuilderSvc.SetIdentityResolver(identityResolver)
+	crdBuilderSvc.SetCEThreshold(f.EDACCEThreshold)
+
+	crdSvcConstructor := func() (crd.SaverSvc, error) {
+		return crd.NewKubeAPISaverSvc(f.Kubeconfig, f.KubeNamespace)
+	}
+
+	offline := f.DMIDump != ""
+
+	var crdSaverSvc crd.SaverSvc
+	if !offline {
+		crdSaverSvc, err = crdSvcConstructor()
+		if err != nil {
+			p.Err(errors.Wrapf(err, "unable to create k8s resorce saver svc"))
+			return nil, CErrRetCode
+		}
+	}
 
 	pciIDs, err := pci.NewIDs()
 	if err != nil {
@@ -61,15 +90,29 @@ func NewInventoryApp() (*InventoryApp, int) {
 	}
 
 	rawDmiSvc := dmi.NewRawSvc(f.Root)
-	dmiSvc := dmi.NewSvc(p, rawDmiSvc)
+	if f.DMIDump != "" {
+		rawDmiSvc = dmi.NewDumpRawSvc(f.DMIDump)
+	}
+	deviceTreeSvc := dmi.NewDeviceTreeSvc(p, f.Root)
+	dmiSvc := dmi.NewSvc(p, rawDmiSvc, deviceTreeSvc)
 
 	cpuInfoSvc := cpu.NewInfoSvc(p, f.Root)
+	cpuTopologySvc := cpu.NewTopologySvc(p, f.Root)
+	cpuMicroarchSvc := cpu.NewMicroarchSvc(p, f.Root)
+	cpuPowerSvc := cpu.NewPowerSvc(p, f.Root)
+	cpuVulnerabilitySvc := cpu.NewVulnerabilitySvc(p, f.Root)
 	memInfoSvc := mem.NewInfoSvc(p, f.Root)
+	hugePagesSvc := mem.NewHugePagesSvc(p, f.Root)
+	memoryBlocksSvc := mem.NewBlocksSvc(p, f.Root)
+	edacSvc := edac.NewSvc(p, f.Root)
 
 	numaStatSvc := numa.NewStatSvc(p)
-	numaNodeSvc := numa.NewNodeSvc(memInfoSvc, numaStatSvc)
+	numaNodeSvc := numa.NewNodeSvc(memInfoSvc, hugePagesSvc, numaStatSvc)
 	numaSvc := numa.NewSvc(p, numaNodeSvc, f.Root)
 
+	nvdimmSvc := nvdimm.NewSvc(p, f.Root)
+	cxlSvc := cxl.NewSvc(p, f.Root)
+
 	partitionTableSvc := block.NewPartitionTableSvc(f.Root)
 	blockDeviceStatSvc := block.NewDeviceStatSvc(p)
 	blockDeviceSvc := block.NewDeviceSvc(p, partitionTableSvc, blockDeviceStatSvc)
@@ -79,7 +122,9 @@ func NewInventoryApp() (*InventoryApp, int) {
 	pciBusSvc := pci.NewBusSvc(p, pciDevSvc)
 	pciSvc := pci.NewSvc(p, pciBusSvc, f.Root)
 
-	hostSvc := host.NewSvc(p, f.Root)
+	virtSvc := virt.NewSvc(dmiSvc, cpuInfoSvc, f.Root)
+
+	hostSvc := host.NewSvc(p, dmiSvc, virtSvc, f.Root)
 
 	redisSvc, err := redis.NewRedisSvc(f.Root)
 	if err != nil {
@@ -98,17 +143,26 @@ func NewInventoryApp() (*InventoryApp, int) {
 
 	nlSvc := netlink.NewSvc(p, f.Root)
 
-	virtSvc := virt.NewSvc(dmiSvc, cpuInfoSvc, f.Root)
-
 	distroSvc := distro.NewSvc(p, hostSvc, f.Root)
 
+	kernelSvc := kernel.NewSvc(p, f.Root)
+
 	opts := []gatherer.Option{
 		gatherer.WithDMI(dmiSvc),
 		gatherer.WithNUMA(numaSvc),
+		gatherer.WithNVDIMM(nvdimmSvc),
+		gatherer.WithCXL(cxlSvc),
 		gatherer.WithBlocks(blockSvc),
 		gatherer.WithPCI(pciSvc),
 		gatherer.WithCPU(cpuInfoSvc),
+		gatherer.WithCPUTopology(cpuTopologySvc),
+		gatherer.WithCPUMicroarch(cpuMicroarchSvc),
+		gatherer.WithCPUPower(cpuPowerSvc),
+		gatherer.WithCPUVulnerabilities(cpuVulnerabilitySvc),
 		gatherer.WithMem(memInfoSvc),
+		gatherer.WithHugePages(hugePagesSvc),
+		gatherer.WithMemoryBlocks(memoryBlocksSvc),
+		gatherer.WithEDAC(edacSvc),
 		gatherer.WithLLDP(lldpSvc),
 		gatherer.WithNIC(nicSvc),
 		gatherer.WithIPMI(ipmiSvc),
@@ -116,6 +170,7 @@ func NewInventoryApp() (*InventoryApp, int) {
 		gatherer.WithVirt(virtSvc),
 		gatherer.WithHost(hostSvc),
 		gatherer.WithDistro(distroSvc),
+		gatherer.WithKernel(kernelSvc),
 	}
 
 	gathererSvc := gatherer.NewSvc(p, opts...)
@@ -126,10 +181,19 @@ func NewInventoryApp() (*InventoryApp, int) {
 		crdBuilderSvc: crdBuilderSvc,
 		crdSaverSvc:   crdSaverSvc,
 		crdSaverPatch: f.Patch,
+		rawDMISvc:     rawDmiSvc,
+		dmiDumpOut:    f.DMIDumpOut,
+		offline:       offline,
 	}, 0
 }
 
 func (s *InventoryApp) Run() int {
+	if s.dmiDumpOut != "" {
+		if err := s.rawDMISvc.Dump(s.dmiDumpOut); err != nil {
+			s.printer.VErr(errors.Wrap(err, "unable to dump DMI data"))
+		}
+	}
+
 	inv := s.gathererSvc.Gather()
 
 	cr, err := s.crdBuilderSvc.Build(inv)
@@ -151,6 +215,16 @@ func (s *InventoryApp) Run() int {
 	s.printer.VOut("Gathered data:")
 	s.printer.VOut(prettifiedJsonBuf.String())
 
+	if s.offline {
+		crBytes, err := json.MarshalIndent(cr, "", "\t")
+		if err != nil {
+			s.printer.Err(errors.Wrap(err, "unable to marshal inventory resource"))
+			return CErrRetCode
+		}
+		s.printer.Out(string(crBytes))
+		return COKRetCode
+	}
+
 	err = s.crdSaverSvc.Save(cr)
 	if err != nil {
 		s.printer.Err(errors.Wrap(err, "unable to save inventory resource"))
@@ -159,3 +233,38 @@ func (s *InventoryApp) Run() int {
 
 	return COKRetCode
 }
+
+func parseOEMStringRules(f *flags.InventoryFlags) ([]crd.OEMStringRule, error) {
+	rules := make([]crd.OEMStringRule, 0)
+
+	for _, spec := range f.OEMStringLabels {
+		rule, err := crd.ParseOEMStringRule(spec, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse label rule")
+		}
+		rules = append(rules, *rule)
+	}
+
+	for _, spec := range f.OEMStringAnnotations {
+		rule, err := crd.ParseOEMStringRule(spec, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse annotation rule")
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+func newIdentityResolver(sources []string, bogusUUIDs []string) (*crd.IdentityResolver, error) {
+	if len(sources) == 0 {
+		return crd.NewIdentityResolver(crd.CDefaultIdentitySources, bogusUUIDs), nil
+	}
+
+	identitySources, err := crd.ParseIdentitySources(sources)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse identity sources")
+	}
+
+	return crd.NewIdentityResolver(identitySources, bogusUUIDs), nil
+}
@@ -7,6 +7,7 @@ import (
 	metalv1alpha1 "github.com/ironcore-dev/metal/api/v1alpha1"
 	"github.com/pkg/errors"
 
+	"github.com/onmetal/inventory/pkg/cpu"
 	"github.com/onmetal/inventory/pkg/crd"
 	"github.com/onmetal/inventory/pkg/dmi"
 	"github.com/onmetal/inventory/pkg/flags"
@@ -19,6 +20,7 @@ import (
 	"github.com/onmetal/inventory/pkg/nic"
 	"github.com/onmetal/inventory/pkg/printer"
 	"github.com/onmetal/inventory/pkg/redis"
+	"github.com/onmetal/inventory/pkg/virt"
 )
 
 type NICUpdaterApp struct {
@@ -34,6 +36,13 @@ func NewNICUpdaterApp() (*NICUpdaterApp, int) {
 
 	crdBuilderSvc := crd.NewBuilderSvc(p)
 
+	identityResolver, err := newIdentityResolver(f.IdentitySources, f.BogusUUIDs)
+	if err != nil {
+		p.Err(errors.Wrapf(err, "unable to create identity resolver"))
+		return nil, CErrRetCode
+	}
+	crdBuilderSvc.SetIdentityResolver(identityResolver)
+
 	crdSvcConstructor := func() (crd.SaverSvc, error) {
 		return crd.NewKubeAPISaverSvc(f.Kubeconfig, f.KubeNamespace)
 	}
@@ -45,9 +54,13 @@ func NewNICUpdaterApp() (*NICUpdaterApp, int) {
 	}
 
 	rawDmiSvc := dmi.NewRawSvc(f.Root)
-	dmiSvc := dmi.NewSvc(p, rawDmiSvc)
+	deviceTreeSvc := dmi.NewDeviceTreeSvc(p, f.Root)
+	dmiSvc := dmi.NewSvc(p, rawDmiSvc, deviceTreeSvc)
+
+	cpuInfoSvc := cpu.NewInfoSvc(p, f.Root)
+	virtSvc := virt.NewSvc(dmiSvc, cpuInfoSvc, f.Root)
 
-	hostSvc := host.NewSvc(p, f.Root)
+	hostSvc := host.NewSvc(p, dmiSvc, virtSvc, f.Root)
 
 	redisSvc, err := redis.NewRedisSvc(f.Root)
 	if err != nil {
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/crd"
+	"github.com/onmetal/inventory/pkg/flags"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+type StaleCheckerApp struct {
+	printer    *printer.Svc
+	staleSvc   *crd.StaleSvc
+	staleAfter time.Duration
+	mark       bool
+}
+
+func NewStaleCheckerApp() (*StaleCheckerApp, int) {
+	f := flags.NewStaleCheckerFlags()
+	p := printer.NewSvc(f.Verbose)
+
+	staleSvc, err := crd.NewKubeAPIStaleSvc(f.Kubeconfig)
+	if err != nil {
+		p.Err(errors.Wrapf(err, "unable to create k8s stale inventory svc"))
+		return nil, CErrRetCode
+	}
+
+	return &StaleCheckerApp{
+		printer:    p,
+		staleSvc:   staleSvc,
+		staleAfter: f.StaleAfter,
+		mark:       f.Mark,
+	}, COKRetCode
+}
+
+func (s *StaleCheckerApp) Run() int {
+	stale, err := s.staleSvc.GetStale(s.staleAfter)
+	if err != nil {
+		s.printer.Err(errors.Wrap(err, "unable to get stale inventories"))
+		return CErrRetCode
+	}
+
+	ret := COKRetCode
+	for _, inv := range stale {
+		s.printer.Out(fmt.Sprintf("%s\tlast seen %s (%s ago)", inv.Name, inv.LastSeen.Format(time.RFC3339), inv.Age.Round(time.Second)))
+
+		if !s.mark {
+			continue
+		}
+
+		if err := s.staleSvc.MarkStale(inv); err != nil {
+			s.printer.Err(errors.Wrapf(err, "unable to mark inventory %s as stale", inv.Name))
+			ret = CErrRetCode
+		}
+	}
+
+	return ret
+}
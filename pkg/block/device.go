@@ -3,6 +3,9 @@
 
 package block
 
+// CNoNUMANode is set for devices that are not attached to any NUMA node, e.g. virtual ones
+const CNoNUMANode = -1
+
 type Device struct {
 	path              string
 	Name              string
@@ -20,7 +23,7 @@ type Device struct {
 	LogicalBlockSize  uint64
 	HWSectorSize      uint64
 	Size              uint64
-	NUMANodeID        uint64
+	NUMANodeID        int
 	PartitionTable    *PartitionTable
 	Stat              *DeviceStat
 }
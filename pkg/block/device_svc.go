@@ -45,8 +45,9 @@ func NewDeviceSvc(printer *printer.Svc, partTableSvc *PartitionTableSvc, blockDe
 
 func (s *DeviceSvc) GetDevice(thePath string, name string) (*Device, error) {
 	device := &Device{
-		path: thePath,
-		Name: name,
+		path:       thePath,
+		Name:       name,
+		NUMANodeID: CNoNUMANode,
 	}
 
 	defs := []func(*Device) error{
@@ -216,7 +217,7 @@ func (s *DeviceSvc) setHWSectorSize(bd *Device) error {
 
 func (s *DeviceSvc) setNumaNodeID(bd *Device) error {
 	numaPath := path.Join(bd.path, CDeviceNumaNodePath)
-	numa, err := file.ToUint64(numaPath)
+	numa, err := file.ToInt(numaPath)
 	if err != nil {
 		return errors.Wrapf(err, "unable to get value from file %s", numaPath)
 	}
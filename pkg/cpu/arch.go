@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	CPOWERModelPrefix = "POWER"
+	CIBMVendor        = "IBM"
+)
+
+// CARMImplementers are MIDR implementer codes, see Arm ARM D23.2 MIDR_EL1
+var CARMImplementers = map[uint64]string{
+	0x41: "ARM",
+	0x42: "Broadcom",
+	0x43: "Cavium",
+	0x46: "Fujitsu",
+	0x48: "HiSilicon",
+	0x4e: "NVIDIA",
+	0x50: "APM",
+	0x51: "Qualcomm",
+	0x61: "Apple",
+	0x6d: "Microsoft",
+	0xc0: "Ampere",
+}
+
+// CARMParts are MIDR part numbers by implementer
+var CARMParts = map[uint64]map[uint64]string{
+	0x41: {
+		0xd03: "Cortex-A53",
+		0xd04: "Cortex-A35",
+		0xd05: "Cortex-A55",
+		0xd07: "Cortex-A57",
+		0xd08: "Cortex-A72",
+		0xd09: "Cortex-A73",
+		0xd0a: "Cortex-A75",
+		0xd0b: "Cortex-A76",
+		0xd0c: "Neoverse-N1",
+		0xd0d: "Cortex-A77",
+		0xd40: "Neoverse-V1",
+		0xd41: "Cortex-A78",
+		0xd44: "Cortex-X1",
+		0xd46: "Cortex-A510",
+		0xd47: "Cortex-A710",
+		0xd48: "Cortex-X2",
+		0xd49: "Neoverse-N2",
+		0xd4a: "Neoverse-E1",
+		0xd4f: "Neoverse-V2",
+		0xd80: "Cortex-A520",
+		0xd81: "Cortex-A720",
+		0xd82: "Cortex-X4",
+		0xd84: "Neoverse-V3",
+		0xd8e: "Neoverse-N3",
+	},
+	0x42: {
+		0x516: "ThunderX2",
+	},
+	0x43: {
+		0x0a1: "ThunderX",
+		0x0a2: "ThunderX-81xx",
+		0x0a3: "ThunderX-83xx",
+		0x0af: "ThunderX2",
+	},
+	0x46: {
+		0x001: "A64FX",
+	},
+	0x48: {
+		0xd01: "TaiShan-v110",
+	},
+	0x4e: {
+		0x004: "Carmel",
+	},
+	0x50: {
+		0x000: "X-Gene",
+	},
+	0xc0: {
+		0xac3: "Ampere-1",
+		0xac4: "Ampere-1a",
+	},
+}
+
+// setArchFields fills vendor and model fields for architectures
+// which cpuinfo does not follow x86 layout
+func (ci *Info) setArchFields() {
+	switch {
+	case ci.Implementer != "":
+		ci.setARMFields()
+	case strings.HasPrefix(ci.ModelName, CPOWERModelPrefix):
+		ci.setPOWERFields()
+	}
+}
+
+// setARMFields decodes MIDR fields, e.g. implementer 0x41 and part 0xd0c
+// are ARM Neoverse-N1, variant 0x3 and revision 1 are r3p1 stepping
+func (ci *Info) setARMFields() {
+	implementer, err := strconv.ParseUint(ci.Implementer, 0, 8)
+	if err != nil {
+		return
+	}
+	part, err := strconv.ParseUint(ci.Part, 0, 16)
+	if err != nil {
+		return
+	}
+
+	if ci.VendorID == "" {
+		ci.VendorID = CARMImplementers[implementer]
+		if ci.VendorID == "" {
+			ci.VendorID = ci.Implementer
+		}
+	}
+	if ci.CPUFamily == "" {
+		ci.CPUFamily = ci.Architecture
+	}
+	if ci.Model == "" {
+		ci.Model = ci.Part
+	}
+	if ci.ModelName == "" {
+		ci.ModelName = CARMParts[implementer][part]
+	}
+
+	variant, variantErr := strconv.ParseUint(ci.Variant, 0, 8)
+	revision, revisionErr := strconv.ParseUint(ci.Revision, 0, 8)
+	if ci.Stepping == "" && variantErr == nil && revisionErr == nil {
+		ci.Stepping = fmt.Sprintf("r%dp%d", variant, revision)
+	}
+}
+
+// setPOWERFields fills fields from cpu line like POWER9 (raw), altivec supported
+func (ci *Info) setPOWERFields() {
+	if ci.VendorID == "" {
+		ci.VendorID = CIBMVendor
+	}
+	if ci.CPUFamily == "" {
+		ci.CPUFamily = strings.Fields(ci.ModelName)[0]
+	}
+	if ci.Stepping == "" {
+		ci.Stepping = ci.Revision
+	}
+}
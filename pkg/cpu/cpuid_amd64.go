@@ -0,0 +1,14 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+const CCPUIDSupported = true
+
+// cpuidex executes CPUID with both leaf and subleaf set,
+// github.com/jeek120/cpuid leaves ECX undefined, so leaf 7 could not be read with it
+func cpuidex(leaf, subleaf uint32) (eax, ebx, ecx, edx uint32)
+
+// xgetbv reads XCR0 to check which register states OS has enabled,
+// it must be called only if OSXSAVE is set
+func xgetbv() (eax, edx uint32)
@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !amd64
+
+package cpu
+
+const CCPUIDSupported = false
+
+func cpuidex(_, _ uint32) (eax, ebx, ecx, edx uint32) {
+	return 0, 0, 0, 0
+}
+
+func xgetbv() (eax, edx uint32) {
+	return 0, 0
+}
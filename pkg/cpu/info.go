@@ -38,6 +38,24 @@ const (
 	CCPUInfoCacheAlignmentKey  = "cache_alignment"
 	CCPUInfoAddressSizesKey    = "address sizes"
 	CCPUInfoPowerManagementKey = "power management"
+
+	// arm64 keys
+	CCPUInfoARMBogoMIPSKey  = "BogoMIPS"
+	CCPUInfoFeaturesKey     = "Features"
+	CCPUInfoImplementerKey  = "CPU implementer"
+	CCPUInfoArchitectureKey = "CPU architecture"
+	CCPUInfoVariantKey      = "CPU variant"
+	CCPUInfoPartKey         = "CPU part"
+	CCPUInfoARMRevisionKey  = "CPU revision"
+
+	// POWER keys, platform keys go after the last processor
+	CCPUInfoCPUKey      = "cpu"
+	CCPUInfoClockKey    = "clock"
+	CCPUInfoRevisionKey = "revision"
+	CCPUInfoTimebaseKey = "timebase"
+	CCPUInfoPlatformKey = "platform"
+	CCPUInfoMachineKey  = "machine"
+	CCPUInfoMMUKey      = "MMU"
 )
 
 type Info struct {
@@ -68,6 +86,12 @@ type Info struct {
 	CacheAlignment  uint64
 	AddressSizes    string
 	PowerManagement string
+	// Implementer, Architecture, Variant, Part and Revision are arm64 MIDR fields
+	Implementer  string
+	Architecture string
+	Variant      string
+	Part         string
+	Revision     string
 }
 
 func (ci *Info) setField(key string, val string) error {
@@ -169,6 +193,27 @@ func (ci *Info) setField(key string, val string) error {
 		ci.AddressSizes = val
 	case CCPUInfoPowerManagementKey:
 		ci.PowerManagement = val
+	case CCPUInfoARMBogoMIPSKey:
+		ci.BogoMIPS = val
+	case CCPUInfoFeaturesKey:
+		v := strings.Split(val, " ")
+		ci.Flags = v
+	case CCPUInfoImplementerKey:
+		ci.Implementer = val
+	case CCPUInfoArchitectureKey:
+		ci.Architecture = val
+	case CCPUInfoVariantKey:
+		ci.Variant = val
+	case CCPUInfoPartKey:
+		ci.Part = val
+	case CCPUInfoARMRevisionKey, CCPUInfoRevisionKey:
+		ci.Revision = val
+	case CCPUInfoCPUKey:
+		ci.ModelName = val
+	case CCPUInfoClockKey:
+		ci.CPUMHz = strings.TrimSuffix(val, "MHz")
+	case CCPUInfoTimebaseKey, CCPUInfoPlatformKey, CCPUInfoMachineKey, CCPUInfoMMUKey:
+		// platform data is not related to the processor
 	default:
 		return errors.Errorf("unknown key %s from cpuinfo", key)
 	}
@@ -6,33 +6,40 @@ package cpu
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"os"
 	"path"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 
+	"github.com/onmetal/inventory/pkg/file"
 	"github.com/onmetal/inventory/pkg/printer"
 )
 
 const (
 	CCPUInfoPath = "/proc/cpuinfo"
 
+	CCPUFreqCurrentPath = "/cpufreq/scaling_cur_freq"
+
 	CCPUInfoLinePattern = "^(\\w+\\s?\\w+?)\\s*:\\s*(.*)$"
 )
 
 var CCPUInfoLineRegexp = regexp.MustCompile(CCPUInfoLinePattern)
 
 type InfoSvc struct {
-	printer     *printer.Svc
-	cpuInfoPath string
+	printer       *printer.Svc
+	cpuInfoPath   string
+	cpuDevicePath string
 }
 
 func NewInfoSvc(printer *printer.Svc, basePath string) *InfoSvc {
 	return &InfoSvc{
-		printer:     printer,
-		cpuInfoPath: path.Join(basePath, CCPUInfoPath),
+		printer:       printer,
+		cpuInfoPath:   path.Join(basePath, CCPUInfoPath),
+		cpuDevicePath: path.Join(basePath, CCPUDevicePath),
 	}
 }
 
@@ -44,6 +51,21 @@ func (s *InfoSvc) GetInfo() ([]Info, error) {
 
 	cpus := make([]Info, 0)
 	cpu := Info{}
+	// record is a processor only if it has processor key,
+	// e.g. POWER lists platform data after the last processor
+	isProcessor := false
+	hasPhysicalID := false
+
+	appendCPU := func() {
+		if isProcessor {
+			s.setSysfsFields(&cpu, hasPhysicalID)
+			cpu.setArchFields()
+			cpus = append(cpus, cpu)
+		}
+		cpu = Info{}
+		isProcessor = false
+		hasPhysicalID = false
+	}
 
 	bufReader := bytes.NewReader(cpuInfoData)
 	scanner := bufio.NewScanner(bufReader)
@@ -52,8 +74,8 @@ func (s *InfoSvc) GetInfo() ([]Info, error) {
 
 		// cpu records are separated with empty line
 		if strings.TrimSpace(line) == "" {
-			cpus = append(cpus, cpu)
-			cpu = Info{}
+			appendCPU()
+			continue
 		}
 
 		groups := CCPUInfoLineRegexp.FindStringSubmatch(line)
@@ -67,12 +89,46 @@ func (s *InfoSvc) GetInfo() ([]Info, error) {
 		key := groups[1]
 		val := groups[2]
 
+		switch key {
+		case CCPUInfoProcessorKey:
+			isProcessor = true
+		case CCPUInfoPhysicalIDKey:
+			hasPhysicalID = true
+		}
+
 		err = cpu.setField(key, val)
 
 		if err != nil {
 			s.printer.VErr(errors.Wrapf(err, "unable to set field %s with value %s", key, val))
 		}
 	}
+	// the last record may be not followed by empty line
+	appendCPU()
 
 	return cpus, nil
 }
+
+// setSysfsFields fills fields which are missing in cpuinfo
+// on architectures other than x86
+func (s *InfoSvc) setSysfsFields(cpu *Info, hasPhysicalID bool) {
+	cpuPath := path.Join(s.cpuDevicePath, "cpu"+strconv.FormatUint(cpu.Processor, 10))
+
+	if !hasPhysicalID {
+		socketID, err := file.ToInt(path.Join(cpuPath, CTopologyPhysicalPackageIDPath))
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get physical package id of cpu %d", cpu.Processor))
+		}
+		// some arm64 machines report -1 if package is not described by firmware
+		if socketID > 0 {
+			cpu.PhysicalID = uint64(socketID)
+		}
+	}
+
+	if cpu.CPUMHz == "" {
+		freq, err := file.ToUint64(path.Join(cpuPath, CCPUFreqCurrentPath))
+		if err == nil {
+			// cpufreq reports frequency in kHz
+			cpu.CPUMHz = fmt.Sprintf("%.3f", float64(freq)/1000)
+		}
+	}
+}
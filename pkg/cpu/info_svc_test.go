@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const testARMCPUInfo = `processor	: 0
+BogoMIPS	: 50.00
+Features	: fp asimd evtstrm
+CPU implementer	: 0x41
+CPU architecture: 8
+CPU variant	: 0x3
+CPU part	: 0xd0c
+CPU revision	: 1
+
+processor	: 1
+BogoMIPS	: 50.00
+Features	: fp asimd evtstrm
+CPU implementer	: 0x41
+CPU architecture: 8
+CPU variant	: 0x3
+CPU part	: 0xd0c
+CPU revision	: 1
+`
+
+const testPOWERCPUInfo = `processor	: 0
+cpu		: POWER9 (raw), altivec supported
+clock		: 2300.000000MHz
+revision	: 2.2 (pvr 004e 1202)
+
+timebase	: 512000000
+platform	: PowerNV
+model		: 9006-22P
+machine		: PowerNV 9006-22P
+MMU		: Radix
+`
+
+func TestGetInfo(t *testing.T) {
+	armCPU := func(id uint64, socketID uint64) Info {
+		return Info{
+			Processor:    id,
+			VendorID:     "ARM",
+			CPUFamily:    "8",
+			Model:        "0xd0c",
+			ModelName:    "Neoverse-N1",
+			Stepping:     "r3p1",
+			CPUMHz:       "3000.000",
+			PhysicalID:   socketID,
+			Flags:        []string{"fp", "asimd", "evtstrm"},
+			BogoMIPS:     "50.00",
+			Implementer:  "0x41",
+			Architecture: "8",
+			Variant:      "0x3",
+			Part:         "0xd0c",
+			Revision:     "1",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		cpuInfo  string
+		sysfs    map[string]string
+		expected []Info
+	}{
+		{
+			name:    "arm64",
+			cpuInfo: testARMCPUInfo,
+			sysfs: map[string]string{
+				path.Join("cpu0", CTopologyPhysicalPackageIDPath): "-1\n",
+				path.Join("cpu1", CTopologyPhysicalPackageIDPath): "1\n",
+				path.Join("cpu0", CCPUFreqCurrentPath):            "3000000\n",
+				path.Join("cpu1", CCPUFreqCurrentPath):            "3000000\n",
+			},
+			expected: []Info{armCPU(0, 0), armCPU(1, 1)},
+		},
+		{
+			name:    "POWER",
+			cpuInfo: testPOWERCPUInfo,
+			expected: []Info{
+				{
+					Processor: 0,
+					VendorID:  CIBMVendor,
+					CPUFamily: "POWER9",
+					ModelName: "POWER9 (raw), altivec supported",
+					Stepping:  "2.2 (pvr 004e 1202)",
+					CPUMHz:    "2300.000000",
+					Revision:  "2.2 (pvr 004e 1202)",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		root := t.TempDir()
+		writeFile(t, root, CCPUInfoPath, test.cpuInfo)
+		for thePath, data := range test.sysfs {
+			writeFile(t, root, path.Join(CCPUDevicePath, thePath), data)
+		}
+
+		cpus, err := NewInfoSvc(printer.NewSvc(false), root).GetInfo()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(cpus, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, cpus)
+		}
+	}
+}
+
+func TestSetARMFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     Info
+		expected Info
+	}{
+		{
+			name: "unknown part of known implementer",
+			info: Info{Implementer: "0xc0", Architecture: "8", Variant: "0x0", Part: "0xfff", Revision: "2"},
+			expected: Info{
+				VendorID: "Ampere", CPUFamily: "8", Model: "0xfff", Stepping: "r0p2",
+				Implementer: "0xc0", Architecture: "8", Variant: "0x0", Part: "0xfff", Revision: "2",
+			},
+		},
+		{
+			name: "unknown implementer",
+			info: Info{Implementer: "0x99", Architecture: "8", Part: "0x001"},
+			expected: Info{
+				VendorID: "0x99", CPUFamily: "8", Model: "0x001",
+				Implementer: "0x99", Architecture: "8", Part: "0x001",
+			},
+		},
+		{
+			name:     "malformed part",
+			info:     Info{Implementer: "0x41", Part: "N1"},
+			expected: Info{Implementer: "0x41", Part: "N1"},
+		},
+	}
+
+	for _, test := range tests {
+		info := test.info
+		info.setArchFields()
+		if !reflect.DeepEqual(info, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, info)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"encoding/binary"
+	"strings"
+)
+
+const (
+	CIntelVendorID = "GenuineIntel"
+	CAMDVendorID   = "AuthenticAMD"
+	CHygonVendorID = "HygonGenuine"
+
+	CTDXGuestVendorID = "IntelTDX    "
+
+	CCapabilityAVX512   = "avx512"
+	CCapabilityAMX      = "amx"
+	CCapabilityAMXBF16  = "amx-bf16"
+	CCapabilityAMXINT8  = "amx-int8"
+	CCapabilitySGX      = "sgx"
+	CCapabilityTDX      = "tdx"
+	CCapabilityTDXGuest = "tdx-guest"
+	CCapabilitySEV      = "sev"
+	CCapabilitySEVES    = "sev-es"
+	CCapabilitySEVSNP   = "sev-snp"
+
+	CCoreTypePerformance = "core"
+	CCoreTypeEfficiency  = "atom"
+
+	cExtendedLeaf = 0x80000000
+
+	// XCR0 state components
+	cXCR0SSE      = 1 << 1
+	cXCR0AVX      = 1 << 2
+	cXCR0Opmask   = 1 << 5
+	cXCR0ZMMHi256 = 1 << 6
+	cXCR0Hi16ZMM  = 1 << 7
+	cXCR0TileCfg  = 1 << 17
+	cXCR0TileData = 1 << 18
+)
+
+// CIntelCodeNames are family 6 display models of Intel processors
+var CIntelCodeNames = map[uint32]string{
+	0x1a: "Nehalem-EP",
+	0x1e: "Nehalem",
+	0x2e: "Nehalem-EX",
+	0x2c: "Westmere-EP",
+	0x2f: "Westmere-EX",
+	0x2a: "Sandy Bridge",
+	0x2d: "Sandy Bridge-EP",
+	0x3a: "Ivy Bridge",
+	0x3e: "Ivy Bridge-EP",
+	0x3c: "Haswell",
+	0x3f: "Haswell-EP",
+	0x45: "Haswell",
+	0x46: "Haswell",
+	0x3d: "Broadwell",
+	0x47: "Broadwell",
+	0x4f: "Broadwell-EP",
+	0x56: "Broadwell-DE",
+	0x4e: "Skylake",
+	0x5e: "Skylake",
+	0x55: "Skylake-SP",
+	0x57: "Knights Landing",
+	0x85: "Knights Mill",
+	0x5c: "Goldmont",
+	0x5f: "Denverton",
+	0x86: "Snow Ridge",
+	0x8e: "Kaby Lake",
+	0x9e: "Coffee Lake",
+	0xa5: "Comet Lake",
+	0xa6: "Comet Lake",
+	0x66: "Cannon Lake",
+	0x7d: "Ice Lake",
+	0x7e: "Ice Lake",
+	0x6a: "Ice Lake-SP",
+	0x6c: "Ice Lake-D",
+	0x8c: "Tiger Lake",
+	0x8d: "Tiger Lake",
+	0xa7: "Rocket Lake",
+	0x97: "Alder Lake",
+	0x9a: "Alder Lake",
+	0xb7: "Raptor Lake",
+	0xba: "Raptor Lake",
+	0xbf: "Raptor Lake",
+	0xaa: "Meteor Lake",
+	0xac: "Meteor Lake",
+	0xbd: "Lunar Lake",
+	0xc5: "Arrow Lake",
+	0xc6: "Arrow Lake",
+	0x8f: "Sapphire Rapids",
+	0xcf: "Emerald Rapids",
+	0xad: "Granite Rapids",
+	0xae: "Granite Rapids-D",
+	0xaf: "Sierra Forest",
+	0xdd: "Clearwater Forest",
+}
+
+// Microarchitecture is a processor identification and ISA capabilities decoded from CPUID
+type Microarchitecture struct {
+	Vendor   string
+	Family   uint32
+	Model    uint32
+	Stepping uint32
+	CodeName string
+	// Level is an x86-64 microarchitecture level as defined by x86-64 psABI,
+	// 0 if processor is not x86-64
+	Level        int
+	Capabilities []string
+	Hybrid       bool
+	// CoreTypes are logical CPUs by core type on hybrid processors
+	CoreTypes map[string][]int
+}
+
+func (m *Microarchitecture) HasCapability(capability string) bool {
+	for _, c := range m.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+type cpuidFunc func(leaf, subleaf uint32) (eax, ebx, ecx, edx uint32)
+
+type xgetbvFunc func() (eax, edx uint32)
+
+// cpuidRegs are registers of CPUID leaves required to classify a processor
+type cpuidRegs struct {
+	maxLeaf    uint32
+	maxExtLeaf uint32
+	vendor     string
+
+	eax1, ecx1, edx1 uint32
+	ebx7, edx7       uint32
+	ecxExt1, edxExt1 uint32
+	eaxExt1F         uint32
+	tdxGuest         bool
+
+	xcr0 uint32
+}
+
+func readCPUIDRegs(cpuid cpuidFunc, xgetbv xgetbvFunc) *cpuidRegs {
+	maxLeaf, ebx, ecx, edx := cpuid(0, 0)
+	if maxLeaf == 0 {
+		return nil
+	}
+
+	regs := &cpuidRegs{
+		maxLeaf: maxLeaf,
+		vendor:  registersToString(ebx, edx, ecx),
+	}
+
+	regs.eax1, _, regs.ecx1, regs.edx1 = cpuid(1, 0)
+	if maxLeaf >= 7 {
+		_, regs.ebx7, _, regs.edx7 = cpuid(7, 0)
+	}
+	if maxLeaf >= 0x21 {
+		_, ebx, ecx, edx := cpuid(0x21, 0)
+		regs.tdxGuest = registersToString(ebx, edx, ecx) == CTDXGuestVendorID
+	}
+
+	regs.maxExtLeaf, _, _, _ = cpuid(cExtendedLeaf, 0)
+	if regs.maxExtLeaf >= cExtendedLeaf+1 {
+		_, _, regs.ecxExt1, regs.edxExt1 = cpuid(cExtendedLeaf+1, 0)
+	}
+	if regs.maxExtLeaf >= cExtendedLeaf+0x1f {
+		regs.eaxExt1F, _, _, _ = cpuid(cExtendedLeaf+0x1f, 0)
+	}
+
+	// XGETBV faults unless OS has set CR4.OSXSAVE
+	if bit(regs.ecx1, 27) {
+		regs.xcr0, _ = xgetbv()
+	}
+
+	return regs
+}
+
+func decodeMicroarchitecture(regs *cpuidRegs) *Microarchitecture {
+	family, model, stepping := signature(regs.eax1)
+	m := &Microarchitecture{
+		Vendor:       regs.vendor,
+		Family:       family,
+		Model:        model,
+		Stepping:     stepping,
+		Level:        x86Level(regs),
+		Capabilities: capabilities(regs),
+		Hybrid:       bit(regs.edx7, 15),
+	}
+	m.CodeName = codeName(m.Vendor, m.Family, m.Model, m.Stepping)
+
+	return m
+}
+
+// signature returns display family, model and stepping of CPUID leaf 1 EAX
+func signature(eax uint32) (uint32, uint32, uint32) {
+	stepping := eax & 0xf
+	model := (eax >> 4) & 0xf
+	family := (eax >> 8) & 0xf
+
+	if family == 0xf {
+		family += (eax >> 20) & 0xff
+	}
+	if family == 0x6 || family >= 0xf {
+		model += ((eax >> 16) & 0xf) << 4
+	}
+
+	return family, model, stepping
+}
+
+func x86Level(regs *cpuidRegs) int {
+	// long mode
+	if !bit(regs.edxExt1, 29) {
+		return 0
+	}
+
+	// CMOV, CX8, FPU, FXSR, MMX, SSE, SSE2
+	if !bits(regs.edx1, 15, 8, 0, 24, 23, 25, 26) {
+		return 0
+	}
+
+	// SSE3, SSSE3, CMPXCHG16B, SSE4.1, SSE4.2, POPCNT and LAHF/SAHF
+	if !bits(regs.ecx1, 0, 9, 13, 19, 20, 23) || !bit(regs.ecxExt1, 0) {
+		return 1
+	}
+
+	osAVX := regs.xcr0&(cXCR0SSE|cXCR0AVX) == cXCR0SSE|cXCR0AVX
+	// FMA, MOVBE, OSXSAVE, AVX, F16C, BMI1, AVX2, BMI2 and LZCNT
+	if !osAVX || !bits(regs.ecx1, 12, 22, 27, 28, 29) || !bits(regs.ebx7, 3, 5, 8) || !bit(regs.ecxExt1, 5) {
+		return 2
+	}
+
+	// AVX512F, AVX512DQ, AVX512CD, AVX512BW and AVX512VL
+	if !osAVX512(regs) || !bits(regs.ebx7, 16, 17, 28, 30, 31) {
+		return 3
+	}
+
+	return 4
+}
+
+func osAVX512(regs *cpuidRegs) bool {
+	mask := uint32(cXCR0SSE | cXCR0AVX | cXCR0Opmask | cXCR0ZMMHi256 | cXCR0Hi16ZMM)
+	return regs.xcr0&mask == mask
+}
+
+func capabilities(regs *cpuidRegs) []string {
+	capabilities := make([]string, 0)
+
+	if bit(regs.ebx7, 16) && osAVX512(regs) {
+		capabilities = append(capabilities, CCapabilityAVX512)
+	}
+
+	osAMX := regs.xcr0&(cXCR0TileCfg|cXCR0TileData) == cXCR0TileCfg|cXCR0TileData
+	if bit(regs.edx7, 24) && osAMX {
+		capabilities = append(capabilities, CCapabilityAMX)
+		if bit(regs.edx7, 22) {
+			capabilities = append(capabilities, CCapabilityAMXBF16)
+		}
+		if bit(regs.edx7, 25) {
+			capabilities = append(capabilities, CCapabilityAMXINT8)
+		}
+	}
+
+	if bit(regs.ebx7, 2) {
+		capabilities = append(capabilities, CCapabilitySGX)
+	}
+	if regs.tdxGuest {
+		capabilities = append(capabilities, CCapabilityTDXGuest)
+	}
+
+	// AMD memory encryption leaf
+	if bit(regs.eaxExt1F, 1) {
+		capabilities = append(capabilities, CCapabilitySEV)
+	}
+	if bit(regs.eaxExt1F, 3) {
+		capabilities = append(capabilities, CCapabilitySEVES)
+	}
+	if bit(regs.eaxExt1F, 4) {
+		capabilities = append(capabilities, CCapabilitySEVSNP)
+	}
+
+	return capabilities
+}
+
+func codeName(vendor string, family, model, stepping uint32) string {
+	switch vendor {
+	case CIntelVendorID:
+		if family != 0x6 {
+			return ""
+		}
+		// Cascade Lake and Cooper Lake share the model with Skylake-SP
+		if model == 0x55 {
+			switch {
+			case stepping >= 10:
+				return "Cooper Lake"
+			case stepping >= 5:
+				return "Cascade Lake"
+			}
+		}
+		return CIntelCodeNames[model]
+	case CAMDVendorID:
+		switch family {
+		case 0x17:
+			switch {
+			case model == 0x08 || model == 0x18:
+				return "Zen+"
+			case model < 0x30:
+				return "Zen"
+			default:
+				return "Zen 2"
+			}
+		case 0x19:
+			switch {
+			case model <= 0x0f, model >= 0x20 && model <= 0x5f:
+				return "Zen 3"
+			case model <= 0x1f, model >= 0x60 && model <= 0x7f, model >= 0xa0 && model <= 0xaf:
+				return "Zen 4"
+			}
+		case 0x1a:
+			return "Zen 5"
+		}
+	case CHygonVendorID:
+		if family == 0x18 {
+			return "Dhyana"
+		}
+	}
+
+	return ""
+}
+
+func registersToString(regs ...uint32) string {
+	buf := make([]byte, 4*len(regs))
+	for i, reg := range regs {
+		binary.LittleEndian.PutUint32(buf[4*i:], reg)
+	}
+	return strings.TrimRight(string(buf), "\x00")
+}
+
+func bit(reg uint32, n uint) bool {
+	return reg&(1<<n) != 0
+}
+
+func bits(reg uint32, ns ...uint) bool {
+	for _, n := range ns {
+		if !bit(reg, n) {
+			return false
+		}
+	}
+	return true
+}
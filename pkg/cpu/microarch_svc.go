@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	// CHybridCoreCPUsPath is a perf PMU of hybrid processor core type,
+	// its cpus file lists logical CPUs of that type
+	CHybridCoreCPUsPath  = "/sys/devices/cpu_%s/cpus"
+	CKVMIntelTDXPath     = "/sys/module/kvm_intel/parameters/tdx"
+	CKVMParameterEnabled = "Y"
+)
+
+type MicroarchSvc struct {
+	printer  *printer.Svc
+	basePath string
+	cpuid    cpuidFunc
+	xgetbv   xgetbvFunc
+}
+
+func NewMicroarchSvc(printer *printer.Svc, basePath string) *MicroarchSvc {
+	return &MicroarchSvc{
+		printer:  printer,
+		basePath: basePath,
+		cpuid:    cpuidex,
+		xgetbv:   xgetbv,
+	}
+}
+
+// GetMicroarchitecture decodes CPUID of the CPU it runs on,
+// so multi socket systems are expected to have the same processors in all sockets
+func (s *MicroarchSvc) GetMicroarchitecture() (*Microarchitecture, error) {
+	if !CCPUIDSupported {
+		return nil, errors.New("cpuid is not supported on this architecture")
+	}
+
+	regs := readCPUIDRegs(s.cpuid, s.xgetbv)
+	if regs == nil {
+		return nil, errors.New("cpuid returned no basic leaves")
+	}
+
+	m := decodeMicroarchitecture(regs)
+
+	// TDX host support is not enumerated by CPUID, KVM reports if it has initialized TDX module
+	if tdx, err := file.ToString(path.Join(s.basePath, CKVMIntelTDXPath)); err == nil && tdx == CKVMParameterEnabled {
+		m.Capabilities = append(m.Capabilities, CCapabilityTDX)
+	}
+
+	if m.Hybrid {
+		m.CoreTypes = s.getCoreTypes()
+	}
+
+	return m, nil
+}
+
+func (s *MicroarchSvc) getCoreTypes() map[string][]int {
+	coreTypes := make(map[string][]int)
+	for _, coreType := range []string{CCoreTypePerformance, CCoreTypeEfficiency} {
+		cpusPath := path.Join(s.basePath, fmt.Sprintf(CHybridCoreCPUsPath, coreType))
+		if _, err := os.Stat(cpusPath); err != nil {
+			continue
+		}
+
+		cpus, err := file.ToIntList(cpusPath)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get cpus of %s core type", coreType))
+			continue
+		}
+		coreTypes[coreType] = cpus
+	}
+	return coreTypes
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"reflect"
+	"testing"
+)
+
+func setBits(ns ...uint) uint32 {
+	var reg uint32
+	for _, n := range ns {
+		reg |= 1 << n
+	}
+	return reg
+}
+
+// levelRegs returns registers of processor supporting x86-64 microarchitecture level
+func levelRegs(level int) *cpuidRegs {
+	regs := &cpuidRegs{}
+	if level >= 1 {
+		regs.edxExt1 = setBits(29)
+		regs.edx1 = setBits(0, 8, 15, 23, 24, 25, 26)
+	}
+	if level >= 2 {
+		regs.ecx1 |= setBits(0, 9, 13, 19, 20, 23)
+		regs.ecxExt1 |= setBits(0)
+	}
+	if level >= 3 {
+		regs.xcr0 |= cXCR0SSE | cXCR0AVX
+		regs.ecx1 |= setBits(12, 22, 27, 28, 29)
+		regs.ebx7 |= setBits(3, 5, 8)
+		regs.ecxExt1 |= setBits(5)
+	}
+	if level >= 4 {
+		regs.xcr0 |= cXCR0Opmask | cXCR0ZMMHi256 | cXCR0Hi16ZMM
+		regs.ebx7 |= setBits(16, 17, 28, 30, 31)
+	}
+	return regs
+}
+
+func TestSignature(t *testing.T) {
+	tests := []struct {
+		name     string
+		eax      uint32
+		expected [3]uint32
+	}{
+		{name: "Sapphire Rapids", eax: 0x000806f8, expected: [3]uint32{0x6, 0x8f, 0x8}},
+		{name: "Cascade Lake", eax: 0x00050657, expected: [3]uint32{0x6, 0x55, 0x7}},
+		{name: "Genoa", eax: 0x00a10f11, expected: [3]uint32{0x19, 0x11, 0x1}},
+		{name: "Rome", eax: 0x00830f10, expected: [3]uint32{0x17, 0x31, 0x0}},
+		// extended model is used only for families 6 and 15+
+		{name: "Pentium", eax: 0x00010543, expected: [3]uint32{0x5, 0x4, 0x3}},
+	}
+
+	for _, test := range tests {
+		family, model, stepping := signature(test.eax)
+		if actual := [3]uint32{family, model, stepping}; actual != test.expected {
+			t.Errorf("%s: expected %#x, got %#x", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestX86Level(t *testing.T) {
+	for level := 0; level <= 4; level++ {
+		if actual := x86Level(levelRegs(level)); actual != level {
+			t.Errorf("expected level %d, got %d", level, actual)
+		}
+	}
+
+	// AVX2 capable processor with AVX state disabled by OS
+	regs := levelRegs(4)
+	regs.xcr0 = cXCR0SSE
+	if actual := x86Level(regs); actual != 2 {
+		t.Errorf("OS without AVX: expected level 2, got %d", actual)
+	}
+
+	// AVX-512 capable processor with ZMM state disabled by OS
+	regs = levelRegs(4)
+	regs.xcr0 = cXCR0SSE | cXCR0AVX
+	if actual := x86Level(regs); actual != 3 {
+		t.Errorf("OS without AVX-512: expected level 3, got %d", actual)
+	}
+}
+
+func TestCodeName(t *testing.T) {
+	tests := []struct {
+		vendor   string
+		family   uint32
+		model    uint32
+		stepping uint32
+		expected string
+	}{
+		{vendor: CIntelVendorID, family: 0x6, model: 0x8f, stepping: 8, expected: "Sapphire Rapids"},
+		{vendor: CIntelVendorID, family: 0x6, model: 0x55, stepping: 4, expected: "Skylake-SP"},
+		{vendor: CIntelVendorID, family: 0x6, model: 0x55, stepping: 7, expected: "Cascade Lake"},
+		{vendor: CIntelVendorID, family: 0x6, model: 0x55, stepping: 11, expected: "Cooper Lake"},
+		{vendor: CIntelVendorID, family: 0x6, model: 0x01, expected: ""},
+		{vendor: CIntelVendorID, family: 0xf, model: 0x06, expected: ""},
+		{vendor: CAMDVendorID, family: 0x17, model: 0x01, expected: "Zen"},
+		{vendor: CAMDVendorID, family: 0x17, model: 0x08, expected: "Zen+"},
+		{vendor: CAMDVendorID, family: 0x17, model: 0x31, expected: "Zen 2"},
+		{vendor: CAMDVendorID, family: 0x19, model: 0x01, expected: "Zen 3"},
+		{vendor: CAMDVendorID, family: 0x19, model: 0x11, expected: "Zen 4"},
+		{vendor: CAMDVendorID, family: 0x19, model: 0xa0, expected: "Zen 4"},
+		{vendor: CAMDVendorID, family: 0x19, model: 0x80, expected: ""},
+		{vendor: CAMDVendorID, family: 0x1a, model: 0x02, expected: "Zen 5"},
+		{vendor: CHygonVendorID, family: 0x18, model: 0x01, expected: "Dhyana"},
+		{vendor: "CentaurHauls", family: 0x6, model: 0x0f, expected: ""},
+	}
+
+	for _, test := range tests {
+		actual := codeName(test.vendor, test.family, test.model, test.stepping)
+		if actual != test.expected {
+			t.Errorf("%s %#x/%#x/%d: expected %q, got %q",
+				test.vendor, test.family, test.model, test.stepping, test.expected, actual)
+		}
+	}
+}
+
+func TestDecodeMicroarchitecture(t *testing.T) {
+	leaves := map[uint32][4]uint32{
+		// GenuineIntel
+		0x0: {0x20, 0x756e6547, 0x6c65746e, 0x49656e69},
+		0x1: {0x000806f8, 0, levelRegs(4).ecx1, levelRegs(4).edx1},
+		// AVX-512, AMX with BF16 and INT8
+		0x7:               {0, levelRegs(4).ebx7, 0, setBits(22, 24, 25)},
+		cExtendedLeaf:     {cExtendedLeaf + 8, 0, 0, 0},
+		cExtendedLeaf + 1: {0, 0, levelRegs(4).ecxExt1, levelRegs(4).edxExt1},
+	}
+	cpuid := func(leaf, subleaf uint32) (uint32, uint32, uint32, uint32) {
+		regs := leaves[leaf]
+		return regs[0], regs[1], regs[2], regs[3]
+	}
+	xgetbv := func() (uint32, uint32) {
+		return levelRegs(4).xcr0 | cXCR0TileCfg | cXCR0TileData, 0
+	}
+
+	expected := &Microarchitecture{
+		Vendor:       CIntelVendorID,
+		Family:       0x6,
+		Model:        0x8f,
+		Stepping:     8,
+		CodeName:     "Sapphire Rapids",
+		Level:        4,
+		Capabilities: []string{CCapabilityAVX512, CCapabilityAMX, CCapabilityAMXBF16, CCapabilityAMXINT8},
+	}
+
+	regs := readCPUIDRegs(cpuid, xgetbv)
+	if regs == nil {
+		t.Fatal("expected registers to be read")
+	}
+	actual := decodeMicroarchitecture(regs)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+// Power is a frequency scaling, idle and power limit configuration of processors
+type Power struct {
+	FrequencyPolicies []FrequencyPolicy
+	// Boost is a global boost state, nil if driver does not expose it
+	Boost *bool
+	// PStateDriver is intel_pstate or amd_pstate if one of them is loaded
+	PStateDriver string
+	PStateMode   string
+
+	IdleDriver   string
+	IdleGovernor string
+	// IdleStates are C-states of the first CPU, all CPUs are expected to have the same
+	IdleStates []IdleState
+
+	RAPLZones []RAPLZone
+}
+
+// FrequencyPolicy is a cpufreq policy shared by a set of CPUs, frequencies are in kHz
+type FrequencyPolicy struct {
+	ID                          int
+	CPUs                        []int
+	Driver                      string
+	Governor                    string
+	AvailableGovernors          []string
+	MinFrequency                uint64
+	MaxFrequency                uint64
+	BaseFrequency               uint64
+	ScalingMinFrequency         uint64
+	ScalingMaxFrequency         uint64
+	EnergyPerformancePreference string
+	Boost                       *bool
+}
+
+// IdleState is a cpuidle state, latency and residency are in microseconds
+type IdleState struct {
+	Name        string
+	Description string
+	Latency     uint64
+	Residency   uint64
+	Disabled    bool
+}
+
+// RAPLZone is a powercap zone of RAPL, e.g. package-0 or dram
+type RAPLZone struct {
+	ID          string
+	Name        string
+	Enabled     bool
+	Constraints []RAPLConstraint
+}
+
+// RAPLConstraint is a power limit of the zone, power is in microwatts and time window in microseconds
+type RAPLConstraint struct {
+	Name       string
+	PowerLimit uint64
+	TimeWindow uint64
+	MaxPower   uint64
+}
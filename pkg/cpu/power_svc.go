@@ -0,0 +1,390 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CCPUFreqPath  = "/sys/devices/system/cpu/cpufreq"
+	CCPUIdlePath  = "/sys/devices/system/cpu/cpuidle"
+	CPowercapPath = "/sys/class/powercap"
+
+	CCPUFreqBoostPath                       = "/boost"
+	CCPUFreqRelatedCPUsPath                 = "/related_cpus"
+	CCPUFreqDriverPath                      = "/scaling_driver"
+	CCPUFreqGovernorPath                    = "/scaling_governor"
+	CCPUFreqAvailableGovernorsPath          = "/scaling_available_governors"
+	CCPUFreqMinFrequencyPath                = "/cpuinfo_min_freq"
+	CCPUFreqMaxFrequencyPath                = "/cpuinfo_max_freq"
+	CCPUFreqBaseFrequencyPath               = "/base_frequency"
+	CCPUFreqScalingMinFrequencyPath         = "/scaling_min_freq"
+	CCPUFreqScalingMaxFrequencyPath         = "/scaling_max_freq"
+	CCPUFreqEnergyPerformancePreferencePath = "/energy_performance_preference"
+
+	CIntelPStateDriver      = "intel_pstate"
+	CAMDPStateDriver        = "amd_pstate"
+	CPStateStatusPath       = "/status"
+	CIntelPStateNoTurboPath = "/no_turbo"
+
+	CCPUIdleDriverPath      = "/current_driver"
+	CCPUIdleGovernorPath    = "/current_governor"
+	CCPUIdleGovernorROPath  = "/current_governor_ro"
+	CCPUIdleStatesPath      = "/cpu0/cpuidle"
+	CIdleStateNamePath      = "/name"
+	CIdleStateDescPath      = "/desc"
+	CIdleStateLatencyPath   = "/latency"
+	CIdleStateResidencyPath = "/residency"
+	CIdleStateDisablePath   = "/disable"
+
+	CRAPLZoneNamePath             = "/name"
+	CRAPLZoneEnabledPath          = "/enabled"
+	CRAPLConstraintNamePath       = "/constraint_%d_name"
+	CRAPLConstraintPowerLimitPath = "/constraint_%d_power_limit_uw"
+	CRAPLConstraintTimeWindowPath = "/constraint_%d_time_window_us"
+	CRAPLConstraintMaxPowerPath   = "/constraint_%d_max_power_uw"
+
+	CPolicyDirNamePattern    = "^policy([0-9]+)$"
+	CIdleStateDirNamePattern = "^state([0-9]+)$"
+	CRAPLZoneDirNamePattern  = "^intel-rapl(-mmio)?(:[0-9]+)+$"
+)
+
+var CPolicyDirNameRegexp = regexp.MustCompile(CPolicyDirNamePattern)
+var CIdleStateDirNameRegexp = regexp.MustCompile(CIdleStateDirNamePattern)
+var CRAPLZoneDirNameRegexp = regexp.MustCompile(CRAPLZoneDirNamePattern)
+
+type PowerSvc struct {
+	printer       *printer.Svc
+	cpuDevicePath string
+	cpuFreqPath   string
+	cpuIdlePath   string
+	powercapPath  string
+}
+
+func NewPowerSvc(printer *printer.Svc, basePath string) *PowerSvc {
+	return &PowerSvc{
+		printer:       printer,
+		cpuDevicePath: path.Join(basePath, CCPUDevicePath),
+		cpuFreqPath:   path.Join(basePath, CCPUFreqPath),
+		cpuIdlePath:   path.Join(basePath, CCPUIdlePath),
+		powercapPath:  path.Join(basePath, CPowercapPath),
+	}
+}
+
+// GetPower collects cpufreq policies, P-state driver mode, C-states and RAPL power limits,
+// every part is optional as it depends on loaded drivers and virtualization
+func (s *PowerSvc) GetPower() (*Power, error) {
+	power := &Power{}
+
+	policies, err := s.getFrequencyPolicies()
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to get cpufreq policies"))
+	}
+	power.FrequencyPolicies = policies
+
+	if boost, err := file.ToBool(path.Join(s.cpuFreqPath, CCPUFreqBoostPath)); err == nil {
+		power.Boost = &boost
+	}
+
+	s.setPState(power)
+
+	if driver, err := file.ToString(path.Join(s.cpuIdlePath, CCPUIdleDriverPath)); err == nil {
+		power.IdleDriver = driver
+	}
+	if governor, err := file.ToString(path.Join(s.cpuIdlePath, CCPUIdleGovernorPath)); err == nil {
+		power.IdleGovernor = governor
+	} else if governor, err := file.ToString(path.Join(s.cpuIdlePath, CCPUIdleGovernorROPath)); err == nil {
+		power.IdleGovernor = governor
+	}
+
+	idleStates, err := s.getIdleStates()
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to get cpuidle states"))
+	}
+	power.IdleStates = idleStates
+
+	zones, err := s.getRAPLZones()
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to get RAPL zones"))
+	}
+	power.RAPLZones = zones
+
+	if len(power.FrequencyPolicies) == 0 && power.IdleDriver == "" && len(power.RAPLZones) == 0 {
+		return nil, errors.New("no cpufreq, cpuidle or powercap data found")
+	}
+
+	return power, nil
+}
+
+func (s *PowerSvc) getFrequencyPolicies() ([]FrequencyPolicy, error) {
+	entries, err := os.ReadDir(s.cpuFreqPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "unable to read %s", s.cpuFreqPath)
+	}
+
+	policies := make([]FrequencyPolicy, 0)
+	for _, entry := range entries {
+		groups := CPolicyDirNameRegexp.FindStringSubmatch(entry.Name())
+		if len(groups) < 2 {
+			continue
+		}
+
+		id, err := strconv.Atoi(groups[1])
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to convert policy number %s", groups[1]))
+			continue
+		}
+
+		policy, err := s.getFrequencyPolicy(path.Join(s.cpuFreqPath, entry.Name()), id)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get cpufreq policy %d", id))
+			continue
+		}
+		policies = append(policies, *policy)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].ID < policies[j].ID
+	})
+
+	return policies, nil
+}
+
+func (s *PowerSvc) getFrequencyPolicy(policyPath string, id int) (*FrequencyPolicy, error) {
+	policy := &FrequencyPolicy{
+		ID: id,
+	}
+
+	cpus, err := file.ToIntList(path.Join(policyPath, CCPUFreqRelatedCPUsPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get related cpus")
+	}
+	policy.CPUs = cpus
+
+	driver, err := file.ToString(path.Join(policyPath, CCPUFreqDriverPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get scaling driver")
+	}
+	policy.Driver = driver
+
+	// the rest depends on the driver
+	if governor, err := file.ToString(path.Join(policyPath, CCPUFreqGovernorPath)); err == nil {
+		policy.Governor = governor
+	}
+	if governors, err := file.ToString(path.Join(policyPath, CCPUFreqAvailableGovernorsPath)); err == nil {
+		policy.AvailableGovernors = strings.Fields(governors)
+	}
+	if freq, err := file.ToUint64(path.Join(policyPath, CCPUFreqMinFrequencyPath)); err == nil {
+		policy.MinFrequency = freq
+	}
+	if freq, err := file.ToUint64(path.Join(policyPath, CCPUFreqMaxFrequencyPath)); err == nil {
+		policy.MaxFrequency = freq
+	}
+	if freq, err := file.ToUint64(path.Join(policyPath, CCPUFreqBaseFrequencyPath)); err == nil {
+		policy.BaseFrequency = freq
+	}
+	if freq, err := file.ToUint64(path.Join(policyPath, CCPUFreqScalingMinFrequencyPath)); err == nil {
+		policy.ScalingMinFrequency = freq
+	}
+	if freq, err := file.ToUint64(path.Join(policyPath, CCPUFreqScalingMaxFrequencyPath)); err == nil {
+		policy.ScalingMaxFrequency = freq
+	}
+	if epp, err := file.ToString(path.Join(policyPath, CCPUFreqEnergyPerformancePreferencePath)); err == nil {
+		policy.EnergyPerformancePreference = epp
+	}
+	if boost, err := file.ToBool(path.Join(policyPath, CCPUFreqBoostPath)); err == nil {
+		policy.Boost = &boost
+	}
+
+	return policy, nil
+}
+
+func (s *PowerSvc) setPState(power *Power) {
+	for _, driver := range []string{CIntelPStateDriver, CAMDPStateDriver} {
+		driverPath := path.Join(s.cpuDevicePath, driver)
+		status, err := file.ToString(path.Join(driverPath, CPStateStatusPath))
+		if err != nil {
+			continue
+		}
+
+		power.PStateDriver = driver
+		power.PStateMode = status
+
+		// intel_pstate does not expose cpufreq boost, turbo is disabled with no_turbo instead
+		if power.Boost != nil {
+			return
+		}
+		if noTurbo, err := file.ToBool(path.Join(driverPath, CIntelPStateNoTurboPath)); err == nil {
+			boost := !noTurbo
+			power.Boost = &boost
+		}
+		return
+	}
+}
+
+func (s *PowerSvc) getIdleStates() ([]IdleState, error) {
+	statesPath := path.Join(s.cpuDevicePath, CCPUIdleStatesPath)
+	entries, err := os.ReadDir(statesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "unable to read %s", statesPath)
+	}
+
+	type indexedState struct {
+		index int
+		state IdleState
+	}
+
+	indexed := make([]indexedState, 0)
+	for _, entry := range entries {
+		groups := CIdleStateDirNameRegexp.FindStringSubmatch(entry.Name())
+		if len(groups) < 2 {
+			continue
+		}
+
+		index, err := strconv.Atoi(groups[1])
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to convert idle state number %s", groups[1]))
+			continue
+		}
+
+		state, err := getIdleState(path.Join(statesPath, entry.Name()))
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get idle state %d", index))
+			continue
+		}
+		indexed = append(indexed, indexedState{index: index, state: *state})
+	}
+
+	sort.Slice(indexed, func(i, j int) bool {
+		return indexed[i].index < indexed[j].index
+	})
+
+	states := make([]IdleState, 0, len(indexed))
+	for _, state := range indexed {
+		states = append(states, state.state)
+	}
+
+	return states, nil
+}
+
+func getIdleState(statePath string) (*IdleState, error) {
+	name, err := file.ToString(path.Join(statePath, CIdleStateNamePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get name")
+	}
+
+	latency, err := file.ToUint64(path.Join(statePath, CIdleStateLatencyPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get latency")
+	}
+
+	residency, err := file.ToUint64(path.Join(statePath, CIdleStateResidencyPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get residency")
+	}
+
+	state := &IdleState{
+		Name:      name,
+		Latency:   latency,
+		Residency: residency,
+	}
+
+	if desc, err := file.ToString(path.Join(statePath, CIdleStateDescPath)); err == nil {
+		state.Description = desc
+	}
+	if disabled, err := file.ToBool(path.Join(statePath, CIdleStateDisablePath)); err == nil {
+		state.Disabled = disabled
+	}
+
+	return state, nil
+}
+
+func (s *PowerSvc) getRAPLZones() ([]RAPLZone, error) {
+	entries, err := os.ReadDir(s.powercapPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "unable to read %s", s.powercapPath)
+	}
+
+	zones := make([]RAPLZone, 0)
+	for _, entry := range entries {
+		if !CRAPLZoneDirNameRegexp.MatchString(entry.Name()) {
+			continue
+		}
+
+		zone, err := getRAPLZone(path.Join(s.powercapPath, entry.Name()), entry.Name())
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get RAPL zone %s", entry.Name()))
+			continue
+		}
+		zones = append(zones, *zone)
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].ID < zones[j].ID
+	})
+
+	return zones, nil
+}
+
+func getRAPLZone(zonePath string, id string) (*RAPLZone, error) {
+	name, err := file.ToString(path.Join(zonePath, CRAPLZoneNamePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get name")
+	}
+
+	zone := &RAPLZone{
+		ID:          id,
+		Name:        name,
+		Constraints: make([]RAPLConstraint, 0),
+	}
+
+	if enabled, err := file.ToBool(path.Join(zonePath, CRAPLZoneEnabledPath)); err == nil {
+		zone.Enabled = enabled
+	}
+
+	// constraints are numbered from 0 without gaps
+	for i := 0; ; i++ {
+		name, err := file.ToString(path.Join(zonePath, fmt.Sprintf(CRAPLConstraintNamePath, i)))
+		if err != nil {
+			break
+		}
+
+		constraint := RAPLConstraint{
+			Name: name,
+		}
+		if limit, err := file.ToUint64(path.Join(zonePath, fmt.Sprintf(CRAPLConstraintPowerLimitPath, i))); err == nil {
+			constraint.PowerLimit = limit
+		}
+		if window, err := file.ToUint64(path.Join(zonePath, fmt.Sprintf(CRAPLConstraintTimeWindowPath, i))); err == nil {
+			constraint.TimeWindow = window
+		}
+		if maxPower, err := file.ToUint64(path.Join(zonePath, fmt.Sprintf(CRAPLConstraintMaxPowerPath, i))); err == nil {
+			constraint.MaxPower = maxPower
+		}
+		zone.Constraints = append(zone.Constraints, constraint)
+	}
+
+	return zone, nil
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+// CacheType is a type of the cache as sysfs reports it
+type CacheType string
+
+const (
+	CDataCacheType        CacheType = "Data"
+	CInstructionCacheType CacheType = "Instruction"
+	CUnifiedCacheType     CacheType = "Unified"
+)
+
+type Cache struct {
+	Level int
+	Type  CacheType
+	// Size is in bytes
+	Size                uint64
+	CoherencyLineSize   uint64
+	WaysOfAssociativity uint64
+	// SharedCPUs are logical CPUs sharing the cache
+	SharedCPUs []int
+}
+
+type Core struct {
+	ID int
+	// ClusterID groups cores sharing L2 on some arm64 CPUs, -1 if not reported
+	ClusterID int
+	// Threads are logical CPUs of the core, more than one means SMT
+	Threads []int
+	// Caches are caches private to the core, usually L1 and L2
+	Caches []Cache
+}
+
+type Die struct {
+	ID    int
+	Cores []Core
+	// Caches are caches shared by cores of the die, e.g. L3 of the chiplet
+	Caches []Cache
+}
+
+type Socket struct {
+	ID   int
+	Dies []Die
+	// Caches are caches shared across dies of the socket
+	Caches []Cache
+}
+
+type Topology struct {
+	Sockets []Socket
+}
+
+// CoreCount returns number of physical cores in the socket
+func (s *Socket) CoreCount() int {
+	count := 0
+	for _, die := range s.Dies {
+		count += len(die.Cores)
+	}
+	return count
+}
+
+// ThreadCount returns number of logical CPUs in the socket
+func (s *Socket) ThreadCount() int {
+	count := 0
+	for _, die := range s.Dies {
+		for _, core := range die.Cores {
+			count += len(core.Threads)
+		}
+	}
+	return count
+}
+
+// CacheSize returns total size of caches of the given level in the socket,
+// both data and instruction caches are counted for L1
+func (s *Socket) CacheSize(level int) uint64 {
+	var size uint64
+	add := func(caches []Cache) {
+		for _, cache := range caches {
+			if cache.Level == level {
+				size += cache.Size
+			}
+		}
+	}
+
+	add(s.Caches)
+	for _, die := range s.Dies {
+		add(die.Caches)
+		for _, core := range die.Cores {
+			add(core.Caches)
+		}
+	}
+
+	return size
+}
@@ -0,0 +1,394 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CCPUDevicePath = "/sys/devices/system/cpu"
+
+	CTopologyPhysicalPackageIDPath = "/topology/physical_package_id"
+	CTopologyDieIDPath             = "/topology/die_id"
+	CTopologyClusterIDPath         = "/topology/cluster_id"
+	CTopologyCoreIDPath            = "/topology/core_id"
+	CTopologyThreadSiblingsPath    = "/topology/thread_siblings_list"
+
+	CCachePath                    = "/cache"
+	CCacheLevelPath               = "/level"
+	CCacheTypePath                = "/type"
+	CCacheSizePath                = "/size"
+	CCacheCoherencyLineSizePath   = "/coherency_line_size"
+	CCacheWaysOfAssociativityPath = "/ways_of_associativity"
+	CCacheSharedCPUListPath       = "/shared_cpu_list"
+
+	CCPUDeviceDirNamePattern  = "^cpu([0-9]+)$"
+	CCacheIndexDirNamePattern = "^index[0-9]+$"
+	CNoClusterID              = -1
+)
+
+var CCPUDeviceDirNameRegexp = regexp.MustCompile(CCPUDeviceDirNamePattern)
+var CCacheIndexDirNameRegexp = regexp.MustCompile(CCacheIndexDirNamePattern)
+
+type TopologySvc struct {
+	printer       *printer.Svc
+	cpuDevicePath string
+}
+
+func NewTopologySvc(printer *printer.Svc, basePath string) *TopologySvc {
+	return &TopologySvc{
+		printer:       printer,
+		cpuDevicePath: path.Join(basePath, CCPUDevicePath),
+	}
+}
+
+// logicalCPU is a topology of a single logical CPU as sysfs reports it
+type logicalCPU struct {
+	id        int
+	socketID  int
+	dieID     int
+	clusterID int
+	coreID    int
+	caches    []Cache
+}
+
+// GetTopology builds socket, die, core and thread tree of online CPUs,
+// caches are attached to the narrowest node containing all CPUs sharing them
+func (s *TopologySvc) GetTopology() (*Topology, error) {
+	entries, err := os.ReadDir(s.cpuDevicePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read cpu devices from %s", s.cpuDevicePath)
+	}
+
+	cpus := make([]logicalCPU, 0)
+	for _, entry := range entries {
+		groups := CCPUDeviceDirNameRegexp.FindStringSubmatch(entry.Name())
+		if len(groups) < 2 {
+			continue
+		}
+
+		id, err := strconv.Atoi(groups[1])
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to convert cpu number %s", groups[1]))
+			continue
+		}
+
+		cpuPath := path.Join(s.cpuDevicePath, entry.Name())
+		// offline CPUs have no topology
+		if _, err := os.Stat(path.Join(cpuPath, CTopologyCoreIDPath)); err != nil {
+			continue
+		}
+
+		cpu, err := s.getLogicalCPU(cpuPath, id)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get topology of %s", cpuPath))
+			continue
+		}
+		cpus = append(cpus, *cpu)
+	}
+
+	if len(cpus) == 0 {
+		return nil, errors.Errorf("no cpu topology found in %s", s.cpuDevicePath)
+	}
+
+	return buildTopology(cpus), nil
+}
+
+func (s *TopologySvc) getLogicalCPU(cpuPath string, id int) (*logicalCPU, error) {
+	cpu := &logicalCPU{
+		id:        id,
+		clusterID: CNoClusterID,
+	}
+
+	socketID, err := file.ToInt(path.Join(cpuPath, CTopologyPhysicalPackageIDPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get physical package id")
+	}
+	// some arm64 machines report -1 if package is not described by firmware
+	if socketID > 0 {
+		cpu.socketID = socketID
+	}
+
+	coreID, err := file.ToInt(path.Join(cpuPath, CTopologyCoreIDPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get core id")
+	}
+	cpu.coreID = coreID
+
+	// die and cluster ids are exposed only by recent kernels
+	if dieID, err := file.ToInt(path.Join(cpuPath, CTopologyDieIDPath)); err == nil && dieID > 0 {
+		cpu.dieID = dieID
+	}
+	if clusterID, err := file.ToInt(path.Join(cpuPath, CTopologyClusterIDPath)); err == nil {
+		cpu.clusterID = clusterID
+	}
+
+	caches, err := s.getCaches(path.Join(cpuPath, CCachePath))
+	if err != nil {
+		s.printer.VErr(errors.Wrapf(err, "unable to get caches of cpu %d", id))
+	}
+	cpu.caches = caches
+
+	return cpu, nil
+}
+
+func (s *TopologySvc) getCaches(cachePath string) ([]Cache, error) {
+	caches := make([]Cache, 0)
+
+	entries, err := os.ReadDir(cachePath)
+	if os.IsNotExist(err) {
+		return caches, nil
+	}
+	if err != nil {
+		return caches, errors.Wrapf(err, "unable to read caches from %s", cachePath)
+	}
+
+	for _, entry := range entries {
+		if !CCacheIndexDirNameRegexp.MatchString(entry.Name()) {
+			continue
+		}
+
+		indexPath := path.Join(cachePath, entry.Name())
+		cache, err := getCache(indexPath)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get cache %s", indexPath))
+			continue
+		}
+		caches = append(caches, *cache)
+	}
+
+	return caches, nil
+}
+
+func getCache(indexPath string) (*Cache, error) {
+	level, err := file.ToInt(path.Join(indexPath, CCacheLevelPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get level")
+	}
+	cacheType, err := file.ToString(path.Join(indexPath, CCacheTypePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get type")
+	}
+	sharedCPUs, err := file.ToIntList(path.Join(indexPath, CCacheSharedCPUListPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get shared cpu list")
+	}
+
+	cache := &Cache{
+		Level:      level,
+		Type:       CacheType(cacheType),
+		SharedCPUs: sharedCPUs,
+	}
+
+	// size and geometry are not reported by some arm64 firmware
+	if size, err := file.ToString(path.Join(indexPath, CCacheSizePath)); err == nil {
+		cache.Size, err = parseCacheSize(size)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse size")
+		}
+	}
+	if lineSize, err := file.ToUint64(path.Join(indexPath, CCacheCoherencyLineSizePath)); err == nil {
+		cache.CoherencyLineSize = lineSize
+	}
+	if ways, err := file.ToUint64(path.Join(indexPath, CCacheWaysOfAssociativityPath)); err == nil {
+		cache.WaysOfAssociativity = ways
+	}
+
+	return cache, nil
+}
+
+// parseCacheSize parses size like 32K or 16M into bytes
+func parseCacheSize(str string) (uint64, error) {
+	multiplier := uint64(1)
+	switch {
+	case strings.HasSuffix(str, "K"):
+		multiplier = 1024
+	case strings.HasSuffix(str, "M"):
+		multiplier = 1024 * 1024
+	case strings.HasSuffix(str, "G"):
+		multiplier = 1024 * 1024 * 1024
+	}
+
+	num, err := strconv.ParseUint(strings.TrimRight(str, "KMG"), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to convert %s to uint", str)
+	}
+
+	return num * multiplier, nil
+}
+
+type coreKey struct {
+	clusterID int
+	coreID    int
+}
+
+func buildTopology(cpus []logicalCPU) *Topology {
+	sort.Slice(cpus, func(i, j int) bool {
+		return cpus[i].id < cpus[j].id
+	})
+
+	sockets := make(map[int]map[int]map[coreKey]*Core)
+	// the same cache is listed by every CPU sharing it
+	caches := make(map[string]Cache)
+	cpuByID := make(map[int]logicalCPU, len(cpus))
+
+	for _, cpu := range cpus {
+		cpuByID[cpu.id] = cpu
+
+		dies, ok := sockets[cpu.socketID]
+		if !ok {
+			dies = make(map[int]map[coreKey]*Core)
+			sockets[cpu.socketID] = dies
+		}
+		cores, ok := dies[cpu.dieID]
+		if !ok {
+			cores = make(map[coreKey]*Core)
+			dies[cpu.dieID] = cores
+		}
+		key := coreKey{clusterID: cpu.clusterID, coreID: cpu.coreID}
+		core, ok := cores[key]
+		if !ok {
+			core = &Core{
+				ID:        cpu.coreID,
+				ClusterID: cpu.clusterID,
+				Threads:   []int{},
+				Caches:    []Cache{},
+			}
+			cores[key] = core
+		}
+		core.Threads = append(core.Threads, cpu.id)
+
+		for _, cache := range cpu.caches {
+			caches[cacheKey(&cache)] = cache
+		}
+	}
+
+	topology := &Topology{
+		Sockets: make([]Socket, 0, len(sockets)),
+	}
+	for socketID, dies := range sockets {
+		socket := Socket{
+			ID:     socketID,
+			Dies:   make([]Die, 0, len(dies)),
+			Caches: []Cache{},
+		}
+		for dieID, cores := range dies {
+			die := Die{
+				ID:     dieID,
+				Cores:  make([]Core, 0, len(cores)),
+				Caches: []Cache{},
+			}
+			for _, core := range cores {
+				die.Cores = append(die.Cores, *core)
+			}
+			sort.Slice(die.Cores, func(i, j int) bool {
+				return die.Cores[i].Threads[0] < die.Cores[j].Threads[0]
+			})
+			socket.Dies = append(socket.Dies, die)
+		}
+		sort.Slice(socket.Dies, func(i, j int) bool {
+			return socket.Dies[i].ID < socket.Dies[j].ID
+		})
+		topology.Sockets = append(topology.Sockets, socket)
+	}
+	sort.Slice(topology.Sockets, func(i, j int) bool {
+		return topology.Sockets[i].ID < topology.Sockets[j].ID
+	})
+
+	keys := make([]string, 0, len(caches))
+	for key := range caches {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		topology.attachCache(caches[key], cpuByID)
+	}
+
+	return topology
+}
+
+// attachCache attaches cache to the core, die or socket of its first CPU,
+// depending on which of them contains all CPUs sharing the cache
+func (t *Topology) attachCache(cache Cache, cpuByID map[int]logicalCPU) {
+	// shared list also contains offline CPUs, which are not in topology
+	online := make([]int, 0, len(cache.SharedCPUs))
+	for _, id := range cache.SharedCPUs {
+		if _, ok := cpuByID[id]; ok {
+			online = append(online, id)
+		}
+	}
+	if len(online) == 0 {
+		return
+	}
+	first := cpuByID[online[0]]
+
+	for i := range t.Sockets {
+		socket := &t.Sockets[i]
+		if socket.ID != first.socketID {
+			continue
+		}
+		for j := range socket.Dies {
+			die := &socket.Dies[j]
+			if die.ID != first.dieID {
+				continue
+			}
+			for k := range die.Cores {
+				core := &die.Cores[k]
+				if core.ID != first.coreID || core.ClusterID != first.clusterID {
+					continue
+				}
+				if containsAll(core.Threads, online) {
+					core.Caches = append(core.Caches, cache)
+					return
+				}
+			}
+			if containsAll(die.threads(), online) {
+				die.Caches = append(die.Caches, cache)
+				return
+			}
+		}
+		socket.Caches = append(socket.Caches, cache)
+		return
+	}
+}
+
+func (d *Die) threads() []int {
+	threads := make([]int, 0)
+	for _, core := range d.Cores {
+		threads = append(threads, core.Threads...)
+	}
+	return threads
+}
+
+func containsAll(set []int, subset []int) bool {
+	members := make(map[int]struct{}, len(set))
+	for _, id := range set {
+		members[id] = struct{}{}
+	}
+	for _, id := range subset {
+		if _, ok := members[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func cacheKey(cache *Cache) string {
+	ids := make([]string, 0, len(cache.SharedCPUs))
+	for _, id := range cache.SharedCPUs {
+		ids = append(ids, strconv.Itoa(id))
+	}
+	return strconv.Itoa(cache.Level) + string(cache.Type) + ":" + strings.Join(ids, ",")
+}
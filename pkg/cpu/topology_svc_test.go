@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func writeFile(t *testing.T, root string, thePath string, data string) {
+	t.Helper()
+	fullPath := path.Join(root, thePath)
+	if err := os.MkdirAll(path.Dir(fullPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseCacheSize(t *testing.T) {
+	tests := []struct {
+		str       string
+		expected  uint64
+		expectErr bool
+	}{
+		{str: "48K", expected: 48 * 1024},
+		{str: "2048K", expected: 2 * 1024 * 1024},
+		{str: "32M", expected: 32 * 1024 * 1024},
+		{str: "1G", expected: 1024 * 1024 * 1024},
+		{str: "512", expected: 512},
+		{str: "", expectErr: true},
+		{str: "1.5M", expectErr: true},
+	}
+
+	for _, test := range tests {
+		size, err := parseCacheSize(test.str)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %d", test.str, size)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.str, err)
+			continue
+		}
+		if size != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.str, test.expected, size)
+		}
+	}
+}
+
+func TestBuildTopology(t *testing.T) {
+	l1Core0 := Cache{Level: 1, Type: CDataCacheType, Size: 48 * 1024, SharedCPUs: []int{0, 2}}
+	l1Core1 := Cache{Level: 1, Type: CDataCacheType, Size: 48 * 1024, SharedCPUs: []int{1}}
+	l2Core1 := Cache{Level: 2, Type: CUnifiedCacheType, Size: 1024 * 1024, SharedCPUs: []int{1}}
+	// spans both dies, CPU 5 is offline
+	l3 := Cache{Level: 3, Type: CUnifiedCacheType, Size: 32 * 1024 * 1024, SharedCPUs: []int{0, 1, 2, 5}}
+
+	cpus := []logicalCPU{
+		{id: 2, dieID: 0, coreID: 0, clusterID: CNoClusterID, caches: []Cache{l1Core0, l3}},
+		{id: 1, dieID: 1, coreID: 0, clusterID: CNoClusterID, caches: []Cache{l1Core1, l2Core1, l3}},
+		{id: 0, dieID: 0, coreID: 0, clusterID: CNoClusterID, caches: []Cache{l1Core0, l3}},
+		{id: 3, socketID: 1, coreID: 4, clusterID: 1, caches: []Cache{}},
+	}
+
+	expected := &Topology{
+		Sockets: []Socket{
+			{
+				ID: 0,
+				Dies: []Die{
+					{
+						ID: 0,
+						Cores: []Core{
+							{ID: 0, ClusterID: CNoClusterID, Threads: []int{0, 2}, Caches: []Cache{l1Core0}},
+						},
+						Caches: []Cache{},
+					},
+					{
+						ID: 1,
+						Cores: []Core{
+							{ID: 0, ClusterID: CNoClusterID, Threads: []int{1}, Caches: []Cache{l1Core1, l2Core1}},
+						},
+						Caches: []Cache{},
+					},
+				},
+				Caches: []Cache{l3},
+			},
+			{
+				ID: 1,
+				Dies: []Die{
+					{
+						ID: 0,
+						Cores: []Core{
+							{ID: 4, ClusterID: 1, Threads: []int{3}, Caches: []Cache{}},
+						},
+						Caches: []Cache{},
+					},
+				},
+				Caches: []Cache{},
+			},
+		},
+	}
+
+	topology := buildTopology(cpus)
+	if !reflect.DeepEqual(topology, expected) {
+		t.Errorf("expected %+v, got %+v", expected, topology)
+	}
+
+	socket := &topology.Sockets[0]
+	if socket.CoreCount() != 2 || socket.ThreadCount() != 3 || socket.CacheSize(3) != l3.Size {
+		t.Errorf("expected 2 cores, 3 threads and %d of L3, got %d, %d and %d",
+			l3.Size, socket.CoreCount(), socket.ThreadCount(), socket.CacheSize(3))
+	}
+}
+
+func TestGetTopology(t *testing.T) {
+	root := t.TempDir()
+	cpuPath := path.Join(CCPUDevicePath, "cpu0")
+	// package is not described by firmware
+	writeFile(t, root, path.Join(cpuPath, CTopologyPhysicalPackageIDPath), "-1\n")
+	writeFile(t, root, path.Join(cpuPath, CTopologyCoreIDPath), "0\n")
+	writeFile(t, root, path.Join(cpuPath, CTopologyClusterIDPath), "0\n")
+	indexPath := path.Join(cpuPath, CCachePath, "index0")
+	writeFile(t, root, path.Join(indexPath, CCacheLevelPath), "1\n")
+	writeFile(t, root, path.Join(indexPath, CCacheTypePath), "Data\n")
+	writeFile(t, root, path.Join(indexPath, CCacheSharedCPUListPath), "0\n")
+	writeFile(t, root, path.Join(indexPath, CCacheSizePath), "64K\n")
+	// offline CPU has no topology
+	writeFile(t, root, path.Join(CCPUDevicePath, "cpu1", "online"), "0\n")
+
+	topology, err := NewTopologySvc(printer.NewSvc(false), root).GetTopology()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Topology{
+		Sockets: []Socket{
+			{
+				ID: 0,
+				Dies: []Die{
+					{
+						ID: 0,
+						Cores: []Core{
+							{
+								ID:      0,
+								Threads: []int{0},
+								Caches: []Cache{
+									{Level: 1, Type: CDataCacheType, Size: 64 * 1024, SharedCPUs: []int{0}},
+								},
+							},
+						},
+						Caches: []Cache{},
+					},
+				},
+				Caches: []Cache{},
+			},
+		},
+	}
+	if !reflect.DeepEqual(topology, expected) {
+		t.Errorf("expected %+v, got %+v", expected, topology)
+	}
+}
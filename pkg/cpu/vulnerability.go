@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"strings"
+)
+
+type VulnerabilityStatus string
+
+const (
+	CVulnerabilityNotAffected VulnerabilityStatus = "NotAffected"
+	CVulnerabilityMitigated   VulnerabilityStatus = "Mitigated"
+	CVulnerabilityVulnerable  VulnerabilityStatus = "Vulnerable"
+	CVulnerabilityUnknown     VulnerabilityStatus = "Unknown"
+
+	CVulnerabilityNotAffectedPrefix = "Not affected"
+	CVulnerabilityMitigationPrefix  = "Mitigation"
+	CVulnerabilityVulnerablePrefix  = "Vulnerable"
+	// e.g. "Processor vulnerable" of srbds and mmio_stale_data
+	CVulnerabilityVulnerableWord = "vulnerable"
+)
+
+// Vulnerability is a CPU vulnerability as kernel reports it in sysfs
+type Vulnerability struct {
+	// Name is a sysfs file name, e.g. spectre_v2
+	Name   string
+	Status VulnerabilityStatus
+	// Vulnerable is also set for partial mitigations,
+	// e.g. "Mitigation: Clear CPU buffers; SMT vulnerable" or "...; BHI: Vulnerable"
+	Vulnerable bool
+	// Text is a status line as it is, including mitigation details
+	Text string
+}
+
+func parseVulnerability(name string, text string) Vulnerability {
+	vulnerability := Vulnerability{
+		Name: name,
+		Text: text,
+	}
+
+	switch {
+	case strings.HasPrefix(text, CVulnerabilityNotAffectedPrefix):
+		vulnerability.Status = CVulnerabilityNotAffected
+	case strings.HasPrefix(text, CVulnerabilityMitigationPrefix):
+		vulnerability.Status = CVulnerabilityMitigated
+	case strings.HasPrefix(text, CVulnerabilityVulnerablePrefix),
+		strings.Contains(strings.ToLower(text), CVulnerabilityVulnerableWord):
+		vulnerability.Status = CVulnerabilityVulnerable
+	default:
+		vulnerability.Status = CVulnerabilityUnknown
+	}
+
+	vulnerability.Vulnerable = vulnerability.Status == CVulnerabilityVulnerable ||
+		strings.Contains(strings.ToLower(text), CVulnerabilityVulnerableWord)
+
+	return vulnerability
+}
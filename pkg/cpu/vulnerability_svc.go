@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"os"
+	"path"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CVulnerabilitiesPath = "/sys/devices/system/cpu/vulnerabilities"
+)
+
+type VulnerabilitySvc struct {
+	printer             *printer.Svc
+	vulnerabilitiesPath string
+}
+
+func NewVulnerabilitySvc(printer *printer.Svc, basePath string) *VulnerabilitySvc {
+	return &VulnerabilitySvc{
+		printer:             printer,
+		vulnerabilitiesPath: path.Join(basePath, CVulnerabilitiesPath),
+	}
+}
+
+// GetVulnerabilities reads status of every vulnerability kernel knows about,
+// the list depends on kernel version, e.g. old_microcode is reported since 6.15
+func (s *VulnerabilitySvc) GetVulnerabilities() ([]Vulnerability, error) {
+	entries, err := os.ReadDir(s.vulnerabilitiesPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read %s", s.vulnerabilitiesPath)
+	}
+
+	vulnerabilities := make([]Vulnerability, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		text, err := file.ToString(path.Join(s.vulnerabilitiesPath, entry.Name()))
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get status of %s", entry.Name()))
+			continue
+		}
+		vulnerabilities = append(vulnerabilities, parseVulnerability(entry.Name(), text))
+	}
+
+	sort.Slice(vulnerabilities, func(i, j int) bool {
+		return vulnerabilities[i].Name < vulnerabilities[j].Name
+	})
+
+	return vulnerabilities, nil
+}
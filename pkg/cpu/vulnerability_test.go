@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cpu
+
+import (
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func TestParseVulnerability(t *testing.T) {
+	tests := []struct {
+		text               string
+		expectedStatus     VulnerabilityStatus
+		expectedVulnerable bool
+	}{
+		{text: "Not affected", expectedStatus: CVulnerabilityNotAffected},
+		{text: "Mitigation: PTE Inversion; VMX: conditional cache flushes, SMT disabled", expectedStatus: CVulnerabilityMitigated},
+		{text: "Mitigation: Clear CPU buffers; SMT vulnerable", expectedStatus: CVulnerabilityMitigated, expectedVulnerable: true},
+		{
+			text:               "Mitigation: Enhanced / Automatic IBRS; IBPB: conditional; RSB filling; BHI: Vulnerable",
+			expectedStatus:     CVulnerabilityMitigated,
+			expectedVulnerable: true,
+		},
+		{text: "Vulnerable: Clear CPU buffers attempted, no microcode", expectedStatus: CVulnerabilityVulnerable, expectedVulnerable: true},
+		{text: "Vulnerable", expectedStatus: CVulnerabilityVulnerable, expectedVulnerable: true},
+		{text: "Processor vulnerable", expectedStatus: CVulnerabilityVulnerable, expectedVulnerable: true},
+		{text: "Unknown: No mitigations", expectedStatus: CVulnerabilityUnknown},
+	}
+
+	for _, test := range tests {
+		vulnerability := parseVulnerability("test", test.text)
+		expected := Vulnerability{
+			Name:       "test",
+			Status:     test.expectedStatus,
+			Vulnerable: test.expectedVulnerable,
+			Text:       test.text,
+		}
+		if !reflect.DeepEqual(vulnerability, expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.text, expected, vulnerability)
+		}
+	}
+}
+
+func TestGetVulnerabilities(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, path.Join(CVulnerabilitiesPath, "spectre_v1"), "Mitigation: usercopy/swapgs barriers and __user pointer sanitization\n")
+	writeFile(t, root, path.Join(CVulnerabilitiesPath, "meltdown"), "Not affected\n")
+
+	vulnerabilities, err := NewVulnerabilitySvc(printer.NewSvc(false), root).GetVulnerabilities()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Vulnerability{
+		{Name: "meltdown", Status: CVulnerabilityNotAffected, Text: "Not affected"},
+		{
+			Name:   "spectre_v1",
+			Status: CVulnerabilityMitigated,
+			Text:   "Mitigation: usercopy/swapgs barriers and __user pointer sanitization",
+		},
+	}
+	if !reflect.DeepEqual(vulnerabilities, expected) {
+		t.Errorf("expected %+v, got %+v", expected, vulnerabilities)
+	}
+}
@@ -4,19 +4,27 @@
 package crd
 
 import (
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	metalv1alpha1 "github.com/ironcore-dev/metal/api/v1alpha1"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
+	"github.com/onmetal/inventory/pkg/cxl"
+	"github.com/onmetal/inventory/pkg/edac"
 	"github.com/onmetal/inventory/pkg/inventory"
 	"github.com/onmetal/inventory/pkg/lldp/frame"
+	"github.com/onmetal/inventory/pkg/mem"
 	"github.com/onmetal/inventory/pkg/netlink"
+	"github.com/onmetal/inventory/pkg/nvdimm"
 	"github.com/onmetal/inventory/pkg/printer"
-	"github.com/onmetal/inventory/pkg/utils"
+	"github.com/onmetal/inventory/pkg/version"
 )
 
 const (
@@ -30,28 +38,68 @@ var CNICPrefixesToExclude = []string{
 }
 
 type BuilderSvc struct {
-	printer *printer.Svc
+	printer          *printer.Svc
+	oemStringRules   []OEMStringRule
+	identityResolver *IdentityResolver
+	ceThreshold      uint64
 }
 
 func NewBuilderSvc(printer *printer.Svc) *BuilderSvc {
 	return &BuilderSvc{
-		printer: printer,
+		printer:          printer,
+		identityResolver: NewIdentityResolver(CDefaultIdentitySources, []string{}),
+		ceThreshold:      edac.CDefaultCEThreshold,
 	}
 }
 
+// SetCEThreshold sets number of correctable errors DIMM is considered degraded at, 0 disables it
+func (s *BuilderSvc) SetCEThreshold(threshold uint64) {
+	s.ceThreshold = threshold
+}
+
+// SetIdentityResolver sets resolver choosing inventory name
+func (s *BuilderSvc) SetIdentityResolver(resolver *IdentityResolver) {
+	s.identityResolver = resolver
+}
+
+// SetOEMStringRules sets rules promoting OEM strings into labels and annotations
+func (s *BuilderSvc) SetOEMStringRules(rules []OEMStringRule) {
+	s.oemStringRules = rules
+}
+
 func (s *BuilderSvc) Build(inv *inventory.Inventory) (*metalv1alpha1.Inventory, error) {
 	setters := []func(*metalv1alpha1.Inventory, *inventory.Inventory){
 		s.SetSystem,
+		s.SetBIOS,
+		s.SetBoards,
+		s.SetChassis,
+		s.SetPowerSupplies,
+		s.SetOEMStrings,
 		s.SetIPMIs,
 		s.SetBlocks,
 		s.SetMemory,
+		s.SetMemoryDevices,
+		s.SetEDAC,
 		s.SetCPUs,
+		s.SetProcessors,
+		s.SetCPUTopology,
+		s.SetCPUMicroarch,
+		s.SetCPUPower,
+		s.SetCPUVulnerabilities,
 		s.SetNUMANodes,
+		s.SetHugePages,
+		s.SetMemoryBlocks,
+		s.SetNVDIMM,
+		s.SetCXL,
 		s.SetPCIDevices,
 		s.SetNICs,
+		s.SetSlots,
+		s.SetHostInterfaces,
 		s.SetVirt,
 		s.SetHost,
 		s.SetDistro,
+		s.SetKernel,
+		s.SetHeartbeat,
 	}
 
 	return s.BuildInOrder(inv, setters)
@@ -80,17 +128,29 @@ func (s *BuilderSvc) SetSystem(cr *metalv1alpha1.Inventory, inv *inventory.Inven
 		return
 	}
 
+	system := System{
+		ProductName: dmi.SystemInformation.ProductName,
+		Version:     dmi.SystemInformation.Version,
+		Family:      dmi.SystemInformation.Family,
+		WakeUpType:  string(dmi.SystemInformation.WakeUpType),
+	}
+	if err := setJSONAnnotation(cr, CSystemAnnotation, system); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set system"))
+	}
+
 	if inv.Host == nil {
 		return
 	}
 
-	// SONiC switches has dumb UUIDs like 03000200-0400-0500-0006-000700080009, maybe
-	// the same on any switch, so it was decided to use md5 hash of serial number as UUID
-	hostUUID := dmi.SystemInformation.UUID
-	if inv.Host.Type == utils.CSwitchType {
-		hostUUID = getUUID(CSonicNamespace, dmi.SystemInformation.SerialNumber)
+	// name is left unset if no identity source resolves, so resource is not saved
+	// under the bogus UUID that could be shared by other machines
+	hostUUID, source, err := s.identityResolver.Resolve(inv)
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to resolve identity"))
+	} else {
+		cr.Name = hostUUID
+		setAnnotation(cr, CIdentitySourceAnnotation, string(source))
 	}
-	cr.Name = hostUUID
 
 	cr.Spec.System = &metalv1alpha1.SystemSpec{
 		ID:           hostUUID,
@@ -100,6 +160,143 @@ func (s *BuilderSvc) SetSystem(cr *metalv1alpha1.Inventory, inv *inventory.Inven
 	}
 }
 
+func (s *BuilderSvc) SetBIOS(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.DMI == nil || inv.DMI.BIOSInformation == nil {
+		return
+	}
+
+	info := inv.DMI.BIOSInformation
+	bios := BIOS{
+		Vendor:                            info.Vendor,
+		Version:                           info.Version,
+		ReleaseDate:                       info.ReleaseDate,
+		Release:                           info.SystemRelease,
+		EmbeddedControllerFirmwareRelease: info.EmbeddedControllerFirmwareRelease,
+		ROMSize:                           info.ROMSize,
+	}
+	if err := setJSONAnnotation(cr, CBIOSAnnotation, bios); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set BIOS"))
+	}
+
+	if info.Version == "" {
+		return
+	}
+	if errs := validation.IsValidLabelValue(info.Version); len(errs) > 0 {
+		s.printer.VErr(errors.Errorf("BIOS version %s is not a valid label value: %s", info.Version, strings.Join(errs, ", ")))
+		return
+	}
+	setLabel(cr, CBIOSVersionLabel, info.Version)
+}
+
+// SetBoards sets data of baseboards in the order they are listed in SMBIOS
+func (s *BuilderSvc) SetBoards(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.DMI == nil || len(inv.DMI.BoardInformation) == 0 {
+		return
+	}
+
+	boards := make([]Board, 0, len(inv.DMI.BoardInformation))
+	for _, info := range inv.DMI.BoardInformation {
+		boards = append(boards, Board{
+			Manufacturer: info.Manufacturer,
+			Product:      info.Product,
+			Version:      info.Version,
+			SerialNumber: info.SerialNumber,
+			AssetTag:     info.AssetTag,
+			Type:         string(info.Type),
+		})
+	}
+
+	if err := setJSONAnnotation(cr, CBoardsAnnotation, boards); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set boards"))
+	}
+}
+
+// SetChassis sets data of the main chassis, which is the first one listed in SMBIOS
+func (s *BuilderSvc) SetChassis(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.DMI == nil || len(inv.DMI.ChassisInformation) == 0 {
+		return
+	}
+
+	chassis := inv.DMI.ChassisInformation[0]
+
+	if chassis.Type != "" {
+		setAnnotation(cr, CChassisTypeAnnotation, string(chassis.Type))
+	}
+	if chassis.SerialNumber != "" {
+		setAnnotation(cr, CChassisSerialNumberAnnotation, chassis.SerialNumber)
+	}
+	if chassis.AssetTag != "" {
+		setAnnotation(cr, CChassisAssetTagAnnotation, chassis.AssetTag)
+	}
+	if chassis.SKUNumber != "" {
+		setAnnotation(cr, CChassisSKUAnnotation, chassis.SKUNumber)
+	}
+	if chassis.NumberOfPowerCords != 0 {
+		setAnnotation(cr, CPowerCordsAnnotation, strconv.Itoa(int(chassis.NumberOfPowerCords)))
+	}
+	if chassis.Height != 0 {
+		setLabel(cr, CRackUnitsLabel, strconv.Itoa(int(chassis.Height)))
+	}
+}
+
+func (s *BuilderSvc) SetPowerSupplies(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.DMI == nil || len(inv.DMI.PowerSupplies) == 0 {
+		return
+	}
+
+	powerSupplies := make([]PowerSupply, 0, len(inv.DMI.PowerSupplies))
+	for _, psu := range inv.DMI.PowerSupplies {
+		powerSupplies = append(powerSupplies, PowerSupply{
+			Location:       psu.Location,
+			Name:           psu.DeviceName,
+			Manufacturer:   psu.Manufacturer,
+			SerialNumber:   psu.SerialNumber,
+			PartNumber:     psu.ModelPartNumber,
+			Revision:       psu.RevisionLevel,
+			MaxPower:       psu.MaxPowerCapacity,
+			Status:         string(psu.Status),
+			Present:        psu.Present,
+			Unplugged:      psu.Unplugged,
+			HotReplaceable: psu.HotReplaceable,
+		})
+	}
+
+	sort.Slice(powerSupplies, func(i, j int) bool {
+		return powerSupplies[i].Location+powerSupplies[i].Name < powerSupplies[j].Location+powerSupplies[j].Name
+	})
+
+	if err := setJSONAnnotation(cr, CPowerSuppliesAnnotation, powerSupplies); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set power supplies"))
+	}
+}
+
+func (s *BuilderSvc) SetOEMStrings(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.DMI == nil {
+		return
+	}
+
+	if len(inv.DMI.OEMStrings) > 0 {
+		if err := setJSONAnnotation(cr, COEMStringsAnnotation, inv.DMI.OEMStrings); err != nil {
+			s.printer.VErr(errors.Wrap(err, "unable to set OEM strings"))
+		}
+	}
+	if len(inv.DMI.SystemConfigurationOptions) > 0 {
+		if err := setJSONAnnotation(cr, CSystemConfigurationOptionsAnnotation, inv.DMI.SystemConfigurationOptions); err != nil {
+			s.printer.VErr(errors.Wrap(err, "unable to set system configuration options"))
+		}
+	}
+
+	values := make([]string, 0, len(inv.DMI.OEMStrings)+len(inv.DMI.SystemConfigurationOptions))
+	values = append(values, inv.DMI.OEMStrings...)
+	values = append(values, inv.DMI.SystemConfigurationOptions...)
+
+	for _, rule := range s.oemStringRules {
+		if err := rule.apply(cr, values); err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to apply OEM string rule %s", rule.Key))
+		}
+	}
+}
+
 func (s *BuilderSvc) SetIPMIs(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
 	ipmiDevCount := len(inv.IPMIDevices)
 	if ipmiDevCount == 0 {
@@ -201,6 +398,157 @@ func (s *BuilderSvc) SetMemory(cr *metalv1alpha1.Inventory, inv *inventory.Inven
 	}
 }
 
+func (s *BuilderSvc) SetMemoryDevices(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.DMI == nil || len(inv.DMI.MemoryDevices) == 0 {
+		return
+	}
+
+	// ranks of the same module are reported as separate DIMMs by some EDAC drivers,
+	// modules are keyed by SMBIOS handle, as device locators are not unique on some boards
+	dimms := make(map[uint16]*edac.DIMM)
+	for _, controller := range inv.EDAC {
+		for _, dimm := range controller.DIMMs {
+			if dimm.Locator == "" {
+				continue
+			}
+			module, ok := dimms[dimm.MemoryDeviceHandle]
+			if !ok {
+				module = &edac.DIMM{}
+				dimms[dimm.MemoryDeviceHandle] = module
+			}
+			module.CECount += dimm.CECount
+			module.UECount += dimm.UECount
+		}
+	}
+
+	devices := make([]MemoryDevice, 0, len(inv.DMI.MemoryDevices))
+	for _, dev := range inv.DMI.MemoryDevices {
+		device := MemoryDevice{
+			Locator:         dev.DeviceLocator,
+			Bank:            dev.BankLocator,
+			Size:            dev.Size,
+			Type:            string(dev.Type),
+			FormFactor:      string(dev.FormFactor),
+			Speed:           dev.Speed,
+			ConfiguredSpeed: dev.ConfiguredSpeed,
+			Rank:            dev.Rank,
+			Manufacturer:    dev.Manufacturer,
+			SerialNumber:    dev.SerialNumber,
+			PartNumber:      dev.PartNumber,
+		}
+		if module, ok := dimms[dev.Handle]; ok {
+			device.Health = string(module.Health(s.ceThreshold))
+			device.CorrectableErrors = module.CECount
+			device.UncorrectableErrors = module.UECount
+		}
+		devices = append(devices, device)
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		if devices[i].Bank != devices[j].Bank {
+			return devices[i].Bank < devices[j].Bank
+		}
+		return devices[i].Locator < devices[j].Locator
+	})
+
+	if err := setJSONAnnotation(cr, CMemoryDevicesAnnotation, devices); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set memory devices"))
+	}
+}
+
+func (s *BuilderSvc) SetEDAC(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	// condition is kept on merge, so it is reset not to report errors that can no longer be checked
+	if len(inv.EDAC) == 0 {
+		condition := metav1.Condition{
+			Type:               CMemoryDegradedConditionType,
+			Status:             metav1.ConditionUnknown,
+			LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+			Reason:             CNoEDACDataReason,
+			Message:            "no EDAC data",
+		}
+		if err := SetCondition(cr, condition); err != nil {
+			s.printer.VErr(errors.Wrap(err, "unable to set memory degraded condition"))
+		}
+		return
+	}
+
+	controllers := make([]EDACMemoryController, 0, len(inv.EDAC))
+	failed := make([]string, 0)
+	degraded := make([]string, 0)
+	for _, mc := range inv.EDAC {
+		controller := EDACMemoryController{
+			ID:                        mc.ID,
+			Name:                      mc.Name,
+			Size:                      mc.Size,
+			CorrectableErrors:         mc.CECount,
+			UncorrectableErrors:       mc.UECount,
+			CorrectableNoInfoErrors:   mc.CENoInfoCount,
+			UncorrectableNoInfoErrors: mc.UENoInfoCount,
+			DIMMs:                     make([]EDACDIMM, 0, len(mc.DIMMs)),
+		}
+
+		for _, dimm := range mc.DIMMs {
+			health := dimm.Health(s.ceThreshold)
+			controller.DIMMs = append(controller.DIMMs, EDACDIMM{
+				Name:                dimm.Name,
+				Label:               dimm.Label,
+				Locator:             dimm.Locator,
+				Size:                dimm.Size,
+				MemType:             dimm.MemType,
+				EDACMode:            dimm.EDACMode,
+				CorrectableErrors:   dimm.CECount,
+				UncorrectableErrors: dimm.UECount,
+				Health:              string(health),
+			})
+
+			name := fmt.Sprintf("mc%d/%s", mc.ID, dimm.Name)
+			if dimm.Locator != "" {
+				name = fmt.Sprintf("%s (%s)", dimm.Locator, name)
+			}
+			switch health {
+			case edac.CHealthFailed:
+				failed = append(failed, fmt.Sprintf("%s: %d uncorrectable errors", name, dimm.UECount))
+			case edac.CHealthDegraded:
+				degraded = append(degraded, fmt.Sprintf("%s: %d correctable errors", name, dimm.CECount))
+			}
+		}
+
+		// uncorrectable errors could not be attributed to DIMM
+		if mc.UENoInfoCount > 0 {
+			failed = append(failed, fmt.Sprintf("mc%d: %d uncorrectable errors of unknown DIMM", mc.ID, mc.UENoInfoCount))
+		}
+
+		controllers = append(controllers, controller)
+	}
+
+	if err := setJSONAnnotation(cr, CEDACAnnotation, controllers); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set edac"))
+	}
+
+	condition := metav1.Condition{
+		Type:               CMemoryDegradedConditionType,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(time.Now().UTC()),
+		Reason:             CNoMemoryErrorsReason,
+		Message:            "no DIMM has uncorrectable errors or reached correctable error threshold",
+	}
+	switch {
+	case len(failed) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = CUncorrectableErrorsReason
+		condition.Message = strings.Join(append(failed, degraded...), "; ")
+	case len(degraded) > 0:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = CCorrectableErrorsReason
+		condition.Message = strings.Join(degraded, "; ")
+	}
+
+	if err := SetCondition(cr, condition); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set memory degraded condition"))
+	}
+	setLabel(cr, CMemoryDegradedLabel, strconv.FormatBool(condition.Status == metav1.ConditionTrue))
+}
+
 func (s *BuilderSvc) SetMLCPerf(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
 	// TODO set data to inventory when CRD will get perf fields
 }
@@ -230,7 +578,7 @@ func (s *BuilderSvc) SetCPUs(cr *metalv1alpha1.Inventory, inv *inventory.Invento
 			ModelName:       cpuInfo.ModelName,
 			Stepping:        cpuInfo.Stepping,
 			Microcode:       cpuInfo.Microcode,
-			MHz:             resource.MustParse(cpuInfo.CPUMHz),
+			MHz:             s.parseQuantity(cpuInfo.CPUMHz),
 			CacheSize:       cpuInfo.CacheSize,
 			FPU:             cpuInfo.FPU,
 			FPUException:    cpuInfo.FPUException,
@@ -239,7 +587,7 @@ func (s *BuilderSvc) SetCPUs(cr *metalv1alpha1.Inventory, inv *inventory.Invento
 			Flags:           cpuInfo.Flags,
 			VMXFlags:        cpuInfo.VMXFlags,
 			Bugs:            cpuInfo.Bugs,
-			BogoMIPS:        resource.MustParse(cpuInfo.BogoMIPS),
+			BogoMIPS:        s.parseQuantity(cpuInfo.BogoMIPS),
 			CLFlushSize:     cpuInfo.CLFlushSize,
 			CacheAlignment:  cpuInfo.CacheAlignment,
 			AddressSizes:    cpuInfo.AddressSizes,
@@ -260,6 +608,20 @@ func (s *BuilderSvc) SetCPUs(cr *metalv1alpha1.Inventory, inv *inventory.Invento
 		cpus = append(cpus, v)
 	}
 
+	// cpuinfo reports the same core and sibling counts for all sockets
+	// and lacks them on some architectures, so counts from sysfs topology are preferred
+	if inv.CPUTopology != nil {
+		for i := range cpus {
+			for _, socket := range inv.CPUTopology.Sockets {
+				if uint64(socket.ID) != cpus[i].PhysicalID {
+					continue
+				}
+				cpus[i].Cores = uint64(socket.CoreCount())
+				cpus[i].Siblings = uint64(socket.ThreadCount())
+			}
+		}
+	}
+
 	sort.Slice(cpus, func(i, j int) bool {
 		return cpus[i].PhysicalID < cpus[j].PhysicalID
 	})
@@ -267,6 +629,350 @@ func (s *BuilderSvc) SetCPUs(cr *metalv1alpha1.Inventory, inv *inventory.Invento
 	cr.Spec.CPUs = cpus
 }
 
+// parseQuantity parses quantity from cpuinfo,
+// value may be missing or malformed on architectures other than x86
+func (s *BuilderSvc) parseQuantity(str string) resource.Quantity {
+	if str == "" {
+		return resource.Quantity{}
+	}
+
+	quantity, err := resource.ParseQuantity(str)
+	if err != nil {
+		s.printer.VErr(errors.Wrapf(err, "unable to parse quantity %s", str))
+		return resource.Quantity{}
+	}
+
+	return quantity
+}
+
+func (s *BuilderSvc) SetCPUTopology(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.CPUTopology == nil || len(inv.CPUTopology.Sockets) == 0 {
+		return
+	}
+
+	sockets := make([]CPUTopology, 0, len(inv.CPUTopology.Sockets))
+	for _, socket := range inv.CPUTopology.Sockets {
+		topology := CPUTopology{
+			PhysicalID:     uint64(socket.ID),
+			Dies:           len(socket.Dies),
+			Cores:          socket.CoreCount(),
+			Threads:        socket.ThreadCount(),
+			L1CacheSize:    socket.CacheSize(1),
+			L2CacheSize:    socket.CacheSize(2),
+			L3CacheSize:    socket.CacheSize(3),
+			ThreadSiblings: make([][]int, 0, socket.CoreCount()),
+		}
+		for _, die := range socket.Dies {
+			for _, core := range die.Cores {
+				topology.ThreadSiblings = append(topology.ThreadSiblings, core.Threads)
+			}
+		}
+		sockets = append(sockets, topology)
+	}
+
+	if err := setJSONAnnotation(cr, CCPUTopologyAnnotation, sockets); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set cpu topology"))
+	}
+}
+
+func (s *BuilderSvc) SetCPUMicroarch(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.CPUMicroarch == nil {
+		return
+	}
+
+	m := inv.CPUMicroarch
+	microarch := CPUMicroarch{
+		Vendor:       m.Vendor,
+		Family:       m.Family,
+		Model:        m.Model,
+		Stepping:     m.Stepping,
+		CodeName:     m.CodeName,
+		Level:        m.Level,
+		Capabilities: m.Capabilities,
+		Hybrid:       m.Hybrid,
+		CoreTypes:    m.CoreTypes,
+	}
+	if err := setJSONAnnotation(cr, CCPUMicroarchAnnotation, microarch); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set cpu microarchitecture"))
+	}
+
+	if m.CodeName != "" {
+		// code names have spaces and signs, e.g. "Zen 3" becomes zen-3
+		codeName := strings.ToLower(strings.NewReplacer(" ", "-", "+", "-plus").Replace(m.CodeName))
+		setLabel(cr, CCPUMicroarchLabel, codeName)
+	}
+
+	if m.Level > 0 {
+		setLabel(cr, CX8664LevelLabel, "v"+strconv.Itoa(m.Level))
+		for level := 1; level <= m.Level; level++ {
+			setLabel(cr, CX8664LevelLabelPrefix+strconv.Itoa(level), "true")
+		}
+	}
+
+	for _, capability := range m.Capabilities {
+		setLabel(cr, CCPUCapabilityLabelPrefix+capability, "true")
+	}
+}
+
+func (s *BuilderSvc) SetCPUPower(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.CPUPower == nil {
+		return
+	}
+
+	p := inv.CPUPower
+	power := CPUPower{
+		FrequencyPolicies: make([]CPUFrequencyPolicy, 0, len(p.FrequencyPolicies)),
+		Boost:             p.Boost,
+		PStateDriver:      p.PStateDriver,
+		PStateMode:        p.PStateMode,
+		IdleDriver:        p.IdleDriver,
+		IdleGovernor:      p.IdleGovernor,
+		IdleStates:        make([]CPUIdleState, 0, len(p.IdleStates)),
+		RAPLZones:         make([]RAPLZone, 0, len(p.RAPLZones)),
+	}
+
+	// policies are per CPU on most x86 systems, the ones with the same settings
+	// are merged to keep annotation small on hosts with hundreds of CPUs
+	policyIdxs := make(map[cpuFrequencyPolicyKey]int)
+	governors := make(map[string]struct{})
+	for _, policy := range p.FrequencyPolicies {
+		frequencyPolicy := CPUFrequencyPolicy{
+			CPUs:                        policy.CPUs,
+			Driver:                      policy.Driver,
+			Governor:                    policy.Governor,
+			MinKHz:                      policy.MinFrequency,
+			MaxKHz:                      policy.MaxFrequency,
+			BaseKHz:                     policy.BaseFrequency,
+			ScalingMinKHz:               policy.ScalingMinFrequency,
+			ScalingMaxKHz:               policy.ScalingMaxFrequency,
+			EnergyPerformancePreference: policy.EnergyPerformancePreference,
+			Boost:                       policy.Boost,
+		}
+		governors[policy.Governor] = struct{}{}
+
+		key := newCPUFrequencyPolicyKey(&frequencyPolicy)
+		if idx, ok := policyIdxs[key]; ok {
+			merged := &power.FrequencyPolicies[idx]
+			merged.CPUs = append(merged.CPUs, policy.CPUs...)
+			continue
+		}
+		frequencyPolicy.CPUs = append(make([]int, 0, len(policy.CPUs)), policy.CPUs...)
+		policyIdxs[key] = len(power.FrequencyPolicies)
+		power.FrequencyPolicies = append(power.FrequencyPolicies, frequencyPolicy)
+	}
+	for i := range power.FrequencyPolicies {
+		sort.Ints(power.FrequencyPolicies[i].CPUs)
+	}
+
+	for _, state := range p.IdleStates {
+		power.IdleStates = append(power.IdleStates, CPUIdleState{
+			Name:        state.Name,
+			Description: state.Description,
+			LatencyUs:   state.Latency,
+			ResidencyUs: state.Residency,
+			Disabled:    state.Disabled,
+		})
+	}
+
+	for _, zone := range p.RAPLZones {
+		raplZone := RAPLZone{
+			ID:          zone.ID,
+			Name:        zone.Name,
+			Enabled:     zone.Enabled,
+			Constraints: make([]RAPLConstraint, 0, len(zone.Constraints)),
+		}
+		for _, constraint := range zone.Constraints {
+			raplZone.Constraints = append(raplZone.Constraints, RAPLConstraint{
+				Name:         constraint.Name,
+				PowerLimitUw: constraint.PowerLimit,
+				TimeWindowUs: constraint.TimeWindow,
+				MaxPowerUw:   constraint.MaxPower,
+			})
+		}
+		power.RAPLZones = append(power.RAPLZones, raplZone)
+	}
+
+	if err := setJSONAnnotation(cr, CCPUPowerAnnotation, power); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set cpu power"))
+	}
+
+	if len(governors) == 1 && len(p.FrequencyPolicies) > 0 && p.FrequencyPolicies[0].Governor != "" {
+		setLabel(cr, CCPUGovernorLabel, p.FrequencyPolicies[0].Governor)
+	}
+	if p.Boost != nil {
+		setLabel(cr, CCPUBoostLabel, strconv.FormatBool(*p.Boost))
+	}
+	if p.PStateDriver != "" {
+		setLabel(cr, CCPUPStateDriverLabel, p.PStateDriver)
+		setLabel(cr, CCPUPStateModeLabel, p.PStateMode)
+	}
+}
+
+// cpuFrequencyPolicyKey is a comparable set of policy settings, CPUs excluded
+type cpuFrequencyPolicyKey struct {
+	driver                      string
+	governor                    string
+	minKHz                      uint64
+	maxKHz                      uint64
+	baseKHz                     uint64
+	scalingMinKHz               uint64
+	scalingMaxKHz               uint64
+	energyPerformancePreference string
+	hasBoost                    bool
+	boost                       bool
+}
+
+func newCPUFrequencyPolicyKey(policy *CPUFrequencyPolicy) cpuFrequencyPolicyKey {
+	key := cpuFrequencyPolicyKey{
+		driver:                      policy.Driver,
+		governor:                    policy.Governor,
+		minKHz:                      policy.MinKHz,
+		maxKHz:                      policy.MaxKHz,
+		baseKHz:                     policy.BaseKHz,
+		scalingMinKHz:               policy.ScalingMinKHz,
+		scalingMaxKHz:               policy.ScalingMaxKHz,
+		energyPerformancePreference: policy.EnergyPerformancePreference,
+	}
+	if policy.Boost != nil {
+		key.hasBoost = true
+		key.boost = *policy.Boost
+	}
+	return key
+}
+
+func (s *BuilderSvc) SetCPUVulnerabilities(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if len(inv.CPUVulnerabilities) == 0 {
+		return
+	}
+
+	microcodeSet := make(map[string]struct{})
+	for _, info := range inv.CPUInfo {
+		if info.Microcode != "" {
+			microcodeSet[info.Microcode] = struct{}{}
+		}
+	}
+	microcode := make([]string, 0, len(microcodeSet))
+	for revision := range microcodeSet {
+		microcode = append(microcode, revision)
+	}
+	sort.Strings(microcode)
+
+	vulnerabilities := CPUVulnerabilities{
+		Microcode:       microcode,
+		Vulnerabilities: make([]CPUVulnerability, 0, len(inv.CPUVulnerabilities)),
+	}
+	vulnerable := false
+	for _, vulnerability := range inv.CPUVulnerabilities {
+		vulnerabilities.Vulnerabilities = append(vulnerabilities.Vulnerabilities, CPUVulnerability{
+			Name:       vulnerability.Name,
+			Status:     string(vulnerability.Status),
+			Vulnerable: vulnerability.Vulnerable,
+			Text:       vulnerability.Text,
+		})
+
+		name := strings.ReplaceAll(vulnerability.Name, "_", "-")
+		setLabel(cr, CVulnerableToLabelPrefix+name, strconv.FormatBool(vulnerability.Vulnerable))
+		vulnerable = vulnerable || vulnerability.Vulnerable
+	}
+
+	if err := setJSONAnnotation(cr, CCPUVulnerabilitiesAnnotation, vulnerabilities); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set cpu vulnerabilities"))
+	}
+
+	setLabel(cr, CCPUVulnerableLabel, strconv.FormatBool(vulnerable))
+	if len(microcode) == 1 {
+		if errs := validation.IsValidLabelValue(microcode[0]); len(errs) > 0 {
+			s.printer.VErr(errors.Errorf("microcode revision %s is not a valid label value: %s", microcode[0], strings.Join(errs, ", ")))
+			return
+		}
+		setLabel(cr, CCPUMicrocodeLabel, microcode[0])
+	}
+}
+
+// SetProcessors merges SMBIOS processor data into CPU specs built from cpuinfo.
+// Populated sockets are matched with physical IDs in ascending order.
+func (s *BuilderSvc) SetProcessors(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.DMI == nil {
+		return
+	}
+
+	sockets := inv.DMI.GetPopulatedSockets()
+	if len(sockets) == 0 {
+		return
+	}
+
+	// sockets could not be matched with physical IDs, so only the annotation is set
+	merge := len(cr.Spec.CPUs) == len(sockets)
+	if len(cr.Spec.CPUs) != 0 && !merge {
+		s.printer.VErr(errors.Errorf("SMBIOS reports %d populated sockets, but %d physical CPUs found", len(sockets), len(cr.Spec.CPUs)))
+	}
+
+	processors := make([]Processor, 0, len(sockets))
+	for i, socket := range sockets {
+		processor := Processor{
+			Socket:        socket.SocketDesignation,
+			SocketType:    socket.SocketType,
+			Manufacturer:  socket.Manufacturer,
+			Version:       socket.Version,
+			Family:        socket.Family,
+			ID:            socket.ID,
+			Status:        string(socket.Status),
+			Voltage:       socket.Voltage,
+			ExternalClock: socket.ExternalClock,
+			MaxSpeed:      socket.MaxSpeed,
+			CurrentSpeed:  socket.CurrentSpeed,
+			CoreCount:     socket.CoreCount,
+			CoreEnabled:   socket.CoreEnabled,
+			ThreadCount:   socket.ThreadCount,
+			SerialNumber:  socket.SerialNumber,
+			PartNumber:    socket.PartNumber,
+			AssetTag:      socket.AssetTag,
+		}
+		if processor.SocketType == "" {
+			processor.SocketType = socket.Upgrade
+		}
+
+		if cache := inv.DMI.GetCache(socket.L1CacheHandle); cache != nil {
+			processor.L1CacheSize = cache.InstalledSize
+		}
+		if cache := inv.DMI.GetCache(socket.L2CacheHandle); cache != nil {
+			processor.L2CacheSize = cache.InstalledSize
+		}
+		if cache := inv.DMI.GetCache(socket.L3CacheHandle); cache != nil {
+			processor.L3CacheSize = cache.InstalledSize
+		}
+
+		if merge {
+			cpu := &cr.Spec.CPUs[i]
+			physicalID := cpu.PhysicalID
+			processor.PhysicalID = &physicalID
+
+			// cpuinfo lacks some of the fields on non x86 platforms
+			if cpu.Cores == 0 {
+				cpu.Cores = uint64(socket.CoreEnabled)
+			}
+			if cpu.Cores == 0 {
+				cpu.Cores = uint64(socket.CoreCount)
+			}
+			if cpu.Siblings == 0 {
+				cpu.Siblings = uint64(socket.ThreadCount)
+			}
+			if cpu.ModelName == "" {
+				cpu.ModelName = socket.Version
+			}
+			if cpu.VendorID == "" {
+				cpu.VendorID = socket.Manufacturer
+			}
+		}
+
+		processors = append(processors, processor)
+	}
+
+	if err := setJSONAnnotation(cr, CProcessorsAnnotation, processors); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set processors"))
+	}
+}
+
 func (s *BuilderSvc) SetNUMANodes(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
 	if len(inv.NumaNodes) == 0 {
 		return
@@ -291,6 +997,260 @@ func (s *BuilderSvc) SetNUMANodes(cr *metalv1alpha1.Inventory, inv *inventory.In
 	})
 
 	cr.Spec.NUMA = numaNodes
+
+	memoryOnlyIDs := make([]int, 0)
+	for _, numaNode := range inv.NumaNodes {
+		if numaNode.MemoryOnly {
+			memoryOnlyIDs = append(memoryOnlyIDs, numaNode.ID)
+		}
+	}
+	sort.Ints(memoryOnlyIDs)
+	if len(memoryOnlyIDs) > 0 {
+		memoryOnly := make([]string, 0, len(memoryOnlyIDs))
+		for _, id := range memoryOnlyIDs {
+			memoryOnly = append(memoryOnly, strconv.Itoa(id))
+		}
+		setAnnotation(cr, CMemoryOnlyNUMANodesAnnotation, strings.Join(memoryOnly, ","))
+	}
+
+	devices := make([]NUMADevices, 0, len(inv.NumaNodes))
+	for _, numaNode := range inv.NumaNodes {
+		if len(numaNode.PCIDevices) == 0 && len(numaNode.NICs) == 0 && len(numaNode.BlockDevices) == 0 {
+			continue
+		}
+		devices = append(devices, NUMADevices{
+			ID:           numaNode.ID,
+			PCIDevices:   numaNode.PCIDevices,
+			NICs:         numaNode.NICs,
+			BlockDevices: numaNode.BlockDevices,
+		})
+	}
+	if len(devices) == 0 {
+		return
+	}
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].ID < devices[j].ID
+	})
+
+	if err := setJSONAnnotation(cr, CNUMADevicesAnnotation, devices); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set numa devices"))
+	}
+}
+
+func (s *BuilderSvc) SetHugePages(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if len(inv.HugePages) > 0 {
+		if err := setJSONAnnotation(cr, CHugePagesAnnotation, toHugePages(inv.HugePages)); err != nil {
+			s.printer.VErr(errors.Wrap(err, "unable to set hugepages"))
+		}
+		for _, pool := range inv.HugePages {
+			setLabel(cr, CHugePagesLabelPrefix+hugePageSize(pool.Size), strconv.FormatUint(pool.Total, 10))
+		}
+	}
+
+	nodes := make([]NUMAHugePages, 0, len(inv.NumaNodes))
+	for _, numaNode := range inv.NumaNodes {
+		if len(numaNode.HugePages) == 0 {
+			continue
+		}
+		nodes = append(nodes, NUMAHugePages{
+			ID:        numaNode.ID,
+			HugePages: toHugePages(numaNode.HugePages),
+		})
+	}
+	if len(nodes) == 0 {
+		return
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	if err := setJSONAnnotation(cr, CNUMAHugePagesAnnotation, nodes); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set numa hugepages"))
+	}
+}
+
+func (s *BuilderSvc) SetMemoryBlocks(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.MemoryBlocks == nil {
+		return
+	}
+
+	data := MemoryBlocks{
+		BlockSize: inv.MemoryBlocks.BlockSize,
+		Nodes:     make([]MemoryBlocksNode, 0, len(inv.MemoryBlocks.Nodes)),
+	}
+	offline := false
+	for _, node := range inv.MemoryBlocks.Nodes {
+		data.Nodes = append(data.Nodes, MemoryBlocksNode{
+			ID:            toNUMANode(node.Node, mem.CNoNode),
+			OnlineBlocks:  node.OnlineBlocks,
+			OfflineBlocks: node.OfflineBlocks,
+			Online:        node.OnlineSize,
+			Offline:       node.OfflineSize,
+			Movable:       node.MovableSize,
+			OfflineRanges: formatIntList(node.OfflineIDs),
+		})
+		if node.OfflineBlocks > 0 {
+			offline = true
+		}
+	}
+
+	setLabel(cr, CMemoryOfflineLabel, strconv.FormatBool(offline))
+	if err := setJSONAnnotation(cr, CMemoryBlocksAnnotation, data); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set memory blocks"))
+	}
+}
+
+func (s *BuilderSvc) SetNVDIMM(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.NVDIMM == nil {
+		setLabel(cr, CNVDIMMLabel, "false")
+		return
+	}
+	setLabel(cr, CNVDIMMLabel, strconv.FormatBool(len(inv.NVDIMM.DIMMs) > 0))
+
+	data := NVDIMM{
+		DIMMs:   make([]NVDIMMDevice, 0, len(inv.NVDIMM.DIMMs)),
+		Regions: make([]NVDIMMRegion, 0, len(inv.NVDIMM.Regions)),
+	}
+	for _, dimm := range inv.NVDIMM.DIMMs {
+		data.DIMMs = append(data.DIMMs, NVDIMMDevice{
+			Name:   dimm.Name,
+			ID:     dimm.ID,
+			Serial: dimm.Serial,
+			Handle: dimm.Handle,
+			PhysID: dimm.PhysID,
+			State:  dimm.State,
+			Flags:  dimm.Flags,
+		})
+	}
+	for _, region := range inv.NVDIMM.Regions {
+		r := NVDIMMRegion{
+			Name:              region.Name,
+			Type:              string(region.Type),
+			Size:              region.Size,
+			AvailableSize:     region.AvailableSize,
+			NUMANode:          toNUMANode(region.NUMANode, nvdimm.CNoNUMANode),
+			TargetNode:        toNUMANode(region.TargetNode, nvdimm.CNoNUMANode),
+			PersistenceDomain: region.PersistenceDomain,
+			ReadOnly:          region.ReadOnly,
+			DIMMs:             region.DIMMs,
+			Namespaces:        make([]NVDIMMNamespace, 0, len(region.Namespaces)),
+		}
+		for _, namespace := range region.Namespaces {
+			r.Namespaces = append(r.Namespaces, NVDIMMNamespace{
+				Name:   namespace.Name,
+				Mode:   namespace.Mode,
+				Size:   namespace.Size,
+				UUID:   namespace.UUID,
+				Device: namespace.Device,
+			})
+		}
+		data.Regions = append(data.Regions, r)
+	}
+
+	if err := setJSONAnnotation(cr, CNVDIMMAnnotation, data); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set nvdimm"))
+	}
+}
+
+func (s *BuilderSvc) SetCXL(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.CXL == nil {
+		setLabel(cr, CCXLMemoryLabel, "false")
+		return
+	}
+	setLabel(cr, CCXLMemoryLabel, strconv.FormatBool(len(inv.CXL.MemDevs) > 0))
+
+	data := CXL{
+		MemDevs:  make([]CXLMemDev, 0, len(inv.CXL.MemDevs)),
+		Decoders: make([]CXLDecoder, 0, len(inv.CXL.Decoders)),
+		Regions:  make([]CXLRegion, 0, len(inv.CXL.Regions)),
+	}
+	for _, memDev := range inv.CXL.MemDevs {
+		data.MemDevs = append(data.MemDevs, CXLMemDev{
+			Name:            memDev.Name,
+			Serial:          memDev.Serial,
+			FirmwareVersion: memDev.FirmwareVersion,
+			NUMANode:        toNUMANode(memDev.NUMANode, cxl.CNoNUMANode),
+			RAMSize:         memDev.RAMSize,
+			PMEMSize:        memDev.PMEMSize,
+		})
+	}
+	for _, decoder := range inv.CXL.Decoders {
+		data.Decoders = append(data.Decoders, CXLDecoder{
+			Name:                  decoder.Name,
+			Type:                  string(decoder.Type),
+			Start:                 decoder.Start,
+			Size:                  decoder.Size,
+			InterleaveWays:        decoder.InterleaveWays,
+			InterleaveGranularity: decoder.InterleaveGranularity,
+			TargetType:            decoder.TargetType,
+			Mode:                  decoder.Mode,
+			Region:                decoder.Region,
+			Locked:                decoder.Locked,
+		})
+	}
+	for _, region := range inv.CXL.Regions {
+		data.Regions = append(data.Regions, CXLRegion{
+			Name:                  region.Name,
+			Mode:                  region.Mode,
+			UUID:                  region.UUID,
+			Start:                 region.Start,
+			Size:                  region.Size,
+			InterleaveWays:        region.InterleaveWays,
+			InterleaveGranularity: region.InterleaveGranularity,
+			Targets:               region.Targets,
+			NUMANodes:             region.NUMANodes,
+		})
+	}
+
+	if err := setJSONAnnotation(cr, CCXLAnnotation, data); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set cxl"))
+	}
+}
+
+// toNUMANode omits node that is not described by firmware
+func toNUMANode(node int, noNode int) *int {
+	if node == noNode {
+		return nil
+	}
+	return &node
+}
+
+func toHugePages(pools []mem.HugePages) []HugePages {
+	hugePages := make([]HugePages, 0, len(pools))
+	for _, pool := range pools {
+		hugePages = append(hugePages, HugePages{
+			Size:       hugePageSize(pool.Size),
+			Total:      pool.Total,
+			Free:       pool.Free,
+			Reserved:   pool.Reserved,
+			Surplus:    pool.Surplus,
+			Overcommit: pool.Overcommit,
+		})
+	}
+	return hugePages
+}
+
+func hugePageSize(size uint64) string {
+	return resource.NewQuantity(int64(size), resource.BinarySI).String()
+}
+
+// formatIntList formats sorted list in kernel cpulist format, e.g. 0,3,5-8
+func formatIntList(list []int) string {
+	elements := make([]string, 0)
+	for i := 0; i < len(list); i++ {
+		first := list[i]
+		for i+1 < len(list) && list[i+1] == list[i]+1 {
+			i++
+		}
+		if list[i] == first {
+			elements = append(elements, strconv.Itoa(first))
+			continue
+		}
+		elements = append(elements, fmt.Sprintf("%d-%d", first, list[i]))
+	}
+	return strings.Join(elements, ",")
 }
 
 func (s *BuilderSvc) SetPCIDevices(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
@@ -435,14 +1395,8 @@ func (s *BuilderSvc) SetNICs(cr *metalv1alpha1.Inventory, inv *inventory.Invento
 		}
 
 		// filter non-physical interfaces according to type of inventorying host
-		if inv.Host.Type == utils.CSwitchType {
-			if nic.PCIAddress == "" && !strings.HasPrefix(nic.Name, "Ethernet") {
-				continue
-			}
-		} else {
-			if nic.PCIAddress == "" {
-				continue
-			}
+		if nic.PCIAddress == "" && !inv.Host.IsFrontPanelPort(nic.Name) {
+			continue
 		}
 
 		lldps := lldpMap[int(nic.InterfaceIndex)]
@@ -480,6 +1434,96 @@ func (s *BuilderSvc) SetNICs(cr *metalv1alpha1.Inventory, inv *inventory.Invento
 	cr.Spec.NICs = nics
 }
 
+func (s *BuilderSvc) SetSlots(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	pciSlots := make(map[string]string)
+	for _, pciBus := range inv.PCIBusDevices {
+		for _, pciDevice := range pciBus.Devices {
+			if pciDevice.Slot != "" {
+				pciSlots[pciDevice.Address] = pciDevice.Slot
+			}
+		}
+	}
+
+	nicSlots := make(map[string]string)
+	for _, nic := range inv.NICs {
+		if nic.Slot == "" {
+			continue
+		}
+		nicSlots[nic.Name] = nic.Slot
+		if nic.PCIAddress != "" {
+			pciSlots[nic.PCIAddress] = nic.Slot
+		}
+	}
+
+	if len(pciSlots) > 0 {
+		if err := setJSONAnnotation(cr, CPCISlotsAnnotation, pciSlots); err != nil {
+			s.printer.VErr(errors.Wrap(err, "unable to set PCI slots"))
+		}
+	}
+	if len(nicSlots) > 0 {
+		if err := setJSONAnnotation(cr, CNICSlotsAnnotation, nicSlots); err != nil {
+			s.printer.VErr(errors.Wrap(err, "unable to set NIC slots"))
+		}
+	}
+}
+
+func (s *BuilderSvc) SetHostInterfaces(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	nics := make([]string, 0)
+	for _, nic := range inv.NICs {
+		if nic.HostInterface {
+			nics = append(nics, nic.Name)
+		}
+	}
+	if len(nics) > 0 {
+		sort.Strings(nics)
+		setAnnotation(cr, CBMCHostInterfaceNICsAnnotation, strings.Join(nics, ","))
+	}
+
+	if inv.DMI == nil || len(inv.DMI.HostInterfaces) == 0 {
+		return
+	}
+
+	hostInterfaces := make([]HostInterface, 0, len(inv.DMI.HostInterfaces))
+	for _, hostInterface := range inv.DMI.HostInterfaces {
+		hi := HostInterface{
+			Type: hostInterface.Type,
+		}
+
+		if hostInterface.Device != nil {
+			hi.DeviceType = string(hostInterface.Device.Type)
+			hi.VendorID = hostInterface.Device.VendorID
+			hi.ProductID = hostInterface.Device.ProductID
+			hi.MACAddress = hostInterface.Device.MACAddress
+			hi.PCIAddress = hostInterface.Device.PCIAddress
+		}
+
+		for _, protocol := range hostInterface.Protocols {
+			if protocol.RedfishOverIP == nil {
+				continue
+			}
+			redfish := protocol.RedfishOverIP
+			hi.Redfish = append(hi.Redfish, RedfishHostInterface{
+				ServiceUUID:          redfish.ServiceUUID,
+				HostIPAssignmentType: redfish.HostIPAssignmentType,
+				HostIPAddress:        redfish.HostIPAddress,
+				HostIPMask:           redfish.HostIPMask,
+				ServiceDiscoveryType: redfish.ServiceDiscoveryType,
+				ServiceIPAddress:     redfish.ServiceIPAddress,
+				ServiceIPMask:        redfish.ServiceIPMask,
+				ServicePort:          redfish.ServicePort,
+				ServiceVLANID:        redfish.ServiceVLANID,
+				ServiceHostname:      redfish.ServiceHostname,
+			})
+		}
+
+		hostInterfaces = append(hostInterfaces, hi)
+	}
+
+	if err := setJSONAnnotation(cr, CBMCHostInterfacesAnnotation, hostInterfaces); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set host interfaces"))
+	}
+}
+
 func (s *BuilderSvc) SetVirt(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
 	if inv.Virtualization == nil {
 		return
@@ -497,6 +1541,20 @@ func (s *BuilderSvc) SetHost(cr *metalv1alpha1.Inventory, inv *inventory.Invento
 	cr.Spec.Host = &metalv1alpha1.HostSpec{
 		Name: inv.Host.Name,
 	}
+
+	setLabel(cr, CHostTypeLabel, inv.Host.Type)
+	if inv.Host.NetworkOS != "" {
+		setAnnotation(cr, CNetworkOSAnnotation, inv.Host.NetworkOS)
+	}
+	if inv.Host.FQDN != "" {
+		setAnnotation(cr, CFQDNAnnotation, inv.Host.FQDN)
+	}
+	if inv.Host.MachineID != "" {
+		setAnnotation(cr, CMachineIDAnnotation, inv.Host.MachineID)
+	}
+	if inv.Host.BootID != "" {
+		setAnnotation(cr, CBootIDAnnotation, inv.Host.BootID)
+	}
 }
 
 func (s *BuilderSvc) SetDistro(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
@@ -513,4 +1571,85 @@ func (s *BuilderSvc) SetDistro(cr *metalv1alpha1.Inventory, inv *inventory.Inven
 		BuildNumber:   inv.Distro.BuildNumber,
 		BuildBy:       inv.Distro.BuildBy,
 	}
+
+	if inv.Distro.Architecture != "" {
+		setLabel(cr, CArchitectureLabel, inv.Distro.Architecture)
+	}
+
+	release := inv.Distro.OSRelease
+	if release == nil {
+		return
+	}
+	if release.ID != "" {
+		setAnnotation(cr, COSIDAnnotation, release.ID)
+	}
+	if release.VersionID != "" {
+		setAnnotation(cr, COSVersionIDAnnotation, release.VersionID)
+	}
+	if release.VariantID != "" {
+		setAnnotation(cr, COSVariantIDAnnotation, release.VariantID)
+	}
+}
+
+func (s *BuilderSvc) SetKernel(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	if inv.Kernel == nil {
+		return
+	}
+
+	if cr.Spec.Distro == nil {
+		cr.Spec.Distro = &metalv1alpha1.DistroSpec{}
+	}
+	if cr.Spec.Distro.KernelVersion == "" {
+		cr.Spec.Distro.KernelVersion = inv.Kernel.Release
+	}
+
+	kernel := Kernel{
+		Release:     inv.Kernel.Release,
+		Version:     inv.Kernel.Version,
+		CommandLine: inv.Kernel.CommandLine,
+		Modules:     make([]KernelModule, 0, len(inv.Kernel.Modules)),
+	}
+	for _, module := range inv.Kernel.Modules {
+		kernel.Modules = append(kernel.Modules, KernelModule{
+			Name:       module.Name,
+			Version:    module.Version,
+			SrcVersion: module.SrcVersion,
+		})
+	}
+	if err := setJSONAnnotation(cr, CKernelAnnotation, kernel); err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set kernel"))
+	}
+
+	setAnnotation(cr, CKernelTaintedAnnotation, strconv.FormatUint(inv.Kernel.Tainted, 10))
+	if len(inv.Kernel.TaintFlags) > 0 {
+		letters := make([]string, 0, len(inv.Kernel.TaintFlags))
+		for _, flag := range inv.Kernel.TaintFlags {
+			letters = append(letters, flag.Letter)
+		}
+		setAnnotation(cr, CKernelTaintFlagsAnnotation, strings.Join(letters, ""))
+	}
+
+	if inv.Kernel.Boot != nil {
+		setLabel(cr, CBootModeLabel, strings.ToLower(string(inv.Kernel.Boot.Mode)))
+		setLabel(cr, CSecureBootLabel, strconv.FormatBool(inv.Kernel.Boot.SecureBoot))
+	}
+}
+
+func (s *BuilderSvc) SetHeartbeat(cr *metalv1alpha1.Inventory, inv *inventory.Inventory) {
+	now := time.Now().UTC()
+
+	setAnnotation(cr, CLastSeenAnnotation, now.Format(time.RFC3339))
+	setAnnotation(cr, CAgentVersionAnnotation, version.Version)
+	setLabel(cr, CStaleLabel, "false")
+
+	err := SetCondition(cr, metav1.Condition{
+		Type:               CStaleConditionType,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(now),
+		Reason:             CRefreshedReason,
+		Message:            "inventory was refreshed by agent " + version.Version,
+	})
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to set stale condition"))
+	}
 }
@@ -0,0 +1,410 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"reflect"
+	"testing"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/onmetal/inventory/pkg/cpu"
+	"github.com/onmetal/inventory/pkg/dmi"
+	"github.com/onmetal/inventory/pkg/edac"
+	"github.com/onmetal/inventory/pkg/host"
+	"github.com/onmetal/inventory/pkg/inventory"
+	"github.com/onmetal/inventory/pkg/kernel"
+	"github.com/onmetal/inventory/pkg/mem"
+	"github.com/onmetal/inventory/pkg/numa"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func TestSetKernel(t *testing.T) {
+	inv := &inventory.Inventory{
+		Kernel: &kernel.Kernel{
+			Release:     "6.8.0-45-generic",
+			Version:     "#45-Ubuntu SMP PREEMPT_DYNAMIC",
+			CommandLine: "BOOT_IMAGE=/vmlinuz root=/dev/sda1 intel_iommu=on",
+			Modules: []kernel.Module{
+				{Name: "ice", Size: 1 << 20, Version: "1.14.9", SrcVersion: "A1B2C3"},
+				{Name: "nvme"},
+			},
+			Tainted:    1<<12 | 1<<13,
+			TaintFlags: []kernel.TaintFlag{kernel.CTaintFlags[12], kernel.CTaintFlags[13]},
+			Boot:       &kernel.Boot{Mode: kernel.CUEFIBootMode, SecureBoot: true},
+		},
+	}
+
+	cr := &metalv1alpha1.Inventory{}
+	NewBuilderSvc(printer.NewSvc(false)).SetKernel(cr, inv)
+
+	actual, err := GetKernel(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Kernel{
+		Release:     "6.8.0-45-generic",
+		Version:     "#45-Ubuntu SMP PREEMPT_DYNAMIC",
+		CommandLine: "BOOT_IMAGE=/vmlinuz root=/dev/sda1 intel_iommu=on",
+		Modules: []KernelModule{
+			{Name: "ice", Version: "1.14.9", SrcVersion: "A1B2C3"},
+			{Name: "nvme"},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+
+	if cr.Spec.Distro.KernelVersion != "6.8.0-45-generic" {
+		t.Errorf("expected kernel version 6.8.0-45-generic, got %s", cr.Spec.Distro.KernelVersion)
+	}
+	if cr.Annotations[CKernelTaintedAnnotation] != "12288" || cr.Annotations[CKernelTaintFlagsAnnotation] != "OE" {
+		t.Errorf("expected taint 12288 with flags OE, got %s with %s",
+			cr.Annotations[CKernelTaintedAnnotation], cr.Annotations[CKernelTaintFlagsAnnotation])
+	}
+	if cr.Labels[CBootModeLabel] != "uefi" || cr.Labels[CSecureBootLabel] != "true" {
+		t.Errorf("expected uefi boot with secure boot, got %+v", cr.Labels)
+	}
+}
+
+func TestSetProcessorsSocketMismatch(t *testing.T) {
+	inv := &inventory.Inventory{
+		DMI: &dmi.DMI{
+			Processors: []dmi.ProcessorInformation{
+				{SocketDesignation: "CPU0", Type: dmi.CCentralProcessorType, SocketPopulated: true, Version: "Xeon", CoreCount: 8},
+				{SocketDesignation: "CPU1", Type: dmi.CCentralProcessorType, SocketPopulated: true, Version: "Xeon", CoreCount: 8},
+			},
+		},
+	}
+
+	cr := &metalv1alpha1.Inventory{}
+	cr.Spec.CPUs = []metalv1alpha1.CPUSpec{{PhysicalID: 0}}
+	NewBuilderSvc(printer.NewSvc(false)).SetProcessors(cr, inv)
+
+	if cr.Spec.CPUs[0].ModelName != "" || cr.Spec.CPUs[0].Cores != 0 {
+		t.Errorf("expected cpu spec not to be merged, got %+v", cr.Spec.CPUs[0])
+	}
+
+	processors, err := GetProcessors(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(processors) != 2 {
+		t.Fatalf("expected 2 processors in annotation, got %d", len(processors))
+	}
+	for _, processor := range processors {
+		if processor.PhysicalID != nil {
+			t.Errorf("expected no physical ID for unmatched socket %s, got %d", processor.Socket, *processor.PhysicalID)
+		}
+	}
+
+	cr.Spec.CPUs = []metalv1alpha1.CPUSpec{{PhysicalID: 0}, {PhysicalID: 1}}
+	NewBuilderSvc(printer.NewSvc(false)).SetProcessors(cr, inv)
+
+	processors, err = GetProcessors(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, processor := range processors {
+		if processor.PhysicalID == nil || *processor.PhysicalID != uint64(i) {
+			t.Errorf("expected physical ID %d for matched socket %s, got %v", i, processor.Socket, processor.PhysicalID)
+		}
+	}
+}
+
+func TestSetSystemAnnotation(t *testing.T) {
+	inv := &inventory.Inventory{
+		DMI: &dmi.DMI{
+			SystemInformation: &dmi.SystemInformation{
+				ProductName: "ThinkSystem SR650",
+				Version:     "07",
+				Family:      "ThinkSystem",
+				WakeUpType:  dmi.CPowerSwitchWakeUpType,
+			},
+		},
+	}
+
+	cr := &metalv1alpha1.Inventory{}
+	NewBuilderSvc(printer.NewSvc(false)).SetSystem(cr, inv)
+
+	system, err := GetSystem(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &System{ProductName: "ThinkSystem SR650", Version: "07", Family: "ThinkSystem", WakeUpType: "Power Switch"}
+	if !reflect.DeepEqual(system, expected) {
+		t.Errorf("expected %+v, got %+v", expected, system)
+	}
+}
+
+func TestSetBIOS(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		label   string
+	}{
+		{name: "valid label value", version: "2.19.1", label: "2.19.1"},
+		{name: "invalid label value", version: "Version 1.2 (build 7)", label: ""},
+		{name: "no version", version: "", label: ""},
+	}
+
+	for _, test := range tests {
+		inv := &inventory.Inventory{
+			DMI: &dmi.DMI{
+				BIOSInformation: &dmi.BIOSInformation{
+					Vendor:        "Dell Inc.",
+					Version:       test.version,
+					ReleaseDate:   "08/10/2023",
+					SystemRelease: "2.19",
+					ROMSize:       64 << 20,
+				},
+			},
+		}
+
+		cr := &metalv1alpha1.Inventory{}
+		NewBuilderSvc(printer.NewSvc(false)).SetBIOS(cr, inv)
+
+		bios, err := GetBIOS(cr)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		expected := &BIOS{Vendor: "Dell Inc.", Version: test.version, ReleaseDate: "08/10/2023", Release: "2.19", ROMSize: 64 << 20}
+		if !reflect.DeepEqual(bios, expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, expected, bios)
+		}
+		label, ok := cr.Labels[CBIOSVersionLabel]
+		if label != test.label || ok != (test.label != "") {
+			t.Errorf("%s: expected label %+v, got %+v", test.name, test.label, label)
+		}
+	}
+}
+
+func TestSetBoards(t *testing.T) {
+	inv := &inventory.Inventory{
+		DMI: &dmi.DMI{
+			BoardInformation: []dmi.BoardInformation{
+				{Manufacturer: "Supermicro", Product: "X12DPi-NT6", Version: "1.02", SerialNumber: "OM21AS000123", Type: dmi.CMotherboardBoardType},
+				{Manufacturer: "Supermicro", Product: "AOC-S25G", AssetTag: "riser", Type: dmi.CDaughterBoardBoardType},
+			},
+		},
+	}
+
+	cr := &metalv1alpha1.Inventory{}
+	NewBuilderSvc(printer.NewSvc(false)).SetBoards(cr, inv)
+
+	boards, err := GetBoards(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Board{
+		{Manufacturer: "Supermicro", Product: "X12DPi-NT6", Version: "1.02", SerialNumber: "OM21AS000123", Type: dmi.CMotherboardBoardType},
+		{Manufacturer: "Supermicro", Product: "AOC-S25G", AssetTag: "riser", Type: dmi.CDaughterBoardBoardType},
+	}
+	if !reflect.DeepEqual(boards, expected) {
+		t.Errorf("expected %+v, got %+v", expected, boards)
+	}
+}
+
+func TestSetSystemUnresolvedIdentity(t *testing.T) {
+	inv := &inventory.Inventory{
+		DMI: &dmi.DMI{
+			SystemInformation: &dmi.SystemInformation{
+				UUID:         "03000200-0400-0500-0006-000700080009",
+				Manufacturer: "Example",
+			},
+		},
+		Host: &host.Info{},
+	}
+
+	builder := NewBuilderSvc(printer.NewSvc(false))
+	builder.SetIdentityResolver(NewIdentityResolver([]IdentitySource{CDMIUUIDIdentitySource}, []string{}))
+
+	cr := &metalv1alpha1.Inventory{}
+	builder.SetSystem(cr, inv)
+
+	if cr.Name != "" {
+		t.Errorf("expected name to be unset for bogus uuid, got %s", cr.Name)
+	}
+	if _, ok := cr.Annotations[CIdentitySourceAnnotation]; ok {
+		t.Error("expected no identity source annotation")
+	}
+	if cr.Spec.System == nil || cr.Spec.System.Manufacturer != "Example" {
+		t.Errorf("expected system spec to be set, got %+v", cr.Spec.System)
+	}
+}
+
+func TestSetCPUPowerMergesPolicies(t *testing.T) {
+	boost := true
+	inv := &inventory.Inventory{
+		CPUPower: &cpu.Power{
+			FrequencyPolicies: []cpu.FrequencyPolicy{
+				{ID: 0, CPUs: []int{0}, Driver: "intel_pstate", Governor: "performance", MaxFrequency: 3500000, Boost: &boost},
+				{ID: 2, CPUs: []int{2}, Driver: "intel_pstate", Governor: "performance", MaxFrequency: 3500000, Boost: &boost},
+				{ID: 1, CPUs: []int{1}, Driver: "intel_pstate", Governor: "powersave", MaxFrequency: 3500000, Boost: &boost},
+				{ID: 3, CPUs: []int{3}, Driver: "intel_pstate", Governor: "performance", MaxFrequency: 3500000},
+			},
+		},
+	}
+
+	cr := &metalv1alpha1.Inventory{}
+	NewBuilderSvc(printer.NewSvc(false)).SetCPUPower(cr, inv)
+
+	power, err := GetCPUPower(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCPUs := [][]int{{0, 2}, {1}, {3}}
+	if len(power.FrequencyPolicies) != len(expectedCPUs) {
+		t.Fatalf("expected %d policies, got %d", len(expectedCPUs), len(power.FrequencyPolicies))
+	}
+	for i, policy := range power.FrequencyPolicies {
+		if !reflect.DeepEqual(policy.CPUs, expectedCPUs[i]) {
+			t.Errorf("policy %d: expected cpus %v, got %v", i, expectedCPUs[i], policy.CPUs)
+		}
+	}
+	if inv.CPUPower.FrequencyPolicies[0].CPUs[0] != 0 || len(inv.CPUPower.FrequencyPolicies[0].CPUs) != 1 {
+		t.Errorf("expected gathered policy to be left intact, got %v", inv.CPUPower.FrequencyPolicies[0].CPUs)
+	}
+}
+
+func TestSetHugePages(t *testing.T) {
+	inv := &inventory.Inventory{
+		HugePages: []mem.HugePages{
+			{Size: 2 << 20, Total: 1024, Free: 1000, Reserved: 8, Overcommit: 64},
+			{Size: 1 << 30, Total: 16, Free: 4},
+		},
+		NumaNodes: []numa.Node{
+			{ID: 1, HugePages: []mem.HugePages{{Size: 1 << 30, Total: 8, Free: 2}}},
+			{ID: 0, HugePages: []mem.HugePages{{Size: 1 << 30, Total: 8, Free: 2, Surplus: 1}}},
+			// node without hugetlbfs pools
+			{ID: 2},
+		},
+	}
+
+	cr := &metalv1alpha1.Inventory{}
+	NewBuilderSvc(printer.NewSvc(false)).SetHugePages(cr, inv)
+
+	pools, err := GetHugePages(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedPools := []HugePages{
+		{Size: "2Mi", Total: 1024, Free: 1000, Reserved: 8, Overcommit: 64},
+		{Size: "1Gi", Total: 16, Free: 4},
+	}
+	if !reflect.DeepEqual(pools, expectedPools) {
+		t.Errorf("expected %+v, got %+v", expectedPools, pools)
+	}
+
+	nodes, err := GetNUMAHugePages(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedNodes := []NUMAHugePages{
+		{ID: 0, HugePages: []HugePages{{Size: "1Gi", Total: 8, Free: 2, Surplus: 1}}},
+		{ID: 1, HugePages: []HugePages{{Size: "1Gi", Total: 8, Free: 2}}},
+	}
+	if !reflect.DeepEqual(nodes, expectedNodes) {
+		t.Errorf("expected %+v, got %+v", expectedNodes, nodes)
+	}
+
+	if cr.Labels[CHugePagesLabelPrefix+"2Mi"] != "1024" || cr.Labels[CHugePagesLabelPrefix+"1Gi"] != "16" {
+		t.Errorf("expected hugepages-2Mi=1024 and hugepages-1Gi=16 labels, got %+v", cr.Labels)
+	}
+}
+
+func TestSetMemoryDevices(t *testing.T) {
+	inv := &inventory.Inventory{
+		DMI: &dmi.DMI{
+			MemoryDevices: []dmi.MemoryDevice{
+				{Handle: 0x1100, BankLocator: "P0 CHANNEL A", DeviceLocator: "DIMM 0", Size: 1 << 34},
+				{Handle: 0x1101, BankLocator: "P0 CHANNEL B", DeviceLocator: "DIMM 0", Size: 1 << 34},
+			},
+		},
+		EDAC: []edac.MemoryController{
+			{
+				DIMMs: []edac.DIMM{
+					{Name: "rank0", Locator: "DIMM 0", MemoryDeviceHandle: 0x1101, CECount: 1},
+					{Name: "rank1", Locator: "DIMM 0", MemoryDeviceHandle: 0x1101, UECount: 1},
+					{Name: "rank2", Locator: "DIMM 0", MemoryDeviceHandle: 0x1100},
+				},
+			},
+		},
+	}
+
+	cr := &metalv1alpha1.Inventory{}
+	NewBuilderSvc(printer.NewSvc(false)).SetMemoryDevices(cr, inv)
+
+	devices, err := GetMemoryDevices(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("expected 2 devices, got %d", len(devices))
+	}
+
+	healthy, failed := devices[0], devices[1]
+	if healthy.Bank != "P0 CHANNEL A" || healthy.Health != string(edac.CHealthOK) || healthy.UncorrectableErrors != 0 {
+		t.Errorf("expected healthy device in channel A, got %+v", healthy)
+	}
+	if failed.Bank != "P0 CHANNEL B" || failed.Health != string(edac.CHealthFailed) ||
+		failed.CorrectableErrors != 1 || failed.UncorrectableErrors != 1 {
+		t.Errorf("expected failed device in channel B, got %+v", failed)
+	}
+}
+
+func TestSetEDACWithoutData(t *testing.T) {
+	builder := NewBuilderSvc(printer.NewSvc(false))
+	failed := &inventory.Inventory{
+		EDAC: []edac.MemoryController{
+			{DIMMs: []edac.DIMM{{Name: "rank0", UECount: 1}}},
+		},
+	}
+
+	dst := &metalv1alpha1.Inventory{}
+	builder.SetEDAC(dst, failed)
+	if dst.Labels[CMemoryDegradedLabel] != "true" {
+		t.Fatalf("expected memory degraded label, got %+v", dst.Labels)
+	}
+
+	// EDAC driver is gone on next run
+	src := &metalv1alpha1.Inventory{}
+	builder.SetEDAC(src, &inventory.Inventory{})
+	if err := mergeMeta(dst, src); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := dst.Labels[CMemoryDegradedLabel]; ok {
+		t.Errorf("expected memory degraded label to be removed, got %+v", dst.Labels)
+	}
+	conditions, err := GetConditions(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conditions) != 1 || conditions[0].Status != metav1.ConditionUnknown || conditions[0].Reason != CNoEDACDataReason {
+		t.Errorf("expected unknown memory degraded condition, got %+v", conditions)
+	}
+}
+
+func TestFormatIntList(t *testing.T) {
+	tests := []struct {
+		list     []int
+		expected string
+	}{
+		{list: []int{}, expected: ""},
+		{list: []int{3}, expected: "3"},
+		{list: []int{0, 1}, expected: "0-1"},
+		{list: []int{0, 3, 5, 6, 7, 8}, expected: "0,3,5-8"},
+		{list: []int{11, 12, 13, 14, 15, 20}, expected: "11-15,20"},
+	}
+
+	for _, test := range tests {
+		if actual := formatIntList(test.list); actual != test.expected {
+			t.Errorf("%v: expected %q, got %q", test.list, test.expected, actual)
+		}
+	}
+}
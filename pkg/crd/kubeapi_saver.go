@@ -24,6 +24,17 @@ type KubeAPISaverSvc struct {
 }
 
 func NewKubeAPISaverSvc(kubeconfig string, namespace string) (SaverSvc, error) {
+	cl, err := newKubeClient(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create k8s client")
+	}
+
+	return &KubeAPISaverSvc{
+		client: cl,
+	}, nil
+}
+
+func newKubeClient(kubeconfig string) (client.Client, error) {
 	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to read kubeconfig from path %s", kubeconfig)
@@ -33,20 +44,19 @@ func NewKubeAPISaverSvc(kubeconfig string, namespace string) (SaverSvc, error) {
 		return nil, errors.Wrap(err, "unable to add registered types to client scheme")
 	}
 
-	// clientset, err := clientv1alpha1.NewForConfig(config)
 	cl, err := client.New(config, client.Options{Scheme: scheme.Scheme})
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to build clientset from config")
 	}
 
-	// client := clientset.Inventories(namespace)
-
-	return &KubeAPISaverSvc{
-		client: cl,
-	}, nil
+	return cl, nil
 }
 
 func (s *KubeAPISaverSvc) Save(inv *metalv1alpha1.Inventory) error {
+	if inv.Name == "" {
+		return errors.New("resource has no name, host identity is not resolved")
+	}
+
 	err := s.client.Create(context.Background(), inv)
 	if err == nil {
 		return nil
@@ -65,6 +75,9 @@ func (s *KubeAPISaverSvc) Save(inv *metalv1alpha1.Inventory) error {
 	}
 
 	existing.Spec = inv.Spec
+	if err := mergeMeta(existing, inv); err != nil {
+		return errors.Wrap(err, "unable to merge metadata")
+	}
 
 	if err = s.client.Update(context.Background(), existing); err != nil {
 		return errors.Wrap(err, "unhandled error on update")
@@ -0,0 +1,765 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package crd builds Inventory resources from gathered data and saves them.
+// Data Inventory CRD has no place for is kept as JSON in annotations,
+// values machines are selected by are duplicated in labels.
+package crd
+
+import (
+	"encoding/json"
+	"strings"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal/api/v1alpha1"
+	"github.com/pkg/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CMetaPrefix = "inventory.onmetal.de/"
+
+	// CIdentitySourceAnnotation tells which data inventory name is derived from
+	CIdentitySourceAnnotation = CMetaPrefix + "identity-source"
+
+	CLastSeenAnnotation     = CMetaPrefix + "last-seen"
+	CAgentVersionAnnotation = CMetaPrefix + "agent-version"
+	// CConditionsAnnotation keeps conditions, as Inventory CRD has no status
+	CConditionsAnnotation = CMetaPrefix + "conditions"
+
+	COSIDAnnotation        = CMetaPrefix + "os-id"
+	COSVersionIDAnnotation = CMetaPrefix + "os-version-id"
+	COSVariantIDAnnotation = CMetaPrefix + "os-variant-id"
+	CArchitectureLabel     = CMetaPrefix + "architecture"
+
+	CHostTypeLabel       = CMetaPrefix + "host-type"
+	CNetworkOSAnnotation = CMetaPrefix + "network-os"
+	CFQDNAnnotation      = CMetaPrefix + "fqdn"
+	CMachineIDAnnotation = CMetaPrefix + "machine-id"
+	CBootIDAnnotation    = CMetaPrefix + "boot-id"
+
+	// CKernelAnnotation keeps kernel build, command line and loaded modules
+	CKernelAnnotation           = CMetaPrefix + "kernel"
+	CKernelTaintedAnnotation    = CMetaPrefix + "kernel-tainted"
+	CKernelTaintFlagsAnnotation = CMetaPrefix + "kernel-taint-flags"
+	CBootModeLabel              = CMetaPrefix + "boot-mode"
+	CSecureBootLabel            = CMetaPrefix + "secure-boot"
+
+	CSystemAnnotation = CMetaPrefix + "system"
+	CBIOSAnnotation   = CMetaPrefix + "bios"
+	CBoardsAnnotation = CMetaPrefix + "boards"
+	// CBIOSVersionLabel is set only if BIOS version is a valid label value
+	CBIOSVersionLabel = CMetaPrefix + "bios-version"
+
+	CMemoryDevicesAnnotation = CMetaPrefix + "memory-devices"
+	// hugepages-<size> labels hold system wide number of pages
+	CHugePagesAnnotation     = CMetaPrefix + "hugepages"
+	CNUMAHugePagesAnnotation = CMetaPrefix + "numa-hugepages"
+	CHugePagesLabelPrefix    = CMetaPrefix + "hugepages-"
+	// CMemoryBlocksAnnotation keeps memory hotplug block size and per NUMA node summary of online
+	// and offline blocks, offline memory label tells if any block is left offline
+	CMemoryBlocksAnnotation = CMetaPrefix + "memory-blocks"
+	CMemoryOfflineLabel     = CMetaPrefix + "memory-offline"
+	// CNUMADevicesAnnotation keeps PCI devices, NICs and block devices attached to every NUMA node
+	CNUMADevicesAnnotation = CMetaPrefix + "numa-devices"
+	// CMemoryOnlyNUMANodesAnnotation holds comma separated IDs of NUMA nodes
+	// that have memory but no CPUs, e.g. CXL or NVDIMM memory onlined as RAM
+	CMemoryOnlyNUMANodesAnnotation = CMetaPrefix + "memory-only-numa-nodes"
+	// NVDIMM and CXL labels are set to false if there is none
+	CNVDIMMAnnotation = CMetaPrefix + "nvdimm"
+	CNVDIMMLabel      = CMetaPrefix + "nvdimm"
+	CCXLAnnotation    = CMetaPrefix + "cxl"
+	CCXLMemoryLabel   = CMetaPrefix + "cxl-memory"
+	// CProcessorsAnnotation keeps per socket processor data from SMBIOS
+	CProcessorsAnnotation = CMetaPrefix + "processors"
+	// CCPUTopologyAnnotation keeps per socket topology from sysfs,
+	// cache sizes are totals of all caches of the level in the socket
+	CCPUTopologyAnnotation = CMetaPrefix + "cpu-topology"
+	// x86-64 level labels are set for every level machine is capable of,
+	// e.g. x86-64-v3=true is set for v3 and v4 machines
+	CCPUMicroarchAnnotation   = CMetaPrefix + "cpu-microarchitecture"
+	CCPUMicroarchLabel        = CMetaPrefix + "cpu-microarchitecture"
+	CX8664LevelLabel          = CMetaPrefix + "x86-64-level"
+	CX8664LevelLabelPrefix    = CMetaPrefix + "x86-64-v"
+	CCPUCapabilityLabelPrefix = CMetaPrefix + "cpu-"
+	// CCPUPowerAnnotation keeps cpufreq policies, C-states and RAPL power limits,
+	// governor label is set only if all policies have the same governor
+	CCPUPowerAnnotation   = CMetaPrefix + "cpu-power"
+	CCPUGovernorLabel     = CMetaPrefix + "cpu-governor"
+	CCPUBoostLabel        = CMetaPrefix + "cpu-boost"
+	CCPUPStateDriverLabel = CMetaPrefix + "cpu-pstate-driver"
+	CCPUPStateModeLabel   = CMetaPrefix + "cpu-pstate-mode"
+	// Vulnerability labels are set to false for not affected and fully mitigated ones,
+	// so machines could be selected by a fixed vulnerability as well
+	CCPUVulnerabilitiesAnnotation = CMetaPrefix + "cpu-vulnerabilities"
+	CVulnerableToLabelPrefix      = CMetaPrefix + "vulnerable-to-"
+	CCPUVulnerableLabel           = CMetaPrefix + "cpu-vulnerable"
+	// CCPUMicrocodeLabel is set only if all CPUs run the same microcode revision
+	CCPUMicrocodeLabel = CMetaPrefix + "cpu-microcode"
+
+	CChassisTypeAnnotation         = CMetaPrefix + "chassis-type"
+	CChassisSerialNumberAnnotation = CMetaPrefix + "chassis-serial-number"
+	CChassisAssetTagAnnotation     = CMetaPrefix + "chassis-asset-tag"
+	CChassisSKUAnnotation          = CMetaPrefix + "chassis-sku"
+	CPowerCordsAnnotation          = CMetaPrefix + "power-cords"
+	CRackUnitsLabel                = CMetaPrefix + "rack-units"
+	CPowerSuppliesAnnotation       = CMetaPrefix + "power-supplies"
+
+	// slots are kept as JSON maps keyed by PCI address and NIC name
+	CPCISlotsAnnotation = CMetaPrefix + "pci-slots"
+	CNICSlotsAnnotation = CMetaPrefix + "nic-slots"
+
+	// CBMCHostInterfaceNICsAnnotation holds comma separated names of NICs that are
+	// a host side of management controller host interface, e.g. Redfish USB NIC
+	CBMCHostInterfaceNICsAnnotation = CMetaPrefix + "bmc-host-interface-nics"
+	CBMCHostInterfacesAnnotation    = CMetaPrefix + "bmc-host-interfaces"
+
+	COEMStringsAnnotation                 = CMetaPrefix + "oem-strings"
+	CSystemConfigurationOptionsAnnotation = CMetaPrefix + "system-configuration-options"
+
+	// CEDACAnnotation keeps EDAC memory controllers with error counts and health of their DIMMs
+	CEDACAnnotation      = CMetaPrefix + "edac"
+	CMemoryDegradedLabel = CMetaPrefix + "memory-degraded"
+
+	CStaleLabel = CMetaPrefix + "stale"
+
+	CStaleConditionType     = "Stale"
+	CRefreshedReason        = "Refreshed"
+	CHeartbeatMissingReason = "HeartbeatMissing"
+
+	CMemoryDegradedConditionType = "MemoryDegraded"
+	CNoMemoryErrorsReason        = "NoMemoryErrors"
+	CUncorrectableErrorsReason   = "UncorrectableMemoryErrors"
+	CCorrectableErrorsReason     = "CorrectableMemoryErrorsThresholdReached"
+	CNoEDACDataReason            = "NoEDACData"
+)
+
+func GetConditions(cr *metalv1alpha1.Inventory) ([]metav1.Condition, error) {
+	conditions := make([]metav1.Condition, 0)
+
+	raw, ok := cr.Annotations[CConditionsAnnotation]
+	if !ok || raw == "" {
+		return conditions, nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), &conditions); err != nil {
+		return nil, errors.Wrapf(err, "unable to unmarshal conditions of %s", cr.Name)
+	}
+
+	return conditions, nil
+}
+
+func SetCondition(cr *metalv1alpha1.Inventory, condition metav1.Condition) error {
+	conditions, err := GetConditions(cr)
+	if err != nil {
+		return errors.Wrap(err, "unable to get conditions")
+	}
+
+	apimeta.SetStatusCondition(&conditions, condition)
+
+	raw, err := json.Marshal(conditions)
+	if err != nil {
+		return errors.Wrapf(err, "unable to marshal conditions of %s", cr.Name)
+	}
+
+	setAnnotation(cr, CConditionsAnnotation, string(raw))
+
+	return nil
+}
+
+// CForeignMetaKeys are prefixed keys that are written not only by the agent,
+// so they are kept on merge even if the agent has not produced them
+var CForeignMetaKeys = map[string]bool{
+	CStaleLabel:           true,
+	CConditionsAnnotation: true,
+}
+
+// mergeMeta copies labels and annotations of src into dst,
+// prefixed keys of dst the agent no longer produces are removed,
+// conditions are merged one by one to keep ones set by other parties
+func mergeMeta(dst *metalv1alpha1.Inventory, src *metalv1alpha1.Inventory) error {
+	for k := range dst.Labels {
+		if _, ok := src.Labels[k]; !ok && isAgentMetaKey(k) {
+			delete(dst.Labels, k)
+		}
+	}
+	for k := range dst.Annotations {
+		if _, ok := src.Annotations[k]; !ok && isAgentMetaKey(k) {
+			delete(dst.Annotations, k)
+		}
+	}
+
+	for k, v := range src.Labels {
+		setLabel(dst, k, v)
+	}
+
+	for k, v := range src.Annotations {
+		if k == CConditionsAnnotation {
+			continue
+		}
+		setAnnotation(dst, k, v)
+	}
+
+	conditions, err := GetConditions(src)
+	if err != nil {
+		return errors.Wrap(err, "unable to get conditions to merge")
+	}
+	for _, condition := range conditions {
+		if err := SetCondition(dst, condition); err != nil {
+			return errors.Wrapf(err, "unable to merge condition %s", condition.Type)
+		}
+	}
+
+	return nil
+}
+
+func isAgentMetaKey(key string) bool {
+	return strings.HasPrefix(key, CMetaPrefix) && !CForeignMetaKeys[key]
+}
+
+// Kernel is a running kernel as it is stored in the annotation
+type Kernel struct {
+	Release     string         `json:"release,omitempty"`
+	Version     string         `json:"version,omitempty"`
+	CommandLine string         `json:"commandLine,omitempty"`
+	Modules     []KernelModule `json:"modules,omitempty"`
+}
+
+type KernelModule struct {
+	Name       string `json:"name"`
+	Version    string `json:"version,omitempty"`
+	SrcVersion string `json:"srcVersion,omitempty"`
+}
+
+func GetKernel(cr *metalv1alpha1.Inventory) (*Kernel, error) {
+	kernel := &Kernel{}
+	if err := getJSONAnnotation(cr, CKernelAnnotation, kernel); err != nil {
+		return nil, errors.Wrap(err, "unable to get kernel")
+	}
+	return kernel, nil
+}
+
+// MemoryDevice is a memory module (or an empty slot)
+// as it is stored in the annotation
+type MemoryDevice struct {
+	Locator         string `json:"locator"`
+	Bank            string `json:"bank,omitempty"`
+	Size            uint64 `json:"size"`
+	Type            string `json:"type,omitempty"`
+	FormFactor      string `json:"formFactor,omitempty"`
+	Speed           uint32 `json:"speed,omitempty"`
+	ConfiguredSpeed uint32 `json:"configuredSpeed,omitempty"`
+	Rank            byte   `json:"rank,omitempty"`
+	Manufacturer    string `json:"manufacturer,omitempty"`
+	SerialNumber    string `json:"serialNumber,omitempty"`
+	PartNumber      string `json:"partNumber,omitempty"`
+	// Health and error counts are set if EDAC DIMM is matched with the module
+	Health              string `json:"health,omitempty"`
+	CorrectableErrors   uint64 `json:"correctableErrors,omitempty"`
+	UncorrectableErrors uint64 `json:"uncorrectableErrors,omitempty"`
+}
+
+// EDACMemoryController is an EDAC memory controller as it is stored in the annotation
+type EDACMemoryController struct {
+	ID                  int    `json:"id"`
+	Name                string `json:"name"`
+	Size                uint64 `json:"size,omitempty"`
+	CorrectableErrors   uint64 `json:"correctableErrors"`
+	UncorrectableErrors uint64 `json:"uncorrectableErrors"`
+	// NoInfo errors could not be attributed to a DIMM
+	CorrectableNoInfoErrors   uint64     `json:"correctableNoInfoErrors,omitempty"`
+	UncorrectableNoInfoErrors uint64     `json:"uncorrectableNoInfoErrors,omitempty"`
+	DIMMs                     []EDACDIMM `json:"dimms,omitempty"`
+}
+
+type EDACDIMM struct {
+	Name                string `json:"name"`
+	Label               string `json:"label,omitempty"`
+	Locator             string `json:"locator,omitempty"`
+	Size                uint64 `json:"size,omitempty"`
+	MemType             string `json:"memType,omitempty"`
+	EDACMode            string `json:"edacMode,omitempty"`
+	CorrectableErrors   uint64 `json:"correctableErrors"`
+	UncorrectableErrors uint64 `json:"uncorrectableErrors"`
+	Health              string `json:"health"`
+}
+
+func GetEDAC(cr *metalv1alpha1.Inventory) ([]EDACMemoryController, error) {
+	controllers := make([]EDACMemoryController, 0)
+	if err := getJSONAnnotation(cr, CEDACAnnotation, &controllers); err != nil {
+		return nil, errors.Wrap(err, "unable to get edac")
+	}
+	return controllers, nil
+}
+
+type System struct {
+	ProductName string `json:"productName,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Family      string `json:"family,omitempty"`
+	WakeUpType  string `json:"wakeUpType,omitempty"`
+}
+
+type BIOS struct {
+	Vendor                            string `json:"vendor,omitempty"`
+	Version                           string `json:"version,omitempty"`
+	ReleaseDate                       string `json:"releaseDate,omitempty"`
+	Release                           string `json:"release,omitempty"`
+	EmbeddedControllerFirmwareRelease string `json:"embeddedControllerFirmwareRelease,omitempty"`
+	ROMSize                           uint64 `json:"romSize,omitempty"`
+}
+
+type Board struct {
+	Manufacturer string `json:"manufacturer,omitempty"`
+	Product      string `json:"product,omitempty"`
+	Version      string `json:"version,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+	AssetTag     string `json:"assetTag,omitempty"`
+	Type         string `json:"type,omitempty"`
+}
+
+func GetSystem(cr *metalv1alpha1.Inventory) (*System, error) {
+	system := &System{}
+	if err := getJSONAnnotation(cr, CSystemAnnotation, system); err != nil {
+		return nil, errors.Wrap(err, "unable to get system")
+	}
+	return system, nil
+}
+
+func GetBIOS(cr *metalv1alpha1.Inventory) (*BIOS, error) {
+	bios := &BIOS{}
+	if err := getJSONAnnotation(cr, CBIOSAnnotation, bios); err != nil {
+		return nil, errors.Wrap(err, "unable to get BIOS")
+	}
+	return bios, nil
+}
+
+func GetBoards(cr *metalv1alpha1.Inventory) ([]Board, error) {
+	boards := make([]Board, 0)
+	if err := getJSONAnnotation(cr, CBoardsAnnotation, &boards); err != nil {
+		return nil, errors.Wrap(err, "unable to get boards")
+	}
+	return boards, nil
+}
+
+// CPUTopology is a socket topology as it is stored in the annotation
+type CPUTopology struct {
+	PhysicalID  uint64 `json:"physicalId"`
+	Dies        int    `json:"dies"`
+	Cores       int    `json:"cores"`
+	Threads     int    `json:"threads"`
+	L1CacheSize uint64 `json:"l1CacheSize,omitempty"`
+	L2CacheSize uint64 `json:"l2CacheSize,omitempty"`
+	L3CacheSize uint64 `json:"l3CacheSize,omitempty"`
+	// ThreadSiblings are logical CPUs of each core
+	ThreadSiblings [][]int `json:"threadSiblings"`
+}
+
+func GetCPUTopology(cr *metalv1alpha1.Inventory) ([]CPUTopology, error) {
+	sockets := make([]CPUTopology, 0)
+	if err := getJSONAnnotation(cr, CCPUTopologyAnnotation, &sockets); err != nil {
+		return nil, errors.Wrap(err, "unable to get cpu topology")
+	}
+	return sockets, nil
+}
+
+// CPUMicroarch is a CPU microarchitecture as it is stored in the annotation
+type CPUMicroarch struct {
+	Vendor       string           `json:"vendor"`
+	Family       uint32           `json:"family"`
+	Model        uint32           `json:"model"`
+	Stepping     uint32           `json:"stepping"`
+	CodeName     string           `json:"codeName,omitempty"`
+	Level        int              `json:"level,omitempty"`
+	Capabilities []string         `json:"capabilities,omitempty"`
+	Hybrid       bool             `json:"hybrid,omitempty"`
+	CoreTypes    map[string][]int `json:"coreTypes,omitempty"`
+}
+
+func GetCPUMicroarch(cr *metalv1alpha1.Inventory) (*CPUMicroarch, error) {
+	microarch := &CPUMicroarch{}
+	if err := getJSONAnnotation(cr, CCPUMicroarchAnnotation, microarch); err != nil {
+		return nil, errors.Wrap(err, "unable to get cpu microarchitecture")
+	}
+	return microarch, nil
+}
+
+// CPUPower is a CPU power management configuration as it is stored in the annotation
+type CPUPower struct {
+	FrequencyPolicies []CPUFrequencyPolicy `json:"frequencyPolicies,omitempty"`
+	Boost             *bool                `json:"boost,omitempty"`
+	PStateDriver      string               `json:"pstateDriver,omitempty"`
+	PStateMode        string               `json:"pstateMode,omitempty"`
+	IdleDriver        string               `json:"idleDriver,omitempty"`
+	IdleGovernor      string               `json:"idleGovernor,omitempty"`
+	IdleStates        []CPUIdleState       `json:"idleStates,omitempty"`
+	RAPLZones         []RAPLZone           `json:"raplZones,omitempty"`
+}
+
+type CPUFrequencyPolicy struct {
+	CPUs                        []int  `json:"cpus"`
+	Driver                      string `json:"driver"`
+	Governor                    string `json:"governor,omitempty"`
+	MinKHz                      uint64 `json:"minKHz,omitempty"`
+	MaxKHz                      uint64 `json:"maxKHz,omitempty"`
+	BaseKHz                     uint64 `json:"baseKHz,omitempty"`
+	ScalingMinKHz               uint64 `json:"scalingMinKHz,omitempty"`
+	ScalingMaxKHz               uint64 `json:"scalingMaxKHz,omitempty"`
+	EnergyPerformancePreference string `json:"energyPerformancePreference,omitempty"`
+	Boost                       *bool  `json:"boost,omitempty"`
+}
+
+type CPUIdleState struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	LatencyUs   uint64 `json:"latencyUs"`
+	ResidencyUs uint64 `json:"residencyUs"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+type RAPLZone struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Enabled     bool             `json:"enabled"`
+	Constraints []RAPLConstraint `json:"constraints,omitempty"`
+}
+
+type RAPLConstraint struct {
+	Name         string `json:"name"`
+	PowerLimitUw uint64 `json:"powerLimitUw"`
+	TimeWindowUs uint64 `json:"timeWindowUs,omitempty"`
+	MaxPowerUw   uint64 `json:"maxPowerUw,omitempty"`
+}
+
+func GetCPUPower(cr *metalv1alpha1.Inventory) (*CPUPower, error) {
+	power := &CPUPower{}
+	if err := getJSONAnnotation(cr, CCPUPowerAnnotation, power); err != nil {
+		return nil, errors.Wrap(err, "unable to get cpu power")
+	}
+	return power, nil
+}
+
+// CPUVulnerabilities is a vulnerability status as it is stored in the annotation
+type CPUVulnerabilities struct {
+	// Microcode is a list of distinct microcode revisions of all CPUs
+	Microcode       []string           `json:"microcode,omitempty"`
+	Vulnerabilities []CPUVulnerability `json:"vulnerabilities"`
+}
+
+type CPUVulnerability struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Vulnerable bool   `json:"vulnerable"`
+	Text       string `json:"text"`
+}
+
+func GetCPUVulnerabilities(cr *metalv1alpha1.Inventory) (*CPUVulnerabilities, error) {
+	vulnerabilities := &CPUVulnerabilities{}
+	if err := getJSONAnnotation(cr, CCPUVulnerabilitiesAnnotation, vulnerabilities); err != nil {
+		return nil, errors.Wrap(err, "unable to get cpu vulnerabilities")
+	}
+	return vulnerabilities, nil
+}
+
+// HugePages is a hugepage pool as it is stored in annotations
+type HugePages struct {
+	// Size is a page size as quantity, e.g. 2Mi or 1Gi
+	Size       string `json:"size"`
+	Total      uint64 `json:"total"`
+	Free       uint64 `json:"free"`
+	Reserved   uint64 `json:"reserved,omitempty"`
+	Surplus    uint64 `json:"surplus,omitempty"`
+	Overcommit uint64 `json:"overcommit,omitempty"`
+}
+
+// NUMAHugePages are hugepage pools of NUMA node with the same ID as in NUMA spec
+type NUMAHugePages struct {
+	ID        int         `json:"id"`
+	HugePages []HugePages `json:"hugePages"`
+}
+
+func GetHugePages(cr *metalv1alpha1.Inventory) ([]HugePages, error) {
+	pools := make([]HugePages, 0)
+	if err := getJSONAnnotation(cr, CHugePagesAnnotation, &pools); err != nil {
+		return nil, errors.Wrap(err, "unable to get hugepages")
+	}
+	return pools, nil
+}
+
+func GetNUMAHugePages(cr *metalv1alpha1.Inventory) ([]NUMAHugePages, error) {
+	nodes := make([]NUMAHugePages, 0)
+	if err := getJSONAnnotation(cr, CNUMAHugePagesAnnotation, &nodes); err != nil {
+		return nil, errors.Wrap(err, "unable to get numa hugepages")
+	}
+	return nodes, nil
+}
+
+// NUMADevices are devices attached to NUMA node with the same ID as in NUMA spec
+type NUMADevices struct {
+	ID           int      `json:"id"`
+	PCIDevices   []string `json:"pciDevices,omitempty"`
+	NICs         []string `json:"nics,omitempty"`
+	BlockDevices []string `json:"blockDevices,omitempty"`
+}
+
+func GetNUMADevices(cr *metalv1alpha1.Inventory) ([]NUMADevices, error) {
+	nodes := make([]NUMADevices, 0)
+	if err := getJSONAnnotation(cr, CNUMADevicesAnnotation, &nodes); err != nil {
+		return nil, errors.Wrap(err, "unable to get numa devices")
+	}
+	return nodes, nil
+}
+
+// MemoryBlocks is a memory hotplug summary as it is stored in the annotation
+type MemoryBlocks struct {
+	BlockSize uint64             `json:"blockSize"`
+	Nodes     []MemoryBlocksNode `json:"nodes,omitempty"`
+}
+
+// MemoryBlocksNode is a summary of memory blocks of NUMA node, node is omitted for blocks without one
+type MemoryBlocksNode struct {
+	ID            *int   `json:"id,omitempty"`
+	OnlineBlocks  int    `json:"onlineBlocks"`
+	OfflineBlocks int    `json:"offlineBlocks"`
+	Online        uint64 `json:"online"`
+	Offline       uint64 `json:"offline"`
+	Movable       uint64 `json:"movable,omitempty"`
+	// OfflineRanges are IDs of offline blocks in kernel cpulist format, e.g. 32-47,64
+	OfflineRanges string `json:"offlineRanges,omitempty"`
+}
+
+func GetMemoryBlocks(cr *metalv1alpha1.Inventory) (*MemoryBlocks, error) {
+	data := &MemoryBlocks{}
+	if err := getJSONAnnotation(cr, CMemoryBlocksAnnotation, data); err != nil {
+		return nil, errors.Wrap(err, "unable to get memory blocks")
+	}
+	return data, nil
+}
+
+// NVDIMM is NVDIMM bus topology as it is stored in the annotation
+type NVDIMM struct {
+	DIMMs   []NVDIMMDevice `json:"dimms,omitempty"`
+	Regions []NVDIMMRegion `json:"regions,omitempty"`
+}
+
+type NVDIMMDevice struct {
+	Name   string   `json:"name"`
+	ID     string   `json:"id,omitempty"`
+	Serial string   `json:"serial,omitempty"`
+	Handle string   `json:"handle,omitempty"`
+	PhysID string   `json:"physId,omitempty"`
+	State  string   `json:"state,omitempty"`
+	Flags  []string `json:"flags,omitempty"`
+}
+
+type NVDIMMRegion struct {
+	Name              string `json:"name"`
+	Type              string `json:"type"`
+	Size              uint64 `json:"size"`
+	AvailableSize     uint64 `json:"availableSize,omitempty"`
+	NUMANode          *int   `json:"numaNode,omitempty"`
+	TargetNode        *int   `json:"targetNode,omitempty"`
+	PersistenceDomain string `json:"persistenceDomain,omitempty"`
+	ReadOnly          bool   `json:"readOnly,omitempty"`
+	// DIMMs are names of NVDIMM devices region is interleaved across
+	DIMMs      []string          `json:"dimms,omitempty"`
+	Namespaces []NVDIMMNamespace `json:"namespaces,omitempty"`
+}
+
+type NVDIMMNamespace struct {
+	Name   string `json:"name"`
+	Mode   string `json:"mode,omitempty"`
+	Size   uint64 `json:"size"`
+	UUID   string `json:"uuid,omitempty"`
+	Device string `json:"device,omitempty"`
+}
+
+func GetNVDIMM(cr *metalv1alpha1.Inventory) (*NVDIMM, error) {
+	data := &NVDIMM{}
+	if err := getJSONAnnotation(cr, CNVDIMMAnnotation, data); err != nil {
+		return nil, errors.Wrap(err, "unable to get nvdimm")
+	}
+	return data, nil
+}
+
+// CXL is CXL memory topology as it is stored in the annotation
+type CXL struct {
+	MemDevs  []CXLMemDev  `json:"memDevs,omitempty"`
+	Decoders []CXLDecoder `json:"decoders,omitempty"`
+	Regions  []CXLRegion  `json:"regions,omitempty"`
+}
+
+type CXLMemDev struct {
+	Name            string `json:"name"`
+	Serial          string `json:"serial,omitempty"`
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	NUMANode        *int   `json:"numaNode,omitempty"`
+	RAMSize         uint64 `json:"ramSize,omitempty"`
+	PMEMSize        uint64 `json:"pmemSize,omitempty"`
+}
+
+type CXLDecoder struct {
+	Name                  string `json:"name"`
+	Type                  string `json:"type"`
+	Start                 uint64 `json:"start"`
+	Size                  uint64 `json:"size"`
+	InterleaveWays        int    `json:"interleaveWays,omitempty"`
+	InterleaveGranularity int    `json:"interleaveGranularity,omitempty"`
+	TargetType            string `json:"targetType,omitempty"`
+	Mode                  string `json:"mode,omitempty"`
+	Region                string `json:"region,omitempty"`
+	Locked                bool   `json:"locked,omitempty"`
+}
+
+type CXLRegion struct {
+	Name                  string   `json:"name"`
+	Mode                  string   `json:"mode,omitempty"`
+	UUID                  string   `json:"uuid,omitempty"`
+	Start                 uint64   `json:"start"`
+	Size                  uint64   `json:"size"`
+	InterleaveWays        int      `json:"interleaveWays,omitempty"`
+	InterleaveGranularity int      `json:"interleaveGranularity,omitempty"`
+	Targets               []string `json:"targets,omitempty"`
+	NUMANodes             []int    `json:"numaNodes,omitempty"`
+}
+
+func GetCXL(cr *metalv1alpha1.Inventory) (*CXL, error) {
+	data := &CXL{}
+	if err := getJSONAnnotation(cr, CCXLAnnotation, data); err != nil {
+		return nil, errors.Wrap(err, "unable to get cxl")
+	}
+	return data, nil
+}
+
+// Processor is a processor socket as it is stored in the annotation
+type Processor struct {
+	// PhysicalID is set only if sockets are matched with physical CPUs
+	PhysicalID    *uint64 `json:"physicalId,omitempty"`
+	Socket        string  `json:"socket"`
+	SocketType    string  `json:"socketType,omitempty"`
+	Manufacturer  string  `json:"manufacturer,omitempty"`
+	Version       string  `json:"version,omitempty"`
+	Family        string  `json:"family,omitempty"`
+	ID            string  `json:"id,omitempty"`
+	Status        string  `json:"status,omitempty"`
+	Voltage       string  `json:"voltage,omitempty"`
+	ExternalClock uint16  `json:"externalClock,omitempty"`
+	MaxSpeed      uint16  `json:"maxSpeed,omitempty"`
+	CurrentSpeed  uint16  `json:"currentSpeed,omitempty"`
+	CoreCount     uint16  `json:"coreCount,omitempty"`
+	CoreEnabled   uint16  `json:"coreEnabled,omitempty"`
+	ThreadCount   uint16  `json:"threadCount,omitempty"`
+	SerialNumber  string  `json:"serialNumber,omitempty"`
+	PartNumber    string  `json:"partNumber,omitempty"`
+	AssetTag      string  `json:"assetTag,omitempty"`
+	L1CacheSize   uint64  `json:"l1CacheSize,omitempty"`
+	L2CacheSize   uint64  `json:"l2CacheSize,omitempty"`
+	L3CacheSize   uint64  `json:"l3CacheSize,omitempty"`
+}
+
+// PowerSupply is a power supply unit as it is stored in the annotation
+type PowerSupply struct {
+	Location       string `json:"location,omitempty"`
+	Name           string `json:"name,omitempty"`
+	Manufacturer   string `json:"manufacturer,omitempty"`
+	SerialNumber   string `json:"serialNumber,omitempty"`
+	PartNumber     string `json:"partNumber,omitempty"`
+	Revision       string `json:"revision,omitempty"`
+	MaxPower       uint16 `json:"maxPower,omitempty"`
+	Status         string `json:"status,omitempty"`
+	Present        bool   `json:"present"`
+	Unplugged      bool   `json:"unplugged"`
+	HotReplaceable bool   `json:"hotReplaceable"`
+}
+
+type HostInterface struct {
+	Type       string                 `json:"type,omitempty"`
+	DeviceType string                 `json:"deviceType,omitempty"`
+	VendorID   string                 `json:"vendorID,omitempty"`
+	ProductID  string                 `json:"productID,omitempty"`
+	MACAddress string                 `json:"macAddress,omitempty"`
+	PCIAddress string                 `json:"pciAddress,omitempty"`
+	Redfish    []RedfishHostInterface `json:"redfish,omitempty"`
+}
+
+type RedfishHostInterface struct {
+	ServiceUUID          string `json:"serviceUUID,omitempty"`
+	HostIPAssignmentType string `json:"hostIPAssignmentType,omitempty"`
+	HostIPAddress        string `json:"hostIPAddress,omitempty"`
+	HostIPMask           string `json:"hostIPMask,omitempty"`
+	ServiceDiscoveryType string `json:"serviceDiscoveryType,omitempty"`
+	ServiceIPAddress     string `json:"serviceIPAddress,omitempty"`
+	ServiceIPMask        string `json:"serviceIPMask,omitempty"`
+	ServicePort          uint16 `json:"servicePort,omitempty"`
+	ServiceVLANID        uint32 `json:"serviceVLANID,omitempty"`
+	ServiceHostname      string `json:"serviceHostname,omitempty"`
+}
+
+func GetHostInterfaces(cr *metalv1alpha1.Inventory) ([]HostInterface, error) {
+	hostInterfaces := make([]HostInterface, 0)
+	if err := getJSONAnnotation(cr, CBMCHostInterfacesAnnotation, &hostInterfaces); err != nil {
+		return nil, errors.Wrap(err, "unable to get host interfaces")
+	}
+	return hostInterfaces, nil
+}
+
+func GetPowerSupplies(cr *metalv1alpha1.Inventory) ([]PowerSupply, error) {
+	powerSupplies := make([]PowerSupply, 0)
+	if err := getJSONAnnotation(cr, CPowerSuppliesAnnotation, &powerSupplies); err != nil {
+		return nil, errors.Wrap(err, "unable to get power supplies")
+	}
+	return powerSupplies, nil
+}
+
+func GetProcessors(cr *metalv1alpha1.Inventory) ([]Processor, error) {
+	processors := make([]Processor, 0)
+	if err := getJSONAnnotation(cr, CProcessorsAnnotation, &processors); err != nil {
+		return nil, errors.Wrap(err, "unable to get processors")
+	}
+	return processors, nil
+}
+
+func GetMemoryDevices(cr *metalv1alpha1.Inventory) ([]MemoryDevice, error) {
+	devices := make([]MemoryDevice, 0)
+	if err := getJSONAnnotation(cr, CMemoryDevicesAnnotation, &devices); err != nil {
+		return nil, errors.Wrap(err, "unable to get memory devices")
+	}
+	return devices, nil
+}
+
+func getJSONAnnotation(cr *metalv1alpha1.Inventory, key string, val interface{}) error {
+	raw, ok := cr.Annotations[key]
+	if !ok || raw == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), val); err != nil {
+		return errors.Wrapf(err, "unable to unmarshal %s of %s", key, cr.Name)
+	}
+
+	return nil
+}
+
+func setJSONAnnotation(cr *metalv1alpha1.Inventory, key string, val interface{}) error {
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return errors.Wrapf(err, "unable to marshal %s of %s", key, cr.Name)
+	}
+
+	setAnnotation(cr, key, string(raw))
+
+	return nil
+}
+
+func setLabel(cr *metalv1alpha1.Inventory, key string, val string) {
+	if cr.Labels == nil {
+		cr.Labels = make(map[string]string)
+	}
+	cr.Labels[key] = val
+}
+
+func setAnnotation(cr *metalv1alpha1.Inventory, key string, val string) {
+	if cr.Annotations == nil {
+		cr.Annotations = make(map[string]string)
+	}
+	cr.Annotations[key] = val
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"reflect"
+	"testing"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMergeMeta(t *testing.T) {
+	dst := &metalv1alpha1.Inventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				CMetaPrefix + "cpu-sgx":       "true",
+				CMetaPrefix + "hugepages-1Gi": "4",
+				CMetaPrefix + "host-type":     "Machine",
+				CStaleLabel:                   "true",
+				"example.com/owner":           "team",
+			},
+			Annotations: map[string]string{
+				CMetaPrefix + "numa-devices": "[]",
+				CMetaPrefix + "fqdn":         "old.example.com",
+				"example.com/note":           "kept",
+			},
+		},
+	}
+	if err := SetCondition(dst, metav1.Condition{
+		Type:   "Foreign",
+		Status: metav1.ConditionTrue,
+		Reason: "SetByOther",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	src := &metalv1alpha1.Inventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				CMetaPrefix + "host-type": "Switch",
+			},
+			Annotations: map[string]string{
+				CMetaPrefix + "fqdn": "new.example.com",
+			},
+		},
+	}
+	if err := SetCondition(src, metav1.Condition{
+		Type:   CStaleConditionType,
+		Status: metav1.ConditionFalse,
+		Reason: CRefreshedReason,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mergeMeta(dst, src); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedLabels := map[string]string{
+		CMetaPrefix + "host-type": "Switch",
+		CStaleLabel:               "true",
+		"example.com/owner":       "team",
+	}
+	if !reflect.DeepEqual(dst.Labels, expectedLabels) {
+		t.Errorf("expected labels %v, got %v", expectedLabels, dst.Labels)
+	}
+
+	if _, ok := dst.Annotations[CMetaPrefix+"numa-devices"]; ok {
+		t.Error("expected annotation no longer produced by agent to be removed")
+	}
+	if dst.Annotations[CMetaPrefix+"fqdn"] != "new.example.com" {
+		t.Errorf("expected fqdn to be overwritten, got %s", dst.Annotations[CMetaPrefix+"fqdn"])
+	}
+	if dst.Annotations["example.com/note"] != "kept" {
+		t.Error("expected annotation of other prefix to be kept")
+	}
+
+	conditions, err := GetConditions(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := make(map[string]bool)
+	for _, condition := range conditions {
+		types[condition.Type] = true
+	}
+	if !types["Foreign"] || !types[CStaleConditionType] {
+		t.Errorf("expected both foreign and merged conditions, got %v", conditions)
+	}
+}
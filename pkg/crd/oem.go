@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"regexp"
+	"strings"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal/api/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const COEMStringRuleSeparator = "="
+
+// OEMStringRule promotes value of the first OEM string (or system configuration option)
+// matching the pattern into label or annotation with provided key.
+// Value is taken from the first capture group if pattern has one,
+// otherwise the whole match is used.
+type OEMStringRule struct {
+	Key        string
+	Pattern    *regexp.Regexp
+	Annotation bool
+}
+
+// ParseOEMStringRule parses rule in <key>=<pattern> format,
+// keys without prefix are placed under the inventory one
+func ParseOEMStringRule(rule string, annotation bool) (*OEMStringRule, error) {
+	key, pattern, found := strings.Cut(rule, COEMStringRuleSeparator)
+	if !found || key == "" || pattern == "" {
+		return nil, errors.Errorf("rule %s does not match <key>=<pattern> format", rule)
+	}
+
+	if !strings.Contains(key, "/") {
+		key = CMetaPrefix + key
+	}
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return nil, errors.Errorf("invalid key %s: %s", key, strings.Join(errs, ", "))
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to compile pattern %s", pattern)
+	}
+
+	return &OEMStringRule{
+		Key:        key,
+		Pattern:    re,
+		Annotation: annotation,
+	}, nil
+}
+
+func (r *OEMStringRule) apply(cr *metalv1alpha1.Inventory, values []string) error {
+	for _, val := range values {
+		groups := r.Pattern.FindStringSubmatch(val)
+		if groups == nil {
+			continue
+		}
+
+		match := groups[0]
+		if len(groups) > 1 {
+			match = groups[1]
+		}
+		match = strings.TrimSpace(match)
+
+		if r.Annotation {
+			setAnnotation(cr, r.Key, match)
+			return nil
+		}
+
+		if errs := validation.IsValidLabelValue(match); len(errs) > 0 {
+			return errors.Errorf("value %s matched for %s is not a valid label value: %s", match, r.Key, strings.Join(errs, ", "))
+		}
+		setLabel(cr, r.Key, match)
+		return nil
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"testing"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal/api/v1alpha1"
+)
+
+func TestParseOEMStringRule(t *testing.T) {
+	tests := []struct {
+		rule        string
+		expectedKey string
+		expectErr   bool
+	}{
+		{rule: "rack=^rack=(.+)$", expectedKey: CMetaPrefix + "rack"},
+		{rule: "example.com/sku=sku:(\\w+)", expectedKey: "example.com/sku"},
+		{rule: "rack", expectErr: true},
+		{rule: "=rack", expectErr: true},
+		{rule: "rack=", expectErr: true},
+		{rule: "bad key=.*", expectErr: true},
+		{rule: "rack=(", expectErr: true},
+	}
+
+	for _, test := range tests {
+		rule, err := ParseOEMStringRule(test.rule, false)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("rule %s: expected error, got %+v", test.rule, rule)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("rule %s: %v", test.rule, err)
+			continue
+		}
+		if rule.Key != test.expectedKey {
+			t.Errorf("rule %s: expected key %s, got %s", test.rule, test.expectedKey, rule.Key)
+		}
+	}
+}
+
+func TestOEMStringRuleApply(t *testing.T) {
+	values := []string{"sku=XYZ 1", "rack=R12 ", "rack=R13"}
+
+	tests := []struct {
+		name        string
+		rule        string
+		annotation  bool
+		expected    string
+		expectErr   bool
+		expectUnset bool
+	}{
+		{name: "first capture group", rule: "rack=^rack=(.+)$", expected: "R12"},
+		{name: "whole match", rule: "rack=R1\\d", expected: "R12"},
+		{name: "no match", rule: "rack=^row=", expectUnset: true},
+		{name: "invalid label value", rule: "sku=^sku=(.+)$", expectErr: true, expectUnset: true},
+		{name: "annotation", rule: "sku=^sku=(.+)$", annotation: true, expected: "XYZ 1"},
+	}
+
+	for _, test := range tests {
+		rule, err := ParseOEMStringRule(test.rule, test.annotation)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		cr := &metalv1alpha1.Inventory{}
+		err = rule.apply(cr, values)
+		if test.expectErr != (err != nil) {
+			t.Errorf("%s: expected error %t, got %v", test.name, test.expectErr, err)
+		}
+
+		meta := cr.Labels
+		if test.annotation {
+			meta = cr.Annotations
+		}
+		val, ok := meta[rule.Key]
+		if test.expectUnset {
+			if ok {
+				t.Errorf("%s: expected %s to be unset, got %s", test.name, rule.Key, val)
+			}
+			continue
+		}
+		if val != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, val)
+		}
+	}
+}
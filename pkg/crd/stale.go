@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metalv1alpha1 "github.com/ironcore-dev/metal/api/v1alpha1"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type StaleInventory struct {
+	Name     string
+	LastSeen time.Time
+	Age      time.Duration
+}
+
+type StaleSvc struct {
+	client client.Client
+}
+
+func NewKubeAPIStaleSvc(kubeconfig string) (*StaleSvc, error) {
+	cl, err := newKubeClient(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create k8s client")
+	}
+
+	return &StaleSvc{
+		client: cl,
+	}, nil
+}
+
+// GetStale returns inventories that were not refreshed within the window.
+// Inventories saved by agents without heartbeat support are judged
+// by their creation timestamp.
+func (s *StaleSvc) GetStale(window time.Duration) ([]StaleInventory, error) {
+	list := &metalv1alpha1.InventoryList{}
+	if err := s.client.List(context.Background(), list); err != nil {
+		return nil, errors.Wrap(err, "unable to list inventories")
+	}
+
+	now := time.Now().UTC()
+	stale := make([]StaleInventory, 0)
+	for _, inv := range list.Items {
+		lastSeen := getLastSeen(&inv)
+		age := now.Sub(lastSeen)
+		if age <= window {
+			continue
+		}
+
+		stale = append(stale, StaleInventory{
+			Name:     inv.Name,
+			LastSeen: lastSeen,
+			Age:      age,
+		})
+	}
+
+	return stale, nil
+}
+
+func (s *StaleSvc) MarkStale(stale StaleInventory) error {
+	existing := &metalv1alpha1.Inventory{}
+	if err := s.client.Get(context.Background(), client.ObjectKey{Name: stale.Name}, existing); err != nil {
+		return errors.Wrapf(err, "unable to get inventory %s", stale.Name)
+	}
+
+	// inventory could be refreshed since it was listed
+	if time.Now().UTC().Sub(getLastSeen(existing)) < stale.Age {
+		return nil
+	}
+
+	if existing.Labels[CStaleLabel] == "true" {
+		return nil
+	}
+
+	setLabel(existing, CStaleLabel, "true")
+	err := SetCondition(existing, metav1.Condition{
+		Type:               CStaleConditionType,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             CHeartbeatMissingReason,
+		Message:            fmt.Sprintf("inventory was not refreshed since %s", stale.LastSeen.Format(time.RFC3339)),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to set stale condition for %s", stale.Name)
+	}
+
+	if err := s.client.Update(context.Background(), existing); err != nil {
+		return errors.Wrapf(err, "unable to update inventory %s", stale.Name)
+	}
+
+	return nil
+}
+
+func getLastSeen(inv *metalv1alpha1.Inventory) time.Time {
+	if raw, ok := inv.Annotations[CLastSeenAnnotation]; ok {
+		if lastSeen, err := time.Parse(time.RFC3339, raw); err == nil {
+			return lastSeen
+		}
+	}
+
+	return inv.CreationTimestamp.UTC()
+}
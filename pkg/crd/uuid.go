@@ -3,7 +3,203 @@
 
 package crd
 
-import "github.com/google/uuid"
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/inventory"
+	"github.com/onmetal/inventory/pkg/nic"
+)
+
+type IdentitySource string
+
+const (
+	CDMIUUIDIdentitySource       IdentitySource = "dmi-uuid"
+	CSystemSerialIdentitySource  IdentitySource = "system-serial"
+	CChassisSerialIdentitySource IdentitySource = "chassis-serial"
+	CBoardSerialIdentitySource   IdentitySource = "board-serial"
+	CMACIdentitySource           IdentitySource = "mac"
+)
+
+var CIdentitySources = []IdentitySource{
+	CDMIUUIDIdentitySource,
+	CSystemSerialIdentitySource,
+	CChassisSerialIdentitySource,
+	CBoardSerialIdentitySource,
+	CMACIdentitySource,
+}
+
+var CDefaultIdentitySources = []IdentitySource{
+	CDMIUUIDIdentitySource,
+	CChassisSerialIdentitySource,
+	CBoardSerialIdentitySource,
+	CMACIdentitySource,
+}
+
+// CBogusUUIDs are UUIDs that firmware reports when vendor has not set one,
+// they are shared by many machines and can not be used as identity
+var CBogusUUIDs = []string{
+	"00000000-0000-0000-0000-000000000000",
+	"ffffffff-ffff-ffff-ffff-ffffffffffff",
+	// SONiC switches and various whitebox boards
+	"03000200-0400-0500-0006-000700080009",
+	// AMI BIOS default
+	"00020003-0004-0005-0006-000700080009",
+}
+
+// CBogusSerialNumbers are placeholders firmware reports when vendor has not set serial number
+var CBogusSerialNumbers = []string{
+	"",
+	"0",
+	"0123456789",
+	"123456789",
+	"default string",
+	"none",
+	"n/a",
+	"not applicable",
+	"not specified",
+	"system serial number",
+	"chassis serial number",
+	"base board serial number",
+	"to be filled by o.e.m.",
+}
+
+const (
+	CZeroMACAddress = "00:00:00:00:00:00"
+)
+
+// IdentityResolver chooses the inventory name from the first source
+// in the chain that provides a non placeholder value
+type IdentityResolver struct {
+	sources    []IdentitySource
+	bogusUUIDs map[string]struct{}
+}
+
+func NewIdentityResolver(sources []IdentitySource, bogusUUIDs []string) *IdentityResolver {
+	denylist := make(map[string]struct{}, len(CBogusUUIDs)+len(bogusUUIDs))
+	for _, id := range append(CBogusUUIDs, bogusUUIDs...) {
+		denylist[strings.ToLower(id)] = struct{}{}
+	}
+
+	return &IdentityResolver{
+		sources:    sources,
+		bogusUUIDs: denylist,
+	}
+}
+
+func ParseIdentitySources(sources []string) ([]IdentitySource, error) {
+	parsed := make([]IdentitySource, 0, len(sources))
+	for _, source := range sources {
+		found := false
+		for _, known := range CIdentitySources {
+			if IdentitySource(source) == known {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("unknown identity source %s", source)
+		}
+		parsed = append(parsed, IdentitySource(source))
+	}
+	return parsed, nil
+}
+
+// Resolve returns the inventory name and the source it was taken from
+func (r *IdentityResolver) Resolve(inv *inventory.Inventory) (string, IdentitySource, error) {
+	sources := r.sources
+	// SONiC switches has dumb UUIDs like 03000200-0400-0500-0006-000700080009, maybe
+	// the same on any switch, so it was decided to use md5 hash of serial number as UUID
+	if inv.Host != nil && inv.Host.IsSONiC() {
+		sources = append([]IdentitySource{CSystemSerialIdentitySource}, sources...)
+	}
+
+	for _, source := range sources {
+		if id := r.resolveSource(inv, source); id != "" {
+			return id, source, nil
+		}
+	}
+
+	return "", "", errors.New("none of identity sources provides a valid value")
+}
+
+func (r *IdentityResolver) resolveSource(inv *inventory.Inventory, source IdentitySource) string {
+	if inv.DMI == nil {
+		return ""
+	}
+
+	switch source {
+	case CDMIUUIDIdentitySource:
+		if inv.DMI.SystemInformation == nil {
+			return ""
+		}
+		id := strings.ToLower(inv.DMI.SystemInformation.UUID)
+		if _, ok := r.bogusUUIDs[id]; ok || id == "" {
+			return ""
+		}
+		return id
+	case CSystemSerialIdentitySource:
+		if inv.DMI.SystemInformation == nil || isBogusSerialNumber(inv.DMI.SystemInformation.SerialNumber) {
+			return ""
+		}
+		// kept without source prefix to preserve names of existing SONiC inventories
+		return getUUID(CSonicNamespace, inv.DMI.SystemInformation.SerialNumber)
+	case CChassisSerialIdentitySource:
+		if len(inv.DMI.ChassisInformation) == 0 || isBogusSerialNumber(inv.DMI.ChassisInformation[0].SerialNumber) {
+			return ""
+		}
+		return getUUID(CSonicNamespace, string(source)+":"+inv.DMI.ChassisInformation[0].SerialNumber)
+	case CBoardSerialIdentitySource:
+		if len(inv.DMI.BoardInformation) == 0 || isBogusSerialNumber(inv.DMI.BoardInformation[0].SerialNumber) {
+			return ""
+		}
+		return getUUID(CSonicNamespace, string(source)+":"+inv.DMI.BoardInformation[0].SerialNumber)
+	case CMACIdentitySource:
+		mac := lowestPermanentMACAddress(inv.NICs)
+		if mac == "" {
+			return ""
+		}
+		return getUUID(CSonicNamespace, string(source)+":"+mac)
+	}
+
+	return ""
+}
+
+func isBogusSerialNumber(serial string) bool {
+	serial = strings.ToLower(strings.TrimSpace(serial))
+	for _, bogus := range CBogusSerialNumbers {
+		if serial == bogus {
+			return true
+		}
+	}
+	return false
+}
+
+// lowestPermanentMACAddress returns the lowest burned-in MAC address,
+// so it does not change if NICs are renamed or enumerated in different order
+func lowestPermanentMACAddress(nics []nic.Device) string {
+	addresses := make([]string, 0)
+	for _, device := range nics {
+		if device.AddressAssignType != nic.CPermanentAddressAssignType {
+			continue
+		}
+		address := strings.ToLower(device.Address)
+		if address == "" || address == CZeroMACAddress {
+			continue
+		}
+		addresses = append(addresses, address)
+	}
+
+	if len(addresses) == 0 {
+		return ""
+	}
+
+	sort.Strings(addresses)
+	return addresses[0]
+}
 
 func getUUID(namespace string, identifier string) string {
 	namespaceUUID := uuid.NewMD5(uuid.UUID{}, []byte(namespace))
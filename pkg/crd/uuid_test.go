@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/dmi"
+	"github.com/onmetal/inventory/pkg/host"
+	"github.com/onmetal/inventory/pkg/inventory"
+	"github.com/onmetal/inventory/pkg/nic"
+	"github.com/onmetal/inventory/pkg/utils"
+)
+
+func TestParseIdentitySources(t *testing.T) {
+	sources, err := ParseIdentitySources([]string{"mac", "dmi-uuid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []IdentitySource{CMACIdentitySource, CDMIUUIDIdentitySource}
+	if !reflect.DeepEqual(sources, expected) {
+		t.Errorf("expected %+v, got %+v", expected, sources)
+	}
+
+	if _, err := ParseIdentitySources([]string{"dmi-uuid", "hostname"}); err == nil {
+		t.Error("expected error for unknown source")
+	}
+}
+
+func TestIdentityResolverResolve(t *testing.T) {
+	nics := []nic.Device{
+		{Name: "eth1", Address: "0a:94:ef:00:00:02", AddressAssignType: nic.CPermanentAddressAssignType},
+		{Name: "eth0", Address: "0a:94:ef:00:00:01", AddressAssignType: nic.CPermanentAddressAssignType},
+		{Name: "bond0", Address: "0a:94:ef:00:00:00", AddressAssignType: nic.CStolenFromAnotherDeviceAddressAssignType},
+	}
+
+	tests := []struct {
+		name           string
+		inv            *inventory.Inventory
+		bogusUUIDs     []string
+		expectedID     string
+		expectedSource IdentitySource
+		expectErr      bool
+	}{
+		{
+			name: "DMI UUID",
+			inv: &inventory.Inventory{
+				DMI: &dmi.DMI{
+					SystemInformation: &dmi.SystemInformation{UUID: "4C4C4544-0032-5910-8043-B4C04F4B3732"},
+				},
+			},
+			expectedID:     "4c4c4544-0032-5910-8043-b4c04f4b3732",
+			expectedSource: CDMIUUIDIdentitySource,
+		},
+		{
+			name: "bogus UUID falls back to chassis serial",
+			inv: &inventory.Inventory{
+				DMI: &dmi.DMI{
+					SystemInformation:  &dmi.SystemInformation{UUID: "03000200-0400-0500-0006-000700080009"},
+					ChassisInformation: []dmi.ChassisInformation{{SerialNumber: "CZ1234"}},
+				},
+			},
+			expectedID:     getUUID(CSonicNamespace, "chassis-serial:CZ1234"),
+			expectedSource: CChassisSerialIdentitySource,
+		},
+		{
+			name: "configured bogus UUID and placeholder serials fall back to MAC",
+			inv: &inventory.Inventory{
+				DMI: &dmi.DMI{
+					SystemInformation:  &dmi.SystemInformation{UUID: "12345678-1234-1234-1234-123456789abc"},
+					ChassisInformation: []dmi.ChassisInformation{{SerialNumber: "To Be Filled By O.E.M."}},
+					BoardInformation:   []dmi.BoardInformation{{SerialNumber: " Default string "}},
+				},
+				NICs: nics,
+			},
+			bogusUUIDs:     []string{"12345678-1234-1234-1234-123456789ABC"},
+			expectedID:     getUUID(CSonicNamespace, "mac:0a:94:ef:00:00:01"),
+			expectedSource: CMACIdentitySource,
+		},
+		{
+			name: "SONiC uses system serial first",
+			inv: &inventory.Inventory{
+				DMI: &dmi.DMI{
+					SystemInformation: &dmi.SystemInformation{
+						UUID:         "4c4c4544-0032-5910-8043-b4c04f4b3732",
+						SerialNumber: "SW1234",
+					},
+				},
+				Host: &host.Info{Type: utils.CSwitchType, NetworkOS: host.CSONiCNetworkOS},
+			},
+			expectedID:     getUUID(CSonicNamespace, "SW1234"),
+			expectedSource: CSystemSerialIdentitySource,
+		},
+		{
+			name: "nothing resolved",
+			inv: &inventory.Inventory{
+				DMI: &dmi.DMI{SystemInformation: &dmi.SystemInformation{}},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "no DMI",
+			inv:       &inventory.Inventory{},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		resolver := NewIdentityResolver(CDefaultIdentitySources, test.bogusUUIDs)
+		id, source, err := resolver.Resolve(test.inv)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %s from %s", test.name, id, source)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if id != test.expectedID || source != test.expectedSource {
+			t.Errorf("%s: expected %s from %s, got %s from %s", test.name, test.expectedID, test.expectedSource, id, source)
+		}
+	}
+}
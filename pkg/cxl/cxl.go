@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cxl
+
+type DecoderType string
+
+const (
+	CDecoderTypeRoot     DecoderType = "root"
+	CDecoderTypeSwitch   DecoderType = "switch"
+	CDecoderTypeEndpoint DecoderType = "endpoint"
+
+	// CNoNUMANode is reported for numa_node and target_node if firmware does not describe them
+	CNoNUMANode = -1
+)
+
+// CXL is a CXL.mem topology, sizes and addresses are in bytes
+type CXL struct {
+	MemDevs  []MemDev
+	Decoders []Decoder
+	Regions  []Region
+}
+
+// MemDev is a CXL memory expander, NUMANode is the node of its host bridge, not of its memory
+type MemDev struct {
+	Name            string
+	Serial          string
+	FirmwareVersion string
+	NUMANode        int
+	RAMSize         uint64
+	PMEMSize        uint64
+}
+
+// Decoder is an HDM decoder of a host bridge, switch or endpoint, unused decoders of zero size are skipped
+type Decoder struct {
+	Name                  string
+	Type                  DecoderType
+	Start                 uint64
+	Size                  uint64
+	InterleaveWays        int
+	InterleaveGranularity int
+	// TargetType is expander for memory devices and accelerator for type 2 devices
+	TargetType string
+	// Mode is ram or pmem, reported by endpoint decoders only
+	Mode   string
+	Region string
+	Locked bool
+}
+
+// Region is a host physical address range interleaved across endpoint decoders
+type Region struct {
+	Name                  string
+	Mode                  string
+	UUID                  string
+	Start                 uint64
+	Size                  uint64
+	InterleaveWays        int
+	InterleaveGranularity int
+	// Targets are names of endpoint decoders in interleave order
+	Targets []string
+	// NUMANodes are nodes region memory is onlined to through device DAX
+	NUMANodes []int
+}
@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cxl
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CCXLBusDevicesPath = "/sys/bus/cxl/devices"
+
+	CUEventPath         = "/uevent"
+	CUEventDevTypeKey   = "DEVTYPE"
+	CDecoderTypePrefix  = "cxl_decoder_"
+	CNUMANodePath       = "/numa_node"
+	CSerialPath         = "/serial"
+	CFirmwareVersion    = "/firmware_version"
+	CRAMSizePath        = "/ram/size"
+	CPMEMSizePath       = "/pmem/size"
+	CStartPath          = "/start"
+	CResourcePath       = "/resource"
+	CSizePath           = "/size"
+	CInterleaveWaysPath = "/interleave_ways"
+	CInterleaveGranPath = "/interleave_granularity"
+	CTargetTypePath     = "/target_type"
+	CModePath           = "/mode"
+	CRegionPath         = "/region"
+	CLockedPath         = "/locked"
+	CUUIDPath           = "/uuid"
+	CTargetPath         = "/target%d"
+	// device DAX instances of ram region, target_node is a node its memory is onlined to
+	CDAXTargetNodeGlob = "/dax_region*/dax*/target_node"
+
+	CMemDevDirNamePattern  = "^mem[0-9]+$"
+	CDecoderDirNamePattern = "^decoder[0-9]+\\.[0-9]+$"
+	CRegionDirNamePattern  = "^region[0-9]+$"
+)
+
+var CMemDevDirNameRegexp = regexp.MustCompile(CMemDevDirNamePattern)
+var CDecoderDirNameRegexp = regexp.MustCompile(CDecoderDirNamePattern)
+var CRegionDirNameRegexp = regexp.MustCompile(CRegionDirNamePattern)
+
+type Svc struct {
+	printer        *printer.Svc
+	cxlDevicesPath string
+}
+
+func NewSvc(printer *printer.Svc, basePath string) *Svc {
+	return &Svc{
+		printer:        printer,
+		cxlDevicesPath: path.Join(basePath, CCXLBusDevicesPath),
+	}
+}
+
+func (s *Svc) GetData() (*CXL, error) {
+	entries, err := os.ReadDir(s.cxlDevicesPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read cxl devices from %s", s.cxlDevicesPath)
+	}
+
+	data := &CXL{
+		MemDevs:  make([]MemDev, 0),
+		Decoders: make([]Decoder, 0),
+		Regions:  make([]Region, 0),
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		devicePath := path.Join(s.cxlDevicesPath, name)
+
+		switch {
+		case CMemDevDirNameRegexp.MatchString(name):
+			data.MemDevs = append(data.MemDevs, getMemDev(devicePath, name))
+		case CDecoderDirNameRegexp.MatchString(name):
+			decoder, err := getDecoder(devicePath, name)
+			if err != nil {
+				s.printer.VErr(errors.Wrapf(err, "unable to get %s", name))
+				continue
+			}
+			if decoder.Size == 0 {
+				continue
+			}
+			data.Decoders = append(data.Decoders, *decoder)
+		case CRegionDirNameRegexp.MatchString(name):
+			region, err := s.getRegion(devicePath, name)
+			if err != nil {
+				s.printer.VErr(errors.Wrapf(err, "unable to get %s", name))
+				continue
+			}
+			data.Regions = append(data.Regions, *region)
+		}
+	}
+
+	if len(data.MemDevs) == 0 {
+		return nil, errors.Errorf("no cxl memory devices found in %s", s.cxlDevicesPath)
+	}
+
+	sort.Slice(data.MemDevs, func(i, j int) bool {
+		return data.MemDevs[i].Name < data.MemDevs[j].Name
+	})
+	sort.Slice(data.Decoders, func(i, j int) bool {
+		return data.Decoders[i].Name < data.Decoders[j].Name
+	})
+	sort.Slice(data.Regions, func(i, j int) bool {
+		return data.Regions[i].Name < data.Regions[j].Name
+	})
+
+	return data, nil
+}
+
+func getMemDev(thePath string, name string) MemDev {
+	memDev := MemDev{
+		Name:     name,
+		NUMANode: CNoNUMANode,
+	}
+
+	memDev.Serial, _ = file.ToString(path.Join(thePath, CSerialPath))
+	memDev.FirmwareVersion, _ = file.ToString(path.Join(thePath, CFirmwareVersion))
+	if node, err := file.ToInt(path.Join(thePath, CNUMANodePath)); err == nil {
+		memDev.NUMANode = node
+	}
+	if size, err := toUint64(path.Join(thePath, CRAMSizePath)); err == nil {
+		memDev.RAMSize = size
+	}
+	if size, err := toUint64(path.Join(thePath, CPMEMSizePath)); err == nil {
+		memDev.PMEMSize = size
+	}
+
+	return memDev
+}
+
+func getDecoder(thePath string, name string) (*Decoder, error) {
+	devType, err := getDevType(thePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get device type")
+	}
+
+	size, err := toUint64(path.Join(thePath, CSizePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get size")
+	}
+
+	decoder := &Decoder{
+		Name: name,
+		Type: DecoderType(strings.TrimPrefix(devType, CDecoderTypePrefix)),
+		Size: size,
+	}
+
+	if start, err := toUint64(path.Join(thePath, CStartPath)); err == nil {
+		decoder.Start = start
+	}
+	if ways, err := file.ToInt(path.Join(thePath, CInterleaveWaysPath)); err == nil {
+		decoder.InterleaveWays = ways
+	}
+	if granularity, err := file.ToInt(path.Join(thePath, CInterleaveGranPath)); err == nil {
+		decoder.InterleaveGranularity = granularity
+	}
+	decoder.TargetType, _ = file.ToString(path.Join(thePath, CTargetTypePath))
+	decoder.Mode, _ = file.ToString(path.Join(thePath, CModePath))
+	decoder.Region, _ = file.ToString(path.Join(thePath, CRegionPath))
+	if locked, err := file.ToBool(path.Join(thePath, CLockedPath)); err == nil {
+		decoder.Locked = locked
+	}
+
+	return decoder, nil
+}
+
+func (s *Svc) getRegion(thePath string, name string) (*Region, error) {
+	size, err := toUint64(path.Join(thePath, CSizePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get size")
+	}
+
+	region := &Region{
+		Name:      name,
+		Size:      size,
+		Targets:   make([]string, 0),
+		NUMANodes: make([]int, 0),
+	}
+
+	if start, err := toUint64(path.Join(thePath, CResourcePath)); err == nil {
+		region.Start = start
+	}
+	if ways, err := file.ToInt(path.Join(thePath, CInterleaveWaysPath)); err == nil {
+		region.InterleaveWays = ways
+	}
+	if granularity, err := file.ToInt(path.Join(thePath, CInterleaveGranPath)); err == nil {
+		region.InterleaveGranularity = granularity
+	}
+	region.Mode, _ = file.ToString(path.Join(thePath, CModePath))
+	region.UUID, _ = file.ToString(path.Join(thePath, CUUIDPath))
+
+	for i := 0; i < region.InterleaveWays; i++ {
+		target, err := file.ToString(path.Join(thePath, fmt.Sprintf(CTargetPath, i)))
+		if err != nil || target == "" {
+			continue
+		}
+		region.Targets = append(region.Targets, target)
+	}
+
+	targetNodePaths, err := filepath.Glob(path.Join(thePath, CDAXTargetNodeGlob))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to find dax target nodes")
+	}
+	for _, targetNodePath := range targetNodePaths {
+		node, err := file.ToInt(targetNodePath)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get target node of %s", name))
+			continue
+		}
+		if node != CNoNUMANode {
+			region.NUMANodes = append(region.NUMANodes, node)
+		}
+	}
+	sort.Ints(region.NUMANodes)
+
+	return region, nil
+}
+
+// toUint64 reads a number that cxl driver prints in hexadecimal
+func toUint64(thePath string) (uint64, error) {
+	str, err := file.ToString(thePath)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to read string from file %s", thePath)
+	}
+
+	num, err := strconv.ParseUint(str, 0, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to convert %s to uint64", str)
+	}
+
+	return num, nil
+}
+
+func getDevType(thePath string) (string, error) {
+	uevent, err := os.ReadFile(path.Join(thePath, CUEventPath))
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read uevent")
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(uevent))
+	for scanner.Scan() {
+		key, val, ok := strings.Cut(scanner.Text(), "=")
+		if ok && key == CUEventDevTypeKey {
+			return val, nil
+		}
+	}
+
+	return "", errors.Errorf("no %s in uevent", CUEventDevTypeKey)
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cxl
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func writeFile(t *testing.T, root string, thePath string, data string) {
+	t.Helper()
+	fullPath := path.Join(root, thePath)
+	if err := os.MkdirAll(path.Dir(fullPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetData(t *testing.T) {
+	root := t.TempDir()
+	devices := func(elem ...string) string {
+		return path.Join(append([]string{CCXLBusDevicesPath}, elem...)...)
+	}
+
+	writeFile(t, root, devices("mem1", CSerialPath), "0x2\n")
+	writeFile(t, root, devices("mem0", CSerialPath), "0x1\n")
+	writeFile(t, root, devices("mem0", CFirmwareVersion), "BWFW VERSION 00\n")
+	writeFile(t, root, devices("mem0", CNUMANodePath), "0\n")
+	writeFile(t, root, devices("mem0", CRAMSizePath), "0x40000000\n")
+	writeFile(t, root, devices("mem0", CPMEMSizePath), "0x0\n")
+
+	writeFile(t, root, devices("decoder0.0", CUEventPath), "DEVTYPE=cxl_decoder_root\n")
+	writeFile(t, root, devices("decoder0.0", CStartPath), "0x1050000000\n")
+	writeFile(t, root, devices("decoder0.0", CSizePath), "0x80000000\n")
+	writeFile(t, root, devices("decoder0.0", CInterleaveWaysPath), "1\n")
+	writeFile(t, root, devices("decoder0.0", CInterleaveGranPath), "256\n")
+	writeFile(t, root, devices("decoder0.0", CTargetTypePath), "expander\n")
+	writeFile(t, root, devices("decoder2.0", CUEventPath), "DEVTYPE=cxl_decoder_endpoint\n")
+	writeFile(t, root, devices("decoder2.0", CStartPath), "0x1050000000\n")
+	writeFile(t, root, devices("decoder2.0", CSizePath), "0x40000000\n")
+	writeFile(t, root, devices("decoder2.0", CInterleaveWaysPath), "1\n")
+	writeFile(t, root, devices("decoder2.0", CTargetTypePath), "expander\n")
+	writeFile(t, root, devices("decoder2.0", CModePath), "ram\n")
+	writeFile(t, root, devices("decoder2.0", CRegionPath), "region0\n")
+	writeFile(t, root, devices("decoder2.0", CLockedPath), "1\n")
+	// unused decoder is skipped
+	writeFile(t, root, devices("decoder2.1", CUEventPath), "DEVTYPE=cxl_decoder_endpoint\n")
+	writeFile(t, root, devices("decoder2.1", CSizePath), "0x0\n")
+
+	writeFile(t, root, devices("region0", CResourcePath), "0x1050000000\n")
+	writeFile(t, root, devices("region0", CSizePath), "0x40000000\n")
+	writeFile(t, root, devices("region0", CInterleaveWaysPath), "1\n")
+	writeFile(t, root, devices("region0", CInterleaveGranPath), "256\n")
+	writeFile(t, root, devices("region0", CModePath), "ram\n")
+	writeFile(t, root, devices("region0", CUUIDPath), "\n")
+	writeFile(t, root, devices("region0", "target0"), "decoder2.0\n")
+	writeFile(t, root, devices("region0", "dax_region0", "dax0.0", "target_node"), "2\n")
+	writeFile(t, root, devices("region0", "dax_region0", "dax0.1", "target_node"), "-1\n")
+	// region without size is skipped
+	writeFile(t, root, devices("region1", CModePath), "pmem\n")
+
+	data, err := NewSvc(printer.NewSvc(false), root).GetData()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &CXL{
+		MemDevs: []MemDev{
+			{
+				Name:            "mem0",
+				Serial:          "0x1",
+				FirmwareVersion: "BWFW VERSION 00",
+				NUMANode:        0,
+				RAMSize:         1 << 30,
+			},
+			{Name: "mem1", Serial: "0x2", NUMANode: CNoNUMANode},
+		},
+		Decoders: []Decoder{
+			{
+				Name:                  "decoder0.0",
+				Type:                  CDecoderTypeRoot,
+				Start:                 0x1050000000,
+				Size:                  2 << 30,
+				InterleaveWays:        1,
+				InterleaveGranularity: 256,
+				TargetType:            "expander",
+			},
+			{
+				Name:           "decoder2.0",
+				Type:           CDecoderTypeEndpoint,
+				Start:          0x1050000000,
+				Size:           1 << 30,
+				InterleaveWays: 1,
+				TargetType:     "expander",
+				Mode:           "ram",
+				Region:         "region0",
+				Locked:         true,
+			},
+		},
+		Regions: []Region{
+			{
+				Name:                  "region0",
+				Mode:                  "ram",
+				Start:                 0x1050000000,
+				Size:                  1 << 30,
+				InterleaveWays:        1,
+				InterleaveGranularity: 256,
+				Targets:               []string{"decoder2.0"},
+				NUMANodes:             []int{2},
+			},
+		},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected %+v, got %+v", expected, data)
+	}
+
+	if _, err := NewSvc(printer.NewSvc(false), t.TempDir()).GetData(); err == nil {
+		t.Error("no cxl bus: expected error")
+	}
+}
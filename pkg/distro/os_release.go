@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package distro
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+const (
+	COSReleaseIDKey              = "ID"
+	COSReleaseIDLikeKey          = "ID_LIKE"
+	COSReleaseNameKey            = "NAME"
+	COSReleaseVersionKey         = "VERSION"
+	COSReleaseVersionIDKey       = "VERSION_ID"
+	COSReleaseVersionCodenameKey = "VERSION_CODENAME"
+	COSReleasePrettyNameKey      = "PRETTY_NAME"
+	COSReleaseVariantKey         = "VARIANT"
+	COSReleaseVariantIDKey       = "VARIANT_ID"
+	COSReleaseBuildIDKey         = "BUILD_ID"
+	COSReleaseImageIDKey         = "IMAGE_ID"
+	COSReleaseImageVersionKey    = "IMAGE_VERSION"
+)
+
+// OSRelease contains identification data from os-release file,
+// see https://www.freedesktop.org/software/systemd/man/os-release.html
+type OSRelease struct {
+	ID              string
+	IDLike          []string
+	Name            string
+	Version         string
+	VersionID       string
+	VersionCodename string
+	PrettyName      string
+	Variant         string
+	VariantID       string
+	BuildID         string
+	ImageID         string
+	ImageVersion    string
+}
+
+func (r *OSRelease) setField(key string, val string) {
+	switch key {
+	case COSReleaseIDKey:
+		r.ID = val
+	case COSReleaseIDLikeKey:
+		r.IDLike = strings.Fields(val)
+	case COSReleaseNameKey:
+		r.Name = val
+	case COSReleaseVersionKey:
+		r.Version = val
+	case COSReleaseVersionIDKey:
+		r.VersionID = val
+	case COSReleaseVersionCodenameKey:
+		r.VersionCodename = val
+	case COSReleasePrettyNameKey:
+		r.PrettyName = val
+	case COSReleaseVariantKey:
+		r.Variant = val
+	case COSReleaseVariantIDKey:
+		r.VariantID = val
+	case COSReleaseBuildIDKey:
+		r.BuildID = val
+	case COSReleaseImageIDKey:
+		r.ImageID = val
+	case COSReleaseImageVersionKey:
+		r.ImageVersion = val
+	}
+}
+
+// IsLike tells whether distro is the one with provided id or derived from it
+func (r *OSRelease) IsLike(id string) bool {
+	if r.ID == id {
+		return true
+	}
+	for _, like := range r.IDLike {
+		if like == id {
+			return true
+		}
+	}
+	return false
+}
+
+func parseOSRelease(data []byte) *OSRelease {
+	release := &OSRelease{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, val, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+
+		release.setField(strings.TrimSpace(key), unquoteOSReleaseValue(strings.TrimSpace(val)))
+	}
+
+	return release
+}
+
+// os-release values follow shell quoting rules, but
+// only a limited subset of escapes is allowed inside double quotes
+func unquoteOSReleaseValue(val string) string {
+	if len(val) < 2 {
+		return val
+	}
+
+	first, last := val[0], val[len(val)-1]
+	if first != last || (first != '"' && first != '\'') {
+		return val
+	}
+
+	val = val[1 : len(val)-1]
+	if first == '\'' {
+		return val
+	}
+
+	replacer := strings.NewReplacer(`\"`, `"`, `\\`, `\`, `\$`, `$`, "\\`", "`")
+	return replacer.Replace(val)
+}
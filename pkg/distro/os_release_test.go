@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package distro
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOSRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected *OSRelease
+	}{
+		{
+			name: "ubuntu",
+			data: `PRETTY_NAME="Ubuntu 22.04.4 LTS"
+NAME="Ubuntu"
+VERSION_ID="22.04"
+VERSION="22.04.4 LTS (Jammy Jellyfish)"
+VERSION_CODENAME=jammy
+ID=ubuntu
+ID_LIKE=debian
+`,
+			expected: &OSRelease{
+				ID:              "ubuntu",
+				IDLike:          []string{"debian"},
+				Name:            "Ubuntu",
+				Version:         "22.04.4 LTS (Jammy Jellyfish)",
+				VersionID:       "22.04",
+				VersionCodename: "jammy",
+				PrettyName:      "Ubuntu 22.04.4 LTS",
+			},
+		},
+		{
+			name: "comments, quoting and escapes",
+			data: `# comment
+ID='gardenlinux'
+ID_LIKE="debian  ubuntu"
+
+VARIANT_ID = "metal"
+PRETTY_NAME="Garden \"Linux\" \$1"
+BROKEN
+`,
+			expected: &OSRelease{
+				ID:         "gardenlinux",
+				IDLike:     []string{"debian", "ubuntu"},
+				VariantID:  "metal",
+				PrettyName: `Garden "Linux" $1`,
+			},
+		},
+		{
+			name:     "empty",
+			data:     "",
+			expected: &OSRelease{},
+		},
+	}
+
+	for _, test := range tests {
+		release := parseOSRelease([]byte(test.data))
+		if !reflect.DeepEqual(release, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, release)
+		}
+	}
+}
+
+func TestOSReleaseIsLike(t *testing.T) {
+	release := &OSRelease{ID: "ubuntu", IDLike: []string{"debian"}}
+	if !release.IsLike("ubuntu") || !release.IsLike("debian") || release.IsLike("rhel") {
+		t.Errorf("unexpected IsLike result for %+v", release)
+	}
+}
@@ -10,13 +10,28 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
 	"gopkg.in/yaml.v3"
 
+	"github.com/onmetal/inventory/pkg/file"
 	"github.com/onmetal/inventory/pkg/host"
 	"github.com/onmetal/inventory/pkg/printer"
 	"github.com/onmetal/inventory/pkg/utils"
 )
 
+const (
+	CEtcOSReleasePath    = "/etc/os-release"
+	CUsrLibOSReleasePath = "/usr/lib/os-release"
+	CDebianVersionPath   = "/etc/debian_version"
+	CKernelReleasePath   = "/proc/sys/kernel/osrelease"
+	CKernelArchPath      = "/proc/sys/kernel/arch"
+
+	// CMaxSymlinkHops is a number of symlinks followed before giving up, the same as kernel does
+	CMaxSymlinkHops = 40
+
+	CDebianID = "debian"
+)
+
 type Distro struct {
 	BuildVersion  string
 	DebianVersion string
@@ -26,19 +41,34 @@ type Distro struct {
 	BuildDate     string
 	BuildNumber   uint32
 	BuildBy       string
+	Architecture  string
+	OSRelease     *OSRelease
 }
 
 type Svc struct {
 	printer           *printer.Svc
 	hostSvc           *host.Svc
+	basePath          string
 	switchVersionPath string
+	osReleasePaths    []string
+	debianVersionPath string
+	kernelReleasePath string
+	kernelArchPath    string
 }
 
 func NewSvc(printer *printer.Svc, hostSvc *host.Svc, basePath string) *Svc {
 	return &Svc{
 		printer:           printer,
 		hostSvc:           hostSvc,
+		basePath:          basePath,
 		switchVersionPath: path.Join(basePath, utils.CVersionFilePath),
+		osReleasePaths: []string{
+			path.Join(basePath, CEtcOSReleasePath),
+			path.Join(basePath, CUsrLibOSReleasePath),
+		},
+		debianVersionPath: path.Join(basePath, CDebianVersionPath),
+		kernelReleasePath: path.Join(basePath, CKernelReleasePath),
+		kernelArchPath:    path.Join(basePath, CKernelArchPath),
 	}
 }
 
@@ -47,10 +77,9 @@ func (s *Svc) GetData() (*Distro, error) {
 	rawInfo := make(map[string]interface{})
 	hostInfo, err := s.hostSvc.GetData()
 	if err != nil {
-		s.printer.VErr(errors.Wrap(err, "failed to collect host info"))
+		return nil, errors.Wrap(err, "failed to collect host info")
 	}
-	switch hostInfo.Type {
-	case utils.CSwitchType:
+	if hostInfo.IsSONiC() {
 		sonicInfo, err := os.ReadFile(s.switchVersionPath)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to read SONiC version file")
@@ -63,11 +92,118 @@ func (s *Svc) GetData() (*Distro, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to process SONiC version")
 		}
-		// todo: case utils.CMachineType:
+	} else {
+		if err := s.setMachineDistro(&distro); err != nil {
+			return nil, errors.Wrap(err, "failed to collect machine distro")
+		}
 	}
+
+	arch, err := s.getArchitecture()
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "failed to get architecture"))
+	}
+	distro.Architecture = arch
+
 	return &distro, nil
 }
 
+func (s *Svc) setMachineDistro(distro *Distro) error {
+	release, err := s.getOSRelease()
+	if err != nil {
+		return errors.Wrap(err, "failed to get os release")
+	}
+	distro.OSRelease = release
+
+	distro.BuildVersion = release.PrettyName
+	if distro.BuildVersion == "" {
+		distro.BuildVersion = strings.TrimSpace(release.ID + " " + release.VersionID)
+	}
+
+	if release.IsLike(CDebianID) {
+		debianVersion, err := file.ToString(s.debianVersionPath)
+		if err != nil {
+			s.printer.VErr(errors.Wrap(err, "failed to get debian version"))
+		}
+		distro.DebianVersion = debianVersion
+	}
+
+	kernelRelease, err := file.ToString(s.kernelReleasePath)
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "failed to get kernel release"))
+	}
+	distro.KernelVersion = kernelRelease
+
+	return nil
+}
+
+// getOSRelease reads the first available os-release file,
+// /etc/os-release takes precedence over /usr/lib/os-release
+func (s *Svc) getOSRelease() (*OSRelease, error) {
+	for _, releasePath := range s.osReleasePaths {
+		resolvedPath, err := resolveUnderRoot(s.basePath, releasePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve %s", releasePath)
+		}
+
+		data, err := os.ReadFile(resolvedPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", resolvedPath)
+		}
+		return parseOSRelease(data), nil
+	}
+
+	return nil, errors.Errorf("none of %s found", strings.Join(s.osReleasePaths, ", "))
+}
+
+// resolveUnderRoot follows symlink chain of the file, absolute targets are resolved
+// relative to the root, since /etc/os-release is usually a link to /usr/lib/os-release
+// that would point to the agent's own file when host filesystem is mounted elsewhere
+func resolveUnderRoot(root string, thePath string) (string, error) {
+	for i := 0; i < CMaxSymlinkHops; i++ {
+		info, err := os.Lstat(thePath)
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return thePath, nil
+		}
+
+		target, err := os.Readlink(thePath)
+		if err != nil {
+			return "", err
+		}
+		if path.IsAbs(target) {
+			thePath = path.Join(root, target)
+		} else {
+			thePath = path.Join(path.Dir(thePath), target)
+		}
+	}
+
+	return "", errors.Errorf("too many levels of symbolic links in %s", thePath)
+}
+
+// getArchitecture reads architecture from proc filesystem,
+// falling back to uname for kernels that do not expose it
+func (s *Svc) getArchitecture() (string, error) {
+	arch, err := file.ToString(s.kernelArchPath)
+	if err == nil {
+		return arch, nil
+	}
+
+	uname := unix.Utsname{}
+	if err := unix.Uname(&uname); err != nil {
+		return "", errors.Wrap(err, "failed to get uname")
+	}
+
+	return unix.ByteSliceToString(uname.Machine[:]), nil
+}
+
 func convertMapStruct(obj *Distro, m map[string]interface{}) error {
 	for k, v := range m {
 		m[strings.Replace(k, "_", "", 1)] = v
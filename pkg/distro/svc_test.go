@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package distro
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func TestGetOSReleaseAbsoluteSymlink(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(path.Join(root, "etc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(path.Join(root, "usr/lib"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(root, CUsrLibOSReleasePath), []byte("ID=flatcar\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// absolute target would point to the agent's own os-release if not resolved under the root
+	if err := os.Symlink(CUsrLibOSReleasePath, path.Join(root, CEtcOSReleasePath)); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewSvc(printer.NewSvc(false), nil, root)
+	release, err := svc.getOSRelease()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release.ID != "flatcar" {
+		t.Errorf("expected os-release of the root to be read, got id %s", release.ID)
+	}
+}
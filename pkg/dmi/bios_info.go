@@ -5,6 +5,18 @@ package dmi
 
 import "fmt"
 
+const (
+	CExtendedROMSize    = 0xFF
+	CUnsupportedRelease = 0xFF
+
+	// CBIOSCharacteristicsExtensionsOffset is an offset of extension bytes
+	// from the beginning of the structure including header
+	CBIOSCharacteristicsExtensionsOffset = 0x12
+	// Minimal structure lengths with two extension bytes defined since 2.4
+	CBIOSInformationLength24 = 0x18
+	CBIOSInformationLength31 = 0x1A
+)
+
 var CCharacteristics = []string{
 	"Reserved",
 	"Reserved",
@@ -67,7 +79,7 @@ var CCharacteristicsExtensions = [][]string{
 type BIOSInformationRefSpec20 struct {
 	Vendor                        byte   `struc:"byte"`
 	Version                       byte   `struc:"byte"`
-	StartingAddressSegment        uint16 `struc:"uint16"`
+	StartingAddressSegment        uint16 `struc:"uint16,little"`
 	ReleaseDate                   byte   `struc:"byte"`
 	ROMSize                       byte   `struc:"byte"`
 	Characteristics               uint64 `struc:"uint64,little"`
@@ -85,7 +97,7 @@ type BIOSInformationRefSpec24 struct {
 
 type BIOSInformationRefSpec31 struct {
 	BIOSInformationRefSpec24
-	ExtendedROMSize uint16 `struc:"uint16"`
+	ExtendedROMSize uint16 `struc:"uint16,little"`
 }
 
 type BIOSInformation struct {
@@ -102,13 +114,14 @@ type BIOSInformation struct {
 func BIOSInformationFromSpec20(ref *BIOSInformationRefSpec20, strings []string) *BIOSInformation {
 	info := &BIOSInformation{}
 
-	// Reducing all values by one since structure contains element number
-	// and we need an element index for the array
-	info.Vendor = strings[ref.Vendor-1]
-	info.Version = strings[ref.Version-1]
+	info.Vendor = emptyStringOrValue(ref.Vendor, strings)
+	info.Version = emptyStringOrValue(ref.Version, strings)
 	info.StartingAddressSegment = fmt.Sprintf("%x", ref.StartingAddressSegment)
-	info.ReleaseDate = strings[ref.ReleaseDate-1]
-	info.ROMSize = (uint64(ref.ROMSize) + 1) * 64 * 1024
+	info.ReleaseDate = emptyStringOrValue(ref.ReleaseDate, strings)
+	// FFh means that size is 16MB or greater and is set in extended ROM size
+	if ref.ROMSize != CExtendedROMSize {
+		info.ROMSize = (uint64(ref.ROMSize) + 1) * 64 * 1024
+	}
 	info.Characteristics = make([]string, 0)
 
 	for i, characteristic := range CCharacteristics {
@@ -120,7 +133,7 @@ func BIOSInformationFromSpec20(ref *BIOSInformationRefSpec20, strings []string)
 	}
 
 	for i, b := range ref.CharacteristicsExtensions {
-		if i > len(CCharacteristicsExtensions) {
+		if i >= len(CCharacteristicsExtensions) {
 			break
 		}
 		for j, characteristic := range CCharacteristicsExtensions[i] {
@@ -138,8 +151,13 @@ func BIOSInformationFromSpec20(ref *BIOSInformationRefSpec20, strings []string)
 func BIOSInformationFromSpec24(ref *BIOSInformationRefSpec24, strings []string) *BIOSInformation {
 	info := BIOSInformationFromSpec20(&ref.BIOSInformationRefSpec20, strings)
 
-	info.SystemRelease = fmt.Sprintf("%d.%d", ref.SystemMajorRelease, ref.SystemMinorRelease)
-	info.EmbeddedControllerFirmwareRelease = fmt.Sprintf("%d.%d", ref.EmbeddedControllerFirmwareMajorRelease, ref.EmbeddedControllerFirmwareMinorRelease)
+	// FFh means that release is not supported
+	if ref.SystemMajorRelease != CUnsupportedRelease {
+		info.SystemRelease = fmt.Sprintf("%d.%d", ref.SystemMajorRelease, ref.SystemMinorRelease)
+	}
+	if ref.EmbeddedControllerFirmwareMajorRelease != CUnsupportedRelease {
+		info.EmbeddedControllerFirmwareRelease = fmt.Sprintf("%d.%d", ref.EmbeddedControllerFirmwareMajorRelease, ref.EmbeddedControllerFirmwareMinorRelease)
+	}
 
 	return info
 }
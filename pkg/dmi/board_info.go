@@ -55,10 +55,10 @@ type BoardInformationRefSpec struct {
 	AssetTag                       byte     `struc:"byte"`
 	FeatureFlags                   byte     `struc:"byte"`
 	LocationInChassis              byte     `struc:"byte"`
-	ChassisHandle                  uint16   `struc:"uint16"`
+	ChassisHandle                  uint16   `struc:"uint16,little"`
 	Type                           byte     `struc:"byte"`
 	NumberOfContainedObjectHandles byte     `struc:"byte,sizeof=ContainedObjectHandles"`
-	ContainedObjectHandles         []uint16 `struc:"[]uint16"`
+	ContainedObjectHandles         []uint16 `struc:"[]uint16,little"`
 }
 
 type BoardInformation struct {
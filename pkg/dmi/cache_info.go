@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+type CacheLocation string
+
+const (
+	CInternalCacheLocation CacheLocation = "Internal"
+	CExternalCacheLocation CacheLocation = "External"
+	CReservedCacheLocation CacheLocation = "Reserved"
+	CUnknownCacheLocation  CacheLocation = "Unknown"
+)
+
+var CCacheLocations = []CacheLocation{
+	CInternalCacheLocation,
+	CExternalCacheLocation,
+	CReservedCacheLocation,
+	CUnknownCacheLocation,
+}
+
+type CacheOperationalMode string
+
+const (
+	CWriteThroughCacheOperationalMode CacheOperationalMode = "Write Through"
+	CWriteBackCacheOperationalMode    CacheOperationalMode = "Write Back"
+	CVariesCacheOperationalMode       CacheOperationalMode = "Varies With Memory Address"
+	CUnknownCacheOperationalMode      CacheOperationalMode = "Unknown"
+)
+
+var CCacheOperationalModes = []CacheOperationalMode{
+	CWriteThroughCacheOperationalMode,
+	CWriteBackCacheOperationalMode,
+	CVariesCacheOperationalMode,
+	CUnknownCacheOperationalMode,
+}
+
+type CacheType string
+
+const (
+	COtherCacheType       CacheType = "Other"
+	CUnknownCacheType     CacheType = "Unknown"
+	CInstructionCacheType CacheType = "Instruction"
+	CDataCacheType        CacheType = "Data"
+	CUnifiedCacheType     CacheType = "Unified"
+)
+
+var cacheTypes = map[byte]CacheType{
+	0x01: COtherCacheType,
+	0x02: CUnknownCacheType,
+	0x03: CInstructionCacheType,
+	0x04: CDataCacheType,
+	0x05: CUnifiedCacheType,
+}
+
+var cacheErrorCorrections = map[byte]MemoryErrorCorrection{
+	0x01: COtherMemoryErrorCorrection,
+	0x02: CUnknownMemoryErrorCorrection,
+	0x03: CNoneMemoryErrorCorrection,
+	0x04: CParityMemoryErrorCorrection,
+	0x05: CSingleBitECCMemoryErrorCorrection,
+	0x06: CMultiBitECCMemoryErrorCorrection,
+}
+
+var cacheAssociativities = map[byte]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Direct Mapped",
+	0x04: "2-way Set-associative",
+	0x05: "4-way Set-associative",
+	0x06: "Fully Associative",
+	0x07: "8-way Set-associative",
+	0x08: "16-way Set-associative",
+	0x09: "12-way Set-associative",
+	0x0A: "24-way Set-associative",
+	0x0B: "32-way Set-associative",
+	0x0C: "48-way Set-associative",
+	0x0D: "64-way Set-associative",
+	0x0E: "20-way Set-associative",
+}
+
+const (
+	CCacheLevelMask         = 0x0007
+	CCacheSocketedMask      = 0x0008
+	CCacheLocationShift     = 5
+	CCacheLocationMask      = 0x0003
+	CCacheEnabledMask       = 0x0080
+	CCacheModeShift         = 8
+	CCacheModeMask          = 0x0003
+	CCacheSizeGranularity   = 0x8000
+	CCacheSize2Granularity  = 0x80000000
+	CCacheSizeGranularity64 = 64
+)
+
+type CacheInformationRefSpec20 struct {
+	SocketDesignation  byte   `struc:"byte"`
+	CacheConfiguration uint16 `struc:"uint16,little"`
+	MaximumCacheSize   uint16 `struc:"uint16,little"`
+	InstalledSize      uint16 `struc:"uint16,little"`
+	SupportedSRAMType  uint16 `struc:"uint16,little"`
+	CurrentSRAMType    uint16 `struc:"uint16,little"`
+}
+
+type CacheInformationRefSpec21 struct {
+	CacheInformationRefSpec20
+	CacheSpeed          byte `struc:"byte"`
+	ErrorCorrectionType byte `struc:"byte"`
+	SystemCacheType     byte `struc:"byte"`
+	Associativity       byte `struc:"byte"`
+}
+
+type CacheInformationRefSpec31 struct {
+	CacheInformationRefSpec21
+	MaximumCacheSize2   uint32 `struc:"uint32,little"`
+	InstalledCacheSize2 uint32 `struc:"uint32,little"`
+}
+
+type CacheInformation struct {
+	Handle            uint16
+	SocketDesignation string
+	// Level starts from 1 for L1 cache
+	Level           byte
+	Socketed        bool
+	Location        CacheLocation
+	Enabled         bool
+	OperationalMode CacheOperationalMode
+	// MaximumSize and InstalledSize are specified in bytes
+	MaximumSize   uint64
+	InstalledSize uint64
+	// Speed is specified in nanoseconds
+	Speed           byte
+	ErrorCorrection MemoryErrorCorrection
+	Type            CacheType
+	Associativity   string
+}
+
+func CacheInformationFromSpec20(ref *CacheInformationRefSpec20, strings []string) *CacheInformation {
+	config := ref.CacheConfiguration
+
+	info := &CacheInformation{
+		SocketDesignation: emptyStringOrValue(ref.SocketDesignation, strings),
+		Level:             byte(config&CCacheLevelMask) + 1,
+		Socketed:          config&CCacheSocketedMask != 0,
+		Location:          CCacheLocations[(config>>CCacheLocationShift)&CCacheLocationMask],
+		Enabled:           config&CCacheEnabledMask != 0,
+		OperationalMode:   CCacheOperationalModes[(config>>CCacheModeShift)&CCacheModeMask],
+		MaximumSize:       cacheSize(uint32(ref.MaximumCacheSize), CCacheSizeGranularity),
+		InstalledSize:     cacheSize(uint32(ref.InstalledSize), CCacheSizeGranularity),
+	}
+
+	return info
+}
+
+func CacheInformationFromSpec21(ref *CacheInformationRefSpec21, strings []string) *CacheInformation {
+	info := CacheInformationFromSpec20(&ref.CacheInformationRefSpec20, strings)
+
+	info.Speed = ref.CacheSpeed
+	info.ErrorCorrection = cacheErrorCorrections[ref.ErrorCorrectionType]
+	info.Type = cacheTypes[ref.SystemCacheType]
+	info.Associativity = cacheAssociativities[ref.Associativity]
+
+	return info
+}
+
+func CacheInformationFromSpec31(ref *CacheInformationRefSpec31, strings []string) *CacheInformation {
+	info := CacheInformationFromSpec21(&ref.CacheInformationRefSpec21, strings)
+
+	// values of 2GB and more do not fit 16 bit fields,
+	// in such case legacy fields are set to all ones
+	if ref.MaximumCacheSize2 != 0 {
+		info.MaximumSize = cacheSize(ref.MaximumCacheSize2, CCacheSize2Granularity)
+	}
+	if ref.InstalledCacheSize2 != 0 {
+		info.InstalledSize = cacheSize(ref.InstalledCacheSize2, CCacheSize2Granularity)
+	}
+
+	return info
+}
+
+// cacheSize converts size field to bytes,
+// granularity bit tells whether size is specified in 1K or 64K blocks
+func cacheSize(val uint32, granularityMask uint32) uint64 {
+	size := uint64(val &^ granularityMask)
+	if val&granularityMask != 0 {
+		size *= CCacheSizeGranularity64
+	}
+	return size * 1024
+}
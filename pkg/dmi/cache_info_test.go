@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCacheInformation(t *testing.T) {
+	strings := []string{"L3 Cache"}
+	// level 3, internal, enabled, write back
+	config := uint16(0x0002 | 0x0080 | 0x0100)
+
+	tests := []struct {
+		name     string
+		version  *SMBIOSVersion
+		ref      interface{}
+		expected *CacheInformation
+	}{
+		{
+			name:    "2.1 sizes in 64K blocks",
+			version: NewSMBIOSVersion(2, 1, 0),
+			ref: &CacheInformationRefSpec21{
+				CacheInformationRefSpec20: CacheInformationRefSpec20{
+					SocketDesignation:  1,
+					CacheConfiguration: config,
+					MaximumCacheSize:   CCacheSizeGranularity | 768,
+					InstalledSize:      CCacheSizeGranularity | 768,
+				},
+				ErrorCorrectionType: 0x05,
+				SystemCacheType:     0x05,
+				Associativity:       0x0A,
+			},
+			expected: &CacheInformation{
+				Handle:            0x0702,
+				SocketDesignation: "L3 Cache",
+				Level:             3,
+				Location:          CInternalCacheLocation,
+				Enabled:           true,
+				OperationalMode:   CWriteBackCacheOperationalMode,
+				MaximumSize:       48 << 20,
+				InstalledSize:     48 << 20,
+				ErrorCorrection:   CSingleBitECCMemoryErrorCorrection,
+				Type:              CUnifiedCacheType,
+				Associativity:     "24-way Set-associative",
+			},
+		},
+		{
+			name:    "3.1 sizes above 2GB",
+			version: NewSMBIOSVersion(3, 1, 0),
+			ref: &CacheInformationRefSpec31{
+				CacheInformationRefSpec21: CacheInformationRefSpec21{
+					CacheInformationRefSpec20: CacheInformationRefSpec20{
+						SocketDesignation:  1,
+						CacheConfiguration: config,
+						MaximumCacheSize:   0xFFFF,
+						InstalledSize:      0xFFFF,
+					},
+				},
+				MaximumCacheSize2:   CCacheSize2Granularity | 65536,
+				InstalledCacheSize2: CCacheSize2Granularity | 32768,
+			},
+			expected: &CacheInformation{
+				Handle:            0x0702,
+				SocketDesignation: "L3 Cache",
+				Level:             3,
+				Location:          CInternalCacheLocation,
+				Enabled:           true,
+				OperationalMode:   CWriteBackCacheOperationalMode,
+				MaximumSize:       4 << 30,
+				InstalledSize:     2 << 30,
+			},
+		},
+	}
+
+	svc := newTestSvc()
+	for _, test := range tests {
+		structure := packStructure(t, CCacheInformationHeaderType, 0x0702, test.ref, strings)
+		info, err := svc.parseCacheInformation(structure, test.version)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(info, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, info)
+		}
+	}
+
+	dmi := &DMI{Caches: []CacheInformation{{Handle: 0x0702}}}
+	if dmi.GetCache(0x0702) == nil || dmi.GetCache(CNoCacheHandle) != nil || dmi.GetCache(0x0703) != nil {
+		t.Error("unexpected cache lookup result")
+	}
+}
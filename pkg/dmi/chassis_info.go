@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+type ChassisType string
+
+const (
+	COtherChassisType             ChassisType = "Other"
+	CUnknownChassisType           ChassisType = "Unknown"
+	CDesktopChassisType           ChassisType = "Desktop"
+	CLowProfileDesktopChassisType ChassisType = "Low Profile Desktop"
+	CPizzaBoxChassisType          ChassisType = "Pizza Box"
+	CMiniTowerChassisType         ChassisType = "Mini Tower"
+	CTowerChassisType             ChassisType = "Tower"
+	CPortableChassisType          ChassisType = "Portable"
+	CLaptopChassisType            ChassisType = "Laptop"
+	CNotebookChassisType          ChassisType = "Notebook"
+	CHandHeldChassisType          ChassisType = "Hand Held"
+	CDockingStationChassisType    ChassisType = "Docking Station"
+	CAllInOneChassisType          ChassisType = "All in One"
+	CSubNotebookChassisType       ChassisType = "Sub Notebook"
+	CSpaceSavingChassisType       ChassisType = "Space-saving"
+	CLunchBoxChassisType          ChassisType = "Lunch Box"
+	CMainServerChassisType        ChassisType = "Main Server Chassis"
+	CExpansionChassisType         ChassisType = "Expansion Chassis"
+	CSubChassisType               ChassisType = "SubChassis"
+	CBusExpansionChassisType      ChassisType = "Bus Expansion Chassis"
+	CPeripheralChassisType        ChassisType = "Peripheral Chassis"
+	CRAIDChassisType              ChassisType = "RAID Chassis"
+	CRackMountChassisType         ChassisType = "Rack Mount Chassis"
+	CSealedCasePCChassisType      ChassisType = "Sealed-case PC"
+	CMultiSystemChassisType       ChassisType = "Multi-system chassis"
+	CCompactPCIChassisType        ChassisType = "Compact PCI"
+	CAdvancedTCAChassisType       ChassisType = "Advanced TCA"
+	CBladeChassisType             ChassisType = "Blade"
+	CBladeEnclosureChassisType    ChassisType = "Blade Enclosure"
+	CTabletChassisType            ChassisType = "Tablet"
+	CConvertibleChassisType       ChassisType = "Convertible"
+	CDetachableChassisType        ChassisType = "Detachable"
+	CIoTGatewayChassisType        ChassisType = "IoT Gateway"
+	CEmbeddedPCChassisType        ChassisType = "Embedded PC"
+	CMiniPCChassisType            ChassisType = "Mini PC"
+	CStickPCChassisType           ChassisType = "Stick PC"
+)
+
+var chassisTypes = map[byte]ChassisType{
+	0x01: COtherChassisType,
+	0x02: CUnknownChassisType,
+	0x03: CDesktopChassisType,
+	0x04: CLowProfileDesktopChassisType,
+	0x05: CPizzaBoxChassisType,
+	0x06: CMiniTowerChassisType,
+	0x07: CTowerChassisType,
+	0x08: CPortableChassisType,
+	0x09: CLaptopChassisType,
+	0x0A: CNotebookChassisType,
+	0x0B: CHandHeldChassisType,
+	0x0C: CDockingStationChassisType,
+	0x0D: CAllInOneChassisType,
+	0x0E: CSubNotebookChassisType,
+	0x0F: CSpaceSavingChassisType,
+	0x10: CLunchBoxChassisType,
+	0x11: CMainServerChassisType,
+	0x12: CExpansionChassisType,
+	0x13: CSubChassisType,
+	0x14: CBusExpansionChassisType,
+	0x15: CPeripheralChassisType,
+	0x16: CRAIDChassisType,
+	0x17: CRackMountChassisType,
+	0x18: CSealedCasePCChassisType,
+	0x19: CMultiSystemChassisType,
+	0x1A: CCompactPCIChassisType,
+	0x1B: CAdvancedTCAChassisType,
+	0x1C: CBladeChassisType,
+	0x1D: CBladeEnclosureChassisType,
+	0x1E: CTabletChassisType,
+	0x1F: CConvertibleChassisType,
+	0x20: CDetachableChassisType,
+	0x21: CIoTGatewayChassisType,
+	0x22: CEmbeddedPCChassisType,
+	0x23: CMiniPCChassisType,
+	0x24: CStickPCChassisType,
+}
+
+type ChassisState string
+
+const (
+	COtherChassisState          ChassisState = "Other"
+	CUnknownChassisState        ChassisState = "Unknown"
+	CSafeChassisState           ChassisState = "Safe"
+	CWarningChassisState        ChassisState = "Warning"
+	CCriticalChassisState       ChassisState = "Critical"
+	CNonRecoverableChassisState ChassisState = "Non-recoverable"
+)
+
+var chassisStates = map[byte]ChassisState{
+	0x01: COtherChassisState,
+	0x02: CUnknownChassisState,
+	0x03: CSafeChassisState,
+	0x04: CWarningChassisState,
+	0x05: CCriticalChassisState,
+	0x06: CNonRecoverableChassisState,
+}
+
+var chassisSecurityStatuses = map[byte]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "None",
+	0x04: "External interface locked out",
+	0x05: "External interface enabled",
+}
+
+const (
+	CChassisLockMask = 0x80
+	CChassisTypeMask = 0x7F
+)
+
+type ChassisInformationRefSpec20 struct {
+	Manufacturer byte `struc:"byte"`
+	Type         byte `struc:"byte"`
+	Version      byte `struc:"byte"`
+	SerialNumber byte `struc:"byte"`
+	AssetTag     byte `struc:"byte"`
+}
+
+type ChassisInformationRefSpec21 struct {
+	ChassisInformationRefSpec20
+	BootUpState      byte `struc:"byte"`
+	PowerSupplyState byte `struc:"byte"`
+	ThermalState     byte `struc:"byte"`
+	SecurityStatus   byte `struc:"byte"`
+}
+
+// ChassisInformationRefSpec23 is followed by the variable length list
+// of contained elements and SKU number string reference (2.7+),
+// so they are handled separately
+type ChassisInformationRefSpec23 struct {
+	ChassisInformationRefSpec21
+	OEMDefined                   uint32 `struc:"uint32,little"`
+	Height                       byte   `struc:"byte"`
+	NumberOfPowerCords           byte   `struc:"byte"`
+	ContainedElementCount        byte   `struc:"byte"`
+	ContainedElementRecordLength byte   `struc:"byte"`
+}
+
+type ChassisInformation struct {
+	Handle           uint16
+	Manufacturer     string
+	Type             ChassisType
+	Lock             bool
+	Version          string
+	SerialNumber     string
+	AssetTag         string
+	BootUpState      ChassisState
+	PowerSupplyState ChassisState
+	ThermalState     ChassisState
+	SecurityStatus   string
+	// Height is specified in rack units, 0 if unknown
+	Height                byte
+	NumberOfPowerCords    byte
+	ContainedElementCount byte
+	SKUNumber             string
+}
+
+func ChassisInformationFromSpec20(ref *ChassisInformationRefSpec20, strings []string) *ChassisInformation {
+	info := &ChassisInformation{
+		Manufacturer: emptyStringOrValue(ref.Manufacturer, strings),
+		Type:         chassisTypes[ref.Type&CChassisTypeMask],
+		Lock:         ref.Type&CChassisLockMask != 0,
+		Version:      emptyStringOrValue(ref.Version, strings),
+		SerialNumber: emptyStringOrValue(ref.SerialNumber, strings),
+		AssetTag:     emptyStringOrValue(ref.AssetTag, strings),
+	}
+
+	return info
+}
+
+func ChassisInformationFromSpec21(ref *ChassisInformationRefSpec21, strings []string) *ChassisInformation {
+	info := ChassisInformationFromSpec20(&ref.ChassisInformationRefSpec20, strings)
+
+	info.BootUpState = chassisStates[ref.BootUpState]
+	info.PowerSupplyState = chassisStates[ref.PowerSupplyState]
+	info.ThermalState = chassisStates[ref.ThermalState]
+	info.SecurityStatus = chassisSecurityStatuses[ref.SecurityStatus]
+
+	return info
+}
+
+func ChassisInformationFromSpec23(ref *ChassisInformationRefSpec23, strings []string) *ChassisInformation {
+	info := ChassisInformationFromSpec21(&ref.ChassisInformationRefSpec21, strings)
+
+	info.Height = ref.Height
+	info.NumberOfPowerCords = ref.NumberOfPowerCords
+	info.ContainedElementCount = ref.ContainedElementCount
+
+	return info
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChassisInformation(t *testing.T) {
+	strings := []string{"Lenovo", "None", "J30A1234", "No Asset Tag", "7X06CTO1WW"}
+	ref := &ChassisInformationRefSpec23{
+		ChassisInformationRefSpec21: ChassisInformationRefSpec21{
+			ChassisInformationRefSpec20: ChassisInformationRefSpec20{
+				Manufacturer: 1,
+				// locked rack mount chassis
+				Type:         CChassisLockMask | 0x17,
+				Version:      2,
+				SerialNumber: 3,
+				AssetTag:     4,
+			},
+			BootUpState:      0x03,
+			PowerSupplyState: 0x03,
+			ThermalState:     0x04,
+			SecurityStatus:   0x03,
+		},
+		Height:                       1,
+		NumberOfPowerCords:           2,
+		ContainedElementCount:        2,
+		ContainedElementRecordLength: 3,
+	}
+
+	expected := &ChassisInformation{
+		Handle:                0x0300,
+		Manufacturer:          "Lenovo",
+		Type:                  CRackMountChassisType,
+		Lock:                  true,
+		Version:               "None",
+		SerialNumber:          "J30A1234",
+		AssetTag:              "No Asset Tag",
+		BootUpState:           CSafeChassisState,
+		PowerSupplyState:      CSafeChassisState,
+		ThermalState:          CWarningChassisState,
+		SecurityStatus:        "None",
+		Height:                1,
+		NumberOfPowerCords:    2,
+		ContainedElementCount: 2,
+		SKUNumber:             "7X06CTO1WW",
+	}
+
+	structure := packStructure(t, CChassisInformationHeaderType, 0x0300, ref, strings)
+	// contained elements are followed by SKU number string reference
+	structure.Formatted = append(structure.Formatted, 0x91, 0x01, 0x02, 0x03, 0x01, 0x01, 5)
+
+	svc := newTestSvc()
+	info, err := svc.parseChassisInformation(structure, NewSMBIOSVersion(2, 7, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(info, expected) {
+		t.Errorf("expected %+v, got %+v", expected, info)
+	}
+
+	// SKU number is not a part of the structure before 2.7
+	info, err = svc.parseChassisInformation(structure, NewSMBIOSVersion(2, 6, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.SKUNumber != "" {
+		t.Errorf("expected no SKU number for 2.6, got %s", info.SKUNumber)
+	}
+}
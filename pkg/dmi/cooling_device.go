@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+var coolingDeviceTypes = map[byte]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Fan",
+	0x04: "Centrifugal Blower",
+	0x05: "Chip Fan",
+	0x06: "Cabinet Fan",
+	0x07: "Power Supply Fan",
+	0x08: "Heat Pipe",
+	0x09: "Integrated Refrigeration",
+	0x10: "Active Cooling",
+	0x11: "Passive Cooling",
+}
+
+const (
+	// Cooling device type and temperature probe location fields
+	// share the byte with the device status
+	CDeviceStatusShift = 5
+	CDeviceStatusMask  = 0x07
+	CDeviceKindMask    = 0x1F
+)
+
+type CoolingDeviceRefSpec22 struct {
+	TemperatureProbeHandle uint16 `struc:"uint16,little"`
+	DeviceTypeAndStatus    byte   `struc:"byte"`
+	CoolingUnitGroup       byte   `struc:"byte"`
+	OEMDefined             uint32 `struc:"uint32,little"`
+	NominalSpeed           uint16 `struc:"uint16,little"`
+}
+
+type CoolingDeviceRefSpec27 struct {
+	CoolingDeviceRefSpec22
+	Description byte `struc:"byte"`
+}
+
+type CoolingDevice struct {
+	Handle                 uint16
+	TemperatureProbeHandle uint16
+	Type                   string
+	Status                 DeviceStatus
+	CoolingUnitGroup       byte
+	// NominalSpeed is specified in RPM, 0 if unknown or device is not rotating
+	NominalSpeed uint16
+	Description  string
+}
+
+func CoolingDeviceFromSpec22(ref *CoolingDeviceRefSpec22) *CoolingDevice {
+	info := &CoolingDevice{
+		TemperatureProbeHandle: ref.TemperatureProbeHandle,
+		Type:                   coolingDeviceTypes[ref.DeviceTypeAndStatus&CDeviceKindMask],
+		Status:                 deviceStatuses[(ref.DeviceTypeAndStatus>>CDeviceStatusShift)&CDeviceStatusMask],
+		CoolingUnitGroup:       ref.CoolingUnitGroup,
+	}
+
+	if ref.NominalSpeed != CUnknownValue {
+		info.NominalSpeed = ref.NominalSpeed
+	}
+
+	return info
+}
+
+func CoolingDeviceFromSpec27(ref *CoolingDeviceRefSpec27, strings []string) *CoolingDevice {
+	info := CoolingDeviceFromSpec22(&ref.CoolingDeviceRefSpec22)
+
+	info.Description = emptyStringOrValue(ref.Description, strings)
+
+	return info
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCoolingDevice(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  *SMBIOSVersion
+		ref      interface{}
+		expected *CoolingDevice
+	}{
+		{
+			name:    "2.7 fan with description",
+			version: NewSMBIOSVersion(2, 7, 0),
+			ref: &CoolingDeviceRefSpec27{
+				CoolingDeviceRefSpec22: CoolingDeviceRefSpec22{
+					TemperatureProbeHandle: 0x1c00,
+					// OK fan
+					DeviceTypeAndStatus: 0x03<<CDeviceStatusShift | 0x03,
+					CoolingUnitGroup:    1,
+					NominalSpeed:        9000,
+				},
+				Description: 1,
+			},
+			expected: &CoolingDevice{
+				Handle:                 0x1b00,
+				TemperatureProbeHandle: 0x1c00,
+				Type:                   "Fan",
+				Status:                 COKDeviceStatus,
+				CoolingUnitGroup:       1,
+				NominalSpeed:           9000,
+				Description:            "Fan 1",
+			},
+		},
+		{
+			name:    "2.2 unknown speed",
+			version: NewSMBIOSVersion(2, 2, 0),
+			ref: &CoolingDeviceRefSpec22{
+				TemperatureProbeHandle: 0xFFFF,
+				// critical heat pipe
+				DeviceTypeAndStatus: 0x05<<CDeviceStatusShift | 0x08,
+				NominalSpeed:        CUnknownValue,
+			},
+			expected: &CoolingDevice{
+				Handle:                 0x1b00,
+				TemperatureProbeHandle: 0xFFFF,
+				Type:                   "Heat Pipe",
+				Status:                 CCriticalDeviceStatus,
+			},
+		},
+	}
+
+	svc := newTestSvc()
+	for _, test := range tests {
+		structure := packStructure(t, CCoolingDeviceHeaderType, 0x1b00, test.ref, []string{"Fan 1"})
+		info, err := svc.parseCoolingDevice(structure, test.version)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(info, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, info)
+		}
+	}
+}
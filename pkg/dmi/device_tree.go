@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"os"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CDeviceTreePath = "/proc/device-tree"
+
+	CDeviceTreeModelPath        = "/model"
+	CDeviceTreeCompatiblePath   = "/compatible"
+	CDeviceTreeSerialNumberPath = "/serial-number"
+	// POWER machines keep serial number in system-id, e.g. IBM,02781234X
+	CDeviceTreeSystemIDPath = "/system-id"
+	// PowerVM and QEMU pseries machines provide partition UUID
+	CDeviceTreeVMUUIDPath        = "/vm,uuid"
+	CDeviceTreePartitionUUIDPath = "/ibm,partition-uuid"
+)
+
+// DeviceTreeSvc provides system identity on machines without SMBIOS,
+// e.g. arm64 boards, DPUs and POWER machines
+type DeviceTreeSvc struct {
+	printer        *printer.Svc
+	deviceTreePath string
+}
+
+func NewDeviceTreeSvc(printer *printer.Svc, basePath string) *DeviceTreeSvc {
+	return &DeviceTreeSvc{
+		printer:        printer,
+		deviceTreePath: path.Join(basePath, CDeviceTreePath),
+	}
+}
+
+// GetData returns DMI data with system and board information filled from device tree
+func (s *DeviceTreeSvc) GetData() (*DMI, error) {
+	if _, err := os.Stat(s.deviceTreePath); err != nil {
+		return nil, errors.Wrapf(err, "unable to access device tree %s", s.deviceTreePath)
+	}
+
+	model, err := s.getString(CDeviceTreeModelPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get model")
+	}
+	compatible, err := s.getStringList(CDeviceTreeCompatiblePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get compatible")
+	}
+	serialNumber, err := s.getString(CDeviceTreeSerialNumberPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get serial number")
+	}
+	systemID, err := s.getString(CDeviceTreeSystemIDPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get system id")
+	}
+	systemUUID := s.getUUID()
+
+	info := &SystemInformation{
+		ProductName:  model,
+		SerialNumber: serialNumber,
+		UUID:         systemUUID,
+	}
+
+	// POWER models and system IDs are prefixed with vendor, e.g. IBM,9009-42A
+	if vendor, product, ok := strings.Cut(model, ","); ok {
+		info.Manufacturer = vendor
+		info.ProductName = product
+	}
+	if info.SerialNumber == "" && systemID != "" {
+		_, serial, ok := strings.Cut(systemID, ",")
+		if !ok {
+			serial = systemID
+		}
+		info.SerialNumber = serial
+	}
+
+	// Compatible strings go from the most specific board to the SoC,
+	// e.g. raspberrypi,4-model-b brcm,bcm2711
+	if len(compatible) > 0 {
+		if vendor, _, ok := strings.Cut(compatible[0], ","); ok && info.Manufacturer == "" {
+			info.Manufacturer = vendor
+		}
+		info.Family = compatible[len(compatible)-1]
+	}
+
+	if info.ProductName == "" && info.SerialNumber == "" && info.UUID == "" {
+		return nil, errors.New("device tree has no system identity")
+	}
+
+	data := newDMI(nil)
+	data.SystemInformation = info
+	data.BoardInformation = []BoardInformation{
+		{
+			Manufacturer: info.Manufacturer,
+			Product:      info.ProductName,
+			SerialNumber: info.SerialNumber,
+			FeatureFlags: []string{},
+			Type:         CMotherboardBoardType,
+		},
+	}
+
+	return data, nil
+}
+
+// getUUID returns the first valid UUID property,
+// UUID is left empty rather than dropping model and serial number with it
+func (s *DeviceTreeSvc) getUUID() string {
+	for _, uuidPath := range []string{CDeviceTreeVMUUIDPath, CDeviceTreePartitionUUIDPath} {
+		str, err := s.getString(uuidPath)
+		if err != nil {
+			s.printer.VErr(errors.Wrap(err, "unable to get system UUID"))
+			continue
+		}
+		if str == "" {
+			continue
+		}
+
+		id, err := uuid.Parse(str)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to parse UUID %s from %s", str, uuidPath))
+			continue
+		}
+		return id.String()
+	}
+
+	return ""
+}
+
+// getString returns null terminated property value,
+// missing property is treated as empty one
+func (s *DeviceTreeSvc) getString(property string) (string, error) {
+	data, err := os.ReadFile(path.Join(s.deviceTreePath, property))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read property %s", property)
+	}
+
+	return strings.TrimSpace(strings.TrimRight(string(data), "\x00")), nil
+}
+
+// getStringList returns values of null separated string list property
+func (s *DeviceTreeSvc) getStringList(property string) ([]string, error) {
+	str, err := s.getString(property)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]string, 0)
+	for _, val := range strings.Split(str, "\x00") {
+		if val != "" {
+			list = append(list, val)
+		}
+	}
+
+	return list, nil
+}
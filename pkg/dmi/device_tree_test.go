@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func TestDeviceTreeGetData(t *testing.T) {
+	tests := []struct {
+		name       string
+		properties map[string]string
+		expected   *SystemInformation
+	}{
+		{
+			name: "arm64 board",
+			properties: map[string]string{
+				CDeviceTreeModelPath:        "Raspberry Pi 4 Model B Rev 1.4\x00",
+				CDeviceTreeCompatiblePath:   "raspberrypi,4-model-b\x00brcm,bcm2711\x00",
+				CDeviceTreeSerialNumberPath: "10000000a1b2c3d4\x00",
+				CDeviceTreeVMUUIDPath:       "4C4C4544-0032-5910-8043-B4C04F4B3732\x00",
+			},
+			expected: &SystemInformation{
+				Manufacturer: "raspberrypi",
+				ProductName:  "Raspberry Pi 4 Model B Rev 1.4",
+				SerialNumber: "10000000a1b2c3d4",
+				UUID:         "4c4c4544-0032-5910-8043-b4c04f4b3732",
+				Family:       "brcm,bcm2711",
+			},
+		},
+		{
+			name: "POWER machine",
+			properties: map[string]string{
+				CDeviceTreeModelPath:         "IBM,9009-42A\x00",
+				CDeviceTreeCompatiblePath:    "IBM,9009\x00",
+				CDeviceTreeSystemIDPath:      "IBM,02781234X\x00",
+				CDeviceTreePartitionUUIDPath: "0f2a6a2e-8e42-4bf1-9c3c-5d0e7a6b1c22\x00",
+			},
+			expected: &SystemInformation{
+				Manufacturer: "IBM",
+				ProductName:  "9009-42A",
+				SerialNumber: "02781234X",
+				UUID:         "0f2a6a2e-8e42-4bf1-9c3c-5d0e7a6b1c22",
+				Family:       "IBM,9009",
+			},
+		},
+		{
+			name: "malformed UUID",
+			properties: map[string]string{
+				CDeviceTreeModelPath:        "NVIDIA BlueField-2 DPU\x00",
+				CDeviceTreeSerialNumberPath: "MT2142X12345\x00",
+				CDeviceTreeVMUUIDPath:       "not-a-uuid\x00",
+			},
+			expected: &SystemInformation{
+				ProductName:  "NVIDIA BlueField-2 DPU",
+				SerialNumber: "MT2142X12345",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		root := t.TempDir()
+		for property, val := range test.properties {
+			thePath := path.Join(root, CDeviceTreePath, property)
+			if err := os.MkdirAll(path.Dir(thePath), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(thePath, []byte(val), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		data, err := NewDeviceTreeSvc(printer.NewSvc(false), root).GetData()
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(data.SystemInformation, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, data.SystemInformation)
+		}
+
+		expectedBoard := BoardInformation{
+			Manufacturer: test.expected.Manufacturer,
+			Product:      test.expected.ProductName,
+			SerialNumber: test.expected.SerialNumber,
+			FeatureFlags: []string{},
+			Type:         CMotherboardBoardType,
+		}
+		if len(data.BoardInformation) != 1 || !reflect.DeepEqual(data.BoardInformation[0], expectedBoard) {
+			t.Errorf("%s: expected board %+v, got %+v", test.name, expectedBoard, data.BoardInformation)
+		}
+	}
+}
+
+func TestDeviceTreeGetDataWithoutIdentity(t *testing.T) {
+	svc := NewDeviceTreeSvc(printer.NewSvc(false), t.TempDir())
+	if data, err := svc.GetData(); err == nil {
+		t.Errorf("missing tree: expected error, got %+v", data)
+	}
+
+	root := t.TempDir()
+	if err := os.MkdirAll(path.Join(root, CDeviceTreePath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	svc = NewDeviceTreeSvc(printer.NewSvc(false), root)
+	if data, err := svc.GetData(); err == nil {
+		t.Errorf("empty tree: expected error, got %+v", data)
+	}
+}
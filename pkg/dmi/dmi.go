@@ -4,8 +4,96 @@
 package dmi
 
 type DMI struct {
-	Version           *SMBIOSVersion
-	BIOSInformation   *BIOSInformation
-	SystemInformation *SystemInformation
-	BoardInformation  []BoardInformation
+	Version            *SMBIOSVersion
+	BIOSInformation    *BIOSInformation
+	SystemInformation  *SystemInformation
+	BoardInformation   []BoardInformation
+	ChassisInformation []ChassisInformation
+	Processors         []ProcessorInformation
+	Caches             []CacheInformation
+	SystemSlots        []SystemSlot
+	OnboardDevices     []OnboardDevice
+	OEMStrings         []string
+	// SystemConfigurationOptions are free form strings, e.g. jumper settings
+	SystemConfigurationOptions []string
+	MemoryArrays               []PhysicalMemoryArray
+	MemoryDevices              []MemoryDevice
+	CoolingDevices             []CoolingDevice
+	TemperatureProbes          []TemperatureProbe
+	PowerSupplies              []SystemPowerSupply
+	HostInterfaces             []HostInterface
+}
+
+// newDMI creates DMI data with empty structure lists,
+// version is nil if data does not come from SMBIOS
+func newDMI(version *SMBIOSVersion) *DMI {
+	return &DMI{
+		Version:                    version,
+		ChassisInformation:         []ChassisInformation{},
+		Processors:                 []ProcessorInformation{},
+		Caches:                     []CacheInformation{},
+		SystemSlots:                []SystemSlot{},
+		OnboardDevices:             []OnboardDevice{},
+		OEMStrings:                 []string{},
+		SystemConfigurationOptions: []string{},
+		MemoryArrays:               []PhysicalMemoryArray{},
+		MemoryDevices:              []MemoryDevice{},
+		CoolingDevices:             []CoolingDevice{},
+		TemperatureProbes:          []TemperatureProbe{},
+		PowerSupplies:              []SystemPowerSupply{},
+		HostInterfaces:             []HostInterface{},
+	}
+}
+
+// GetCache returns cache structure referred by processor
+// or nil if there is no such cache
+func (d *DMI) GetCache(handle uint16) *CacheInformation {
+	if handle == CNoCacheHandle {
+		return nil
+	}
+	for i := range d.Caches {
+		if d.Caches[i].Handle == handle {
+			return &d.Caches[i]
+		}
+	}
+	return nil
+}
+
+// GetPopulatedSockets returns central processors installed into sockets
+// in the order they are listed in SMBIOS, which matches physical IDs order
+func (d *DMI) GetPopulatedSockets() []ProcessorInformation {
+	sockets := make([]ProcessorInformation, 0)
+	for _, processor := range d.Processors {
+		if processor.Type != CCentralProcessorType || !processor.SocketPopulated {
+			continue
+		}
+		sockets = append(sockets, processor)
+	}
+	return sockets
+}
+
+// GetPCIDesignation returns onboard device designation or slot name
+// of the device with provided PCI address or empty string if it is unknown.
+// Slots are matched by device number only, since cards in them could expose
+// several functions, while onboard devices are matched exactly.
+func (d *DMI) GetPCIDesignation(address string) string {
+	for _, device := range d.OnboardDevices {
+		if device.PCIAddress != "" && device.PCIAddress == address {
+			return device.ReferenceDesignation
+		}
+	}
+
+	if len(address) < CPCIDeviceAddressLen {
+		return ""
+	}
+	for _, slot := range d.SystemSlots {
+		if len(slot.PCIAddress) < CPCIDeviceAddressLen {
+			continue
+		}
+		if slot.PCIAddress[:CPCIDeviceAddressLen] == address[:CPCIDeviceAddressLen] {
+			return slot.Designation
+		}
+	}
+
+	return ""
 }
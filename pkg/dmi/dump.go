@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/digitalocean/go-smbios/smbios"
+	"github.com/pkg/errors"
+)
+
+// Dump layout follows `dmidecode --dump-bin`: entry point is placed at the beginning
+// of the file with table address pointing right after it, table follows at 0x20
+const (
+	CDumpTableAddress = 0x20
+
+	CEntryPoint32Anchor = "_SM_"
+	CEntryPoint64Anchor = "_SM3_"
+	CIntermediateAnchor = "_DMI_"
+
+	CEntryPoint32LengthOffset = 0x05
+	CEntryPoint64LengthOffset = 0x06
+)
+
+// ReadDump reads entry point and structure table from the dump
+func ReadDump(r io.ReaderAt) (io.ReadCloser, smbios.EntryPoint, error) {
+	header := make([]byte, CDumpTableAddress)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, nil, errors.Wrap(err, "unable to read entry point")
+	}
+
+	var length int
+	switch {
+	case bytes.HasPrefix(header, []byte(CEntryPoint32Anchor)):
+		length = int(header[CEntryPoint32LengthOffset])
+	case bytes.HasPrefix(header, []byte(CEntryPoint64Anchor)):
+		length = int(header[CEntryPoint64LengthOffset])
+	default:
+		return nil, nil, errors.Errorf("unrecognized entry point anchor %q", header[0:4])
+	}
+	if length > CDumpTableAddress {
+		return nil, nil, errors.Errorf("entry point length %d exceeds table address", length)
+	}
+
+	// Entry point parser verifies checksum over all given bytes,
+	// so only entry point itself should be passed
+	ep, err := smbios.ParseEntryPoint(bytes.NewReader(header[:length]))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to parse entry point")
+	}
+
+	addr, size := ep.Table()
+	// Table size in 64-bit entry point is a maximum size,
+	// so dump may end before it
+	table, err := io.ReadAll(io.NewSectionReader(r, int64(addr), int64(size)))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to read structure table")
+	}
+
+	return io.NopCloser(bytes.NewReader(table)), ep, nil
+}
+
+// WriteDump writes entry point and structure table from the raw stream
+// in the format that can be read back by ReadDump and `dmidecode --from-dump`
+func WriteDump(w io.Writer, raw *Raw) error {
+	table, err := io.ReadAll(raw.Stream)
+	if err != nil {
+		return errors.Wrap(err, "unable to read structure table")
+	}
+
+	header, err := dumpEntryPoint(raw.EntryPoint, len(table))
+	if err != nil {
+		return errors.Wrap(err, "unable to build entry point")
+	}
+
+	if _, err := w.Write(header); err != nil {
+		return errors.Wrap(err, "unable to write entry point")
+	}
+	if _, err := w.Write(table); err != nil {
+		return errors.Wrap(err, "unable to write structure table")
+	}
+
+	return nil
+}
+
+// dumpEntryPoint serializes entry point with table address pointing
+// to the dump table offset and recomputes checksums
+func dumpEntryPoint(ep smbios.EntryPoint, tableSize int) ([]byte, error) {
+	header := make([]byte, CDumpTableAddress)
+
+	switch ep := ep.(type) {
+	case *smbios.EntryPoint32Bit:
+		length := int(ep.Length)
+		if length < 0x1F || length > CDumpTableAddress {
+			return nil, errors.Errorf("unexpected 32-bit entry point length %d", length)
+		}
+		copy(header[0x00:0x04], CEntryPoint32Anchor)
+		header[0x05] = ep.Length
+		header[0x06] = ep.Major
+		header[0x07] = ep.Minor
+		binary.LittleEndian.PutUint16(header[0x08:0x0A], ep.MaxStructureSize)
+		header[0x0A] = ep.EntryPointRevision
+		// go-smbios keeps formatted area shifted by one byte, starting from revision
+		copy(header[0x0B:0x10], ep.FormattedArea[1:])
+		copy(header[0x10:0x15], CIntermediateAnchor)
+		binary.LittleEndian.PutUint16(header[0x16:0x18], uint16(tableSize))
+		binary.LittleEndian.PutUint32(header[0x18:0x1C], CDumpTableAddress)
+		binary.LittleEndian.PutUint16(header[0x1C:0x1E], ep.NumberStructures)
+		header[0x1E] = ep.BCDRevision
+		header[0x15] = entryPointChecksum(header[0x10:0x1F])
+		header[0x04] = entryPointChecksum(header[0x00:length])
+	case *smbios.EntryPoint64Bit:
+		length := int(ep.Length)
+		if length < 0x18 || length > CDumpTableAddress {
+			return nil, errors.Errorf("unexpected 64-bit entry point length %d", length)
+		}
+		copy(header[0x00:0x05], CEntryPoint64Anchor)
+		header[0x06] = ep.Length
+		header[0x07] = ep.Major
+		header[0x08] = ep.Minor
+		header[0x09] = ep.Revision
+		header[0x0A] = ep.EntryPointRevision
+		binary.LittleEndian.PutUint32(header[0x0C:0x10], uint32(tableSize))
+		binary.LittleEndian.PutUint64(header[0x10:0x18], CDumpTableAddress)
+		header[0x05] = entryPointChecksum(header[0x00:length])
+	default:
+		return nil, errors.Errorf("unsupported entry point type %T", ep)
+	}
+
+	return header, nil
+}
+
+// entryPointChecksum returns value making sum of all bytes zero,
+// checksum byte itself is expected to be zero
+func entryPointChecksum(b []byte) byte {
+	var sum byte
+	for _, v := range b {
+		sum += v
+	}
+	return -sum
+}
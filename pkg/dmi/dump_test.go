@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/digitalocean/go-smbios/smbios"
+)
+
+func TestDumpRoundTrip(t *testing.T) {
+	table := []byte{
+		// OEM strings with one string
+		COEMStringsHeaderType, 0x05, 0x00, 0x0b, 0x01,
+		'r', 'a', 'c', 'k', '=', 'R', '1', '2', 0x00, 0x00,
+		// end of table
+		0x7F, 0x04, 0x01, 0x0b, 0x00, 0x00,
+	}
+
+	tests := []struct {
+		name       string
+		entryPoint smbios.EntryPoint
+		expected   [3]int
+	}{
+		{
+			name: "32-bit",
+			entryPoint: &smbios.EntryPoint32Bit{
+				Length:           0x1F,
+				Major:            2,
+				Minor:            8,
+				MaxStructureSize: 0x40,
+				NumberStructures: 2,
+				BCDRevision:      0x28,
+			},
+			expected: [3]int{2, 8, 0},
+		},
+		{
+			name: "64-bit",
+			entryPoint: &smbios.EntryPoint64Bit{
+				Length:                0x18,
+				Major:                 3,
+				Minor:                 3,
+				EntryPointRevision:    1,
+				StructureTableMaxSize: 0x1000,
+				StructureTableAddress: 0x7a000000,
+			},
+			expected: [3]int{3, 3, 0},
+		},
+	}
+
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		raw := &Raw{
+			Stream:     io.NopCloser(bytes.NewReader(table)),
+			EntryPoint: test.entryPoint,
+		}
+		if err := WriteDump(buf, raw); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		stream, ep, err := ReadDump(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		major, minor, rev := ep.Version()
+		if version := [3]int{major, minor, rev}; version != test.expected {
+			t.Errorf("%s: expected version %+v, got %+v", test.name, test.expected, version)
+		}
+		if addr, size := ep.Table(); addr != CDumpTableAddress || size != len(table) {
+			t.Errorf("%s: expected table at %d of size %d, got %d of size %d", test.name, CDumpTableAddress, len(table), addr, size)
+		}
+
+		actual, err := io.ReadAll(stream)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !bytes.Equal(actual, table) {
+			t.Errorf("%s: expected table %x, got %x", test.name, table, actual)
+		}
+
+		structures, err := smbios.NewDecoder(bytes.NewReader(actual)).Decode()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if len(structures) != 2 || structures[0].Strings[0] != "rack=R12" {
+			t.Errorf("%s: unexpected structures %+v", test.name, structures)
+		}
+	}
+}
+
+func TestReadDumpInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		dump []byte
+	}{
+		{name: "too short", dump: []byte(CEntryPoint32Anchor)},
+		{name: "unknown anchor", dump: append([]byte("_XX_"), make([]byte, CDumpTableAddress)...)},
+		{name: "bad checksum", dump: append([]byte{'_', 'S', 'M', '3', '_', 0x00, 0x18}, make([]byte, CDumpTableAddress)...)},
+	}
+
+	for _, test := range tests {
+		if _, _, err := ReadDump(bytes.NewReader(test.dump)); err == nil {
+			t.Errorf("%s: expected error", test.name)
+		}
+	}
+}
@@ -10,3 +10,19 @@ const (
 	CSystemInformationHeaderType
 	CBoardInformationHeaderType
 )
+
+const (
+	CChassisInformationHeaderType   = 3
+	CProcessorInformationHeaderType = 4
+	CCacheInformationHeaderType     = 7
+	CSystemSlotHeaderType           = 9
+	COEMStringsHeaderType           = 11
+	CSystemConfigurationHeaderType  = 12
+	CPhysicalMemoryArrayHeaderType  = 16
+	CMemoryDeviceHeaderType         = 17
+	CCoolingDeviceHeaderType        = 27
+	CTemperatureProbeHeaderType     = 28
+	CSystemPowerSupplyHeaderType    = 39
+	COnboardDeviceHeaderType        = 41
+	CHostInterfaceHeaderType        = 42
+)
@@ -0,0 +1,295 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Host interface layout is defined by DMTF DSP0270 (Redfish Host Interface Specification)
+// and DSP0239 (MCTP IDs and Codes)
+
+var hostInterfaceTypes = map[byte]string{
+	0x02: "KCS: Keyboard Controller Style",
+	0x03: "8250 UART Register Compatible",
+	0x04: "16450 UART Register Compatible",
+	0x05: "16550/16550A UART Register Compatible",
+	0x06: "16650/16650A UART Register Compatible",
+	0x07: "16750/16750A UART Register Compatible",
+	0x08: "16850/16850A UART Register Compatible",
+	0x40: "Network",
+	0xF0: "OEM",
+}
+
+var hostInterfaceProtocolTypes = map[byte]string{
+	0x02: "IPMI",
+	0x03: "MCTP",
+	0x04: "Redfish over IP",
+	0xF0: "OEM",
+}
+
+type HostInterfaceDeviceType string
+
+const (
+	CUSBHostInterfaceDeviceType   HostInterfaceDeviceType = "USB"
+	CPCIHostInterfaceDeviceType   HostInterfaceDeviceType = "PCI/PCIe"
+	CUSBV2HostInterfaceDeviceType HostInterfaceDeviceType = "USB v2"
+	CPCIV2HostInterfaceDeviceType HostInterfaceDeviceType = "PCI/PCIe v2"
+	COEMHostInterfaceDeviceType   HostInterfaceDeviceType = "OEM"
+)
+
+var hostInterfaceDeviceTypes = map[byte]HostInterfaceDeviceType{
+	0x02: CUSBHostInterfaceDeviceType,
+	0x03: CPCIHostInterfaceDeviceType,
+	0x04: CUSBV2HostInterfaceDeviceType,
+	0x05: CPCIV2HostInterfaceDeviceType,
+}
+
+var hostIPAssignmentTypes = map[byte]string{
+	0x00: "Unknown",
+	0x01: "Static",
+	0x02: "DHCP",
+	0x03: "AutoConf",
+	0x04: "Host Selected",
+}
+
+const (
+	CNetworkHostInterfaceType = 0x40
+	CRedfishOverIPProtocol    = 0x04
+	COEMDeviceTypeMin         = 0x80
+
+	CIPv4AddressFormat = 0x01
+	CIPv6AddressFormat = 0x02
+
+	CRedfishServiceUUIDLength = 16
+	CRedfishIPFieldLength     = 16
+	// CRedfishProtocolDataMinLength is a length of the record without service hostname
+	CRedfishProtocolDataMinLength = 91
+)
+
+// HostInterfaceDevice identifies host side network device the interface is exposed by
+type HostInterfaceDevice struct {
+	Type HostInterfaceDeviceType
+	// VendorID and ProductID are USB or PCI IDs in hex without prefix
+	VendorID     string
+	ProductID    string
+	SubVendorID  string
+	SubProductID string
+	SerialNumber string
+	MACAddress   string
+	PCIAddress   string
+}
+
+type RedfishOverIP struct {
+	ServiceUUID          string
+	HostIPAssignmentType string
+	HostIPAddress        string
+	HostIPMask           string
+	ServiceDiscoveryType string
+	ServiceIPAddress     string
+	ServiceIPMask        string
+	ServicePort          uint16
+	ServiceVLANID        uint32
+	ServiceHostname      string
+}
+
+type HostInterfaceProtocol struct {
+	Type          string
+	RedfishOverIP *RedfishOverIP
+}
+
+type HostInterface struct {
+	Handle    uint16
+	Type      string
+	Device    *HostInterfaceDevice
+	Protocols []HostInterfaceProtocol
+}
+
+// HostInterfaceFromFormatted decodes host interface structure,
+// it is not unpacked with struc since it consists of the variable length records
+func HostInterfaceFromFormatted(formatted []byte, strings []string) (*HostInterface, error) {
+	// interface type (1) + interface specific data length (1)
+	if len(formatted) < 2 {
+		return nil, errors.Errorf("structure is too short: %d bytes", len(formatted))
+	}
+
+	info := &HostInterface{
+		Type:      hostInterfaceTypes[formatted[0]],
+		Protocols: make([]HostInterfaceProtocol, 0),
+	}
+
+	dataLength := int(formatted[1])
+	offset := 2
+	if len(formatted) < offset+dataLength {
+		return nil, errors.Errorf("interface specific data length %d exceeds structure length", dataLength)
+	}
+
+	if formatted[0] == CNetworkHostInterfaceType && dataLength > 0 {
+		info.Device = hostInterfaceDeviceFromData(formatted[offset:offset+dataLength], strings)
+	}
+	offset += dataLength
+
+	// protocol records are defined since SMBIOS 3.2,
+	// earlier structures may end right after interface specific data
+	if len(formatted) <= offset {
+		return info, nil
+	}
+
+	protocolCount := int(formatted[offset])
+	offset++
+
+	for i := 0; i < protocolCount; i++ {
+		// protocol type (1) + protocol specific data length (1)
+		if len(formatted) < offset+2 {
+			return info, errors.Errorf("protocol record %d is truncated", i)
+		}
+
+		protocolType := formatted[offset]
+		protocolLength := int(formatted[offset+1])
+		offset += 2
+
+		if len(formatted) < offset+protocolLength {
+			return info, errors.Errorf("protocol record %d data length %d exceeds structure length", i, protocolLength)
+		}
+
+		protocol := HostInterfaceProtocol{
+			Type: hostInterfaceProtocolTypes[protocolType],
+		}
+		if protocolType == CRedfishOverIPProtocol {
+			protocol.RedfishOverIP = redfishOverIPFromData(formatted[offset : offset+protocolLength])
+		}
+		info.Protocols = append(info.Protocols, protocol)
+
+		offset += protocolLength
+	}
+
+	return info, nil
+}
+
+func hostInterfaceDeviceFromData(data []byte, strings []string) *HostInterfaceDevice {
+	device := &HostInterfaceDevice{
+		Type: hostInterfaceDeviceTypes[data[0]],
+	}
+	if data[0] >= COEMDeviceTypeMin {
+		device.Type = COEMHostInterfaceDeviceType
+	}
+
+	switch device.Type {
+	case CUSBHostInterfaceDeviceType:
+		// type (1), vendor id (2), product id (2), serial number USB descriptor
+		if len(data) < 5 {
+			return device
+		}
+		device.VendorID = hexID(data[1:3])
+		device.ProductID = hexID(data[3:5])
+		if len(data) > 7 {
+			device.SerialNumber = usbStringDescriptor(data[5:])
+		}
+	case CPCIHostInterfaceDeviceType:
+		// type (1), vendor id (2), device id (2), subsystem vendor id (2), subsystem id (2)
+		if len(data) < 9 {
+			return device
+		}
+		device.VendorID = hexID(data[1:3])
+		device.ProductID = hexID(data[3:5])
+		device.SubVendorID = hexID(data[5:7])
+		device.SubProductID = hexID(data[7:9])
+	case CUSBV2HostInterfaceDeviceType:
+		// type (1), length (1), vendor id (2), product id (2), serial number string (1), MAC (6)
+		if len(data) < 13 {
+			return device
+		}
+		device.VendorID = hexID(data[2:4])
+		device.ProductID = hexID(data[4:6])
+		device.SerialNumber = emptyStringOrValue(data[6], strings)
+		device.MACAddress = macAddressFromData(data[7:13])
+	case CPCIV2HostInterfaceDeviceType:
+		// type (1), length (1), vendor id (2), device id (2), subsystem vendor id (2),
+		// subsystem id (2), MAC (6), segment (2), bus (1), device/function (1)
+		if len(data) < 20 {
+			return device
+		}
+		device.VendorID = hexID(data[2:4])
+		device.ProductID = hexID(data[4:6])
+		device.SubVendorID = hexID(data[6:8])
+		device.SubProductID = hexID(data[8:10])
+		device.MACAddress = macAddressFromData(data[10:16])
+		device.PCIAddress = pciAddress(binary.LittleEndian.Uint16(data[16:18]), data[18], data[19])
+	}
+
+	return device
+}
+
+func redfishOverIPFromData(data []byte) *RedfishOverIP {
+	if len(data) < CRedfishProtocolDataMinLength {
+		return nil
+	}
+
+	redfish := &RedfishOverIP{
+		ServiceUUID:          uuidFromSMBIOS(data[0:CRedfishServiceUUIDLength]),
+		HostIPAssignmentType: hostIPAssignmentTypes[data[16]],
+		HostIPAddress:        ipFromData(data[17], data[18:34]),
+		HostIPMask:           ipFromData(data[17], data[34:50]),
+		ServiceDiscoveryType: hostIPAssignmentTypes[data[50]],
+		ServiceIPAddress:     ipFromData(data[51], data[52:68]),
+		ServiceIPMask:        ipFromData(data[51], data[68:84]),
+		ServicePort:          binary.LittleEndian.Uint16(data[84:86]),
+		ServiceVLANID:        binary.LittleEndian.Uint32(data[86:90]),
+	}
+
+	hostnameLength := int(data[90])
+	if len(data) >= CRedfishProtocolDataMinLength+hostnameLength {
+		hostname := data[CRedfishProtocolDataMinLength : CRedfishProtocolDataMinLength+hostnameLength]
+		redfish.ServiceHostname = strings.TrimRight(string(hostname), "\x00")
+	}
+
+	return redfish
+}
+
+// ipFromData formats IP address field,
+// IPv4 addresses take first 4 bytes of 16 byte field
+func ipFromData(format byte, data []byte) string {
+	switch format {
+	case CIPv4AddressFormat:
+		return net.IP(data[0:net.IPv4len]).String()
+	case CIPv6AddressFormat:
+		return net.IP(data[0:net.IPv6len]).String()
+	}
+	return ""
+}
+
+// macAddressFromData formats MAC address field,
+// zeroed field means that address is not provided
+func macAddressFromData(data []byte) string {
+	if bytes.Equal(data, make([]byte, len(data))) {
+		return ""
+	}
+	return net.HardwareAddr(data).String()
+}
+
+func hexID(data []byte) string {
+	return fmt.Sprintf("%04x", binary.LittleEndian.Uint16(data))
+}
+
+// usbStringDescriptor decodes USB string descriptor:
+// length (1), descriptor type (1), UTF-16LE string
+func usbStringDescriptor(data []byte) string {
+	length := int(data[0])
+	if length < 2 || length > len(data) {
+		return ""
+	}
+
+	runes := make([]rune, 0, (length-2)/2)
+	for i := 2; i+1 < length; i += 2 {
+		runes = append(runes, rune(binary.LittleEndian.Uint16(data[i:i+2])))
+	}
+
+	return string(runes)
+}
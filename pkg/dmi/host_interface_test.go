@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+// redfishOverIPData builds Redfish over IP protocol record data
+// with IPv4 host and service addresses
+func redfishOverIPData(hostname string) []byte {
+	data := make([]byte, CRedfishProtocolDataMinLength, CRedfishProtocolDataMinLength+len(hostname))
+	for i := 0; i < CRedfishServiceUUIDLength; i++ {
+		data[i] = byte(i)
+	}
+	data[16] = 0x02
+	data[17] = CIPv4AddressFormat
+	copy(data[18:], []byte{169, 254, 0, 2})
+	copy(data[34:], []byte{255, 255, 0, 0})
+	data[50] = 0x01
+	data[51] = CIPv4AddressFormat
+	copy(data[52:], []byte{169, 254, 0, 1})
+	copy(data[68:], []byte{255, 255, 0, 0})
+	binary.LittleEndian.PutUint16(data[84:], 443)
+	binary.LittleEndian.PutUint32(data[86:], 0)
+	data[90] = byte(len(hostname))
+	return append(data, hostname...)
+}
+
+// hostInterfaceData builds formatted part of the network host interface structure
+func hostInterfaceData(device []byte, protocols ...[]byte) []byte {
+	formatted := []byte{CNetworkHostInterfaceType, byte(len(device))}
+	formatted = append(formatted, device...)
+	formatted = append(formatted, byte(len(protocols)))
+	for _, protocol := range protocols {
+		formatted = append(formatted, CRedfishOverIPProtocol, byte(len(protocol)))
+		formatted = append(formatted, protocol...)
+	}
+	return formatted
+}
+
+func TestHostInterfaceFromFormatted(t *testing.T) {
+	usbV2Device := []byte{0x04, 13, 0x6b, 0x1d, 0x03, 0x01, 0x01, 0x0a, 0x94, 0xef, 0x01, 0x02, 0x03}
+	pciV2Device := []byte{
+		0x05, 20,
+		0x86, 0x80, 0x33, 0x15,
+		0x3c, 0x10, 0x01, 0x00,
+		0x0a, 0x94, 0xef, 0x01, 0x02, 0x03,
+		0x00, 0x00, 0x19, 0x01,
+	}
+	usbDevice := []byte{0x02, 0x6b, 0x1d, 0x03, 0x01, 0x08, 0x03, 'A', 0x00, 'B', 0x00, 'C', 0x00}
+	truncated := hostInterfaceData(usbV2Device, redfishOverIPData(""))
+	redfish := &RedfishOverIP{
+		ServiceUUID:          "03020100-0504-0706-0809-0a0b0c0d0e0f",
+		HostIPAssignmentType: "DHCP",
+		HostIPAddress:        "169.254.0.2",
+		HostIPMask:           "255.255.0.0",
+		ServiceDiscoveryType: "Static",
+		ServiceIPAddress:     "169.254.0.1",
+		ServiceIPMask:        "255.255.0.0",
+		ServicePort:          443,
+		ServiceHostname:      "bmc.local",
+	}
+
+	tests := []struct {
+		name      string
+		formatted []byte
+		strings   []string
+		expected  *HostInterface
+		expectErr bool
+	}{
+		{
+			name:      "USB v2 with Redfish over IP",
+			formatted: hostInterfaceData(usbV2Device, redfishOverIPData("bmc.local")),
+			strings:   []string{"SN123"},
+			expected: &HostInterface{
+				Type: "Network",
+				Device: &HostInterfaceDevice{
+					Type:         CUSBV2HostInterfaceDeviceType,
+					VendorID:     "1d6b",
+					ProductID:    "0103",
+					SerialNumber: "SN123",
+					MACAddress:   "0a:94:ef:01:02:03",
+				},
+				Protocols: []HostInterfaceProtocol{{Type: "Redfish over IP", RedfishOverIP: redfish}},
+			},
+		},
+		{
+			name:      "PCI v2",
+			formatted: hostInterfaceData(pciV2Device),
+			expected: &HostInterface{
+				Type: "Network",
+				Device: &HostInterfaceDevice{
+					Type:         CPCIV2HostInterfaceDeviceType,
+					VendorID:     "8086",
+					ProductID:    "1533",
+					SubVendorID:  "103c",
+					SubProductID: "0001",
+					MACAddress:   "0a:94:ef:01:02:03",
+					PCIAddress:   "0000:19:00.1",
+				},
+				Protocols: []HostInterfaceProtocol{},
+			},
+		},
+		{
+			name:      "USB without protocol records",
+			formatted: append([]byte{CNetworkHostInterfaceType, byte(len(usbDevice))}, usbDevice...),
+			expected: &HostInterface{
+				Type: "Network",
+				Device: &HostInterfaceDevice{
+					Type:         CUSBHostInterfaceDeviceType,
+					VendorID:     "1d6b",
+					ProductID:    "0103",
+					SerialNumber: "ABC",
+				},
+				Protocols: []HostInterfaceProtocol{},
+			},
+		},
+		{
+			name:      "OEM device",
+			formatted: hostInterfaceData([]byte{0x80, 0x01}),
+			expected: &HostInterface{
+				Type:      "Network",
+				Device:    &HostInterfaceDevice{Type: COEMHostInterfaceDeviceType},
+				Protocols: []HostInterfaceProtocol{},
+			},
+		},
+		{
+			name:      "too short",
+			formatted: []byte{CNetworkHostInterfaceType},
+			expectErr: true,
+		},
+		{
+			name:      "data length exceeds structure",
+			formatted: []byte{CNetworkHostInterfaceType, 13, 0x04},
+			expectErr: true,
+		},
+		{
+			name:      "truncated protocol record",
+			formatted: truncated[:len(truncated)-1],
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		info, err := HostInterfaceFromFormatted(test.formatted, test.strings)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %+v", test.name, info)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(info, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, info)
+		}
+	}
+}
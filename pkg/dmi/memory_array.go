@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+type MemoryArrayLocation string
+
+const (
+	COtherMemoryArrayLocation       MemoryArrayLocation = "Other"
+	CUnknownMemoryArrayLocation     MemoryArrayLocation = "Unknown"
+	CMotherboardMemoryArrayLocation MemoryArrayLocation = "System board or motherboard"
+	CISAMemoryArrayLocation         MemoryArrayLocation = "ISA add-on card"
+	CEISAMemoryArrayLocation        MemoryArrayLocation = "EISA add-on card"
+	CPCIMemoryArrayLocation         MemoryArrayLocation = "PCI add-on card"
+	CMCAMemoryArrayLocation         MemoryArrayLocation = "MCA add-on card"
+	CPCMCIAMemoryArrayLocation      MemoryArrayLocation = "PCMCIA add-on card"
+	CProprietaryMemoryArrayLocation MemoryArrayLocation = "Proprietary add-on card"
+	CNuBusMemoryArrayLocation       MemoryArrayLocation = "NuBus"
+	CCXLMemoryArrayLocation         MemoryArrayLocation = "CXL add-on card"
+)
+
+var memoryArrayLocations = map[byte]MemoryArrayLocation{
+	0x01: COtherMemoryArrayLocation,
+	0x02: CUnknownMemoryArrayLocation,
+	0x03: CMotherboardMemoryArrayLocation,
+	0x04: CISAMemoryArrayLocation,
+	0x05: CEISAMemoryArrayLocation,
+	0x06: CPCIMemoryArrayLocation,
+	0x07: CMCAMemoryArrayLocation,
+	0x08: CPCMCIAMemoryArrayLocation,
+	0x09: CProprietaryMemoryArrayLocation,
+	0x0A: CNuBusMemoryArrayLocation,
+	0xA5: CCXLMemoryArrayLocation,
+}
+
+type MemoryArrayUse string
+
+const (
+	COtherMemoryArrayUse          MemoryArrayUse = "Other"
+	CUnknownMemoryArrayUse        MemoryArrayUse = "Unknown"
+	CSystemMemoryArrayUse         MemoryArrayUse = "System memory"
+	CVideoMemoryArrayUse          MemoryArrayUse = "Video memory"
+	CFlashMemoryArrayUse          MemoryArrayUse = "Flash memory"
+	CNonVolatileRAMMemoryArrayUse MemoryArrayUse = "Non-volatile RAM"
+	CCacheMemoryArrayUse          MemoryArrayUse = "Cache memory"
+)
+
+var memoryArrayUses = map[byte]MemoryArrayUse{
+	0x01: COtherMemoryArrayUse,
+	0x02: CUnknownMemoryArrayUse,
+	0x03: CSystemMemoryArrayUse,
+	0x04: CVideoMemoryArrayUse,
+	0x05: CFlashMemoryArrayUse,
+	0x06: CNonVolatileRAMMemoryArrayUse,
+	0x07: CCacheMemoryArrayUse,
+}
+
+type MemoryErrorCorrection string
+
+const (
+	COtherMemoryErrorCorrection        MemoryErrorCorrection = "Other"
+	CUnknownMemoryErrorCorrection      MemoryErrorCorrection = "Unknown"
+	CNoneMemoryErrorCorrection         MemoryErrorCorrection = "None"
+	CParityMemoryErrorCorrection       MemoryErrorCorrection = "Parity"
+	CSingleBitECCMemoryErrorCorrection MemoryErrorCorrection = "Single-bit ECC"
+	CMultiBitECCMemoryErrorCorrection  MemoryErrorCorrection = "Multi-bit ECC"
+	CCRCMemoryErrorCorrection          MemoryErrorCorrection = "CRC"
+)
+
+var memoryErrorCorrections = map[byte]MemoryErrorCorrection{
+	0x01: COtherMemoryErrorCorrection,
+	0x02: CUnknownMemoryErrorCorrection,
+	0x03: CNoneMemoryErrorCorrection,
+	0x04: CParityMemoryErrorCorrection,
+	0x05: CSingleBitECCMemoryErrorCorrection,
+	0x06: CMultiBitECCMemoryErrorCorrection,
+	0x07: CCRCMemoryErrorCorrection,
+}
+
+const (
+	// CMemoryArrayExtendedCapacity in maximum capacity field
+	// means that value is stored in extended maximum capacity
+	CMemoryArrayExtendedCapacity = 0x80000000
+	// CNoMemoryErrorInformation handle means that no error was detected
+	CNoMemoryErrorInformation = 0xFFFE
+)
+
+type PhysicalMemoryArrayRefSpec21 struct {
+	Location                     byte   `struc:"byte"`
+	Use                          byte   `struc:"byte"`
+	MemoryErrorCorrection        byte   `struc:"byte"`
+	MaximumCapacity              uint32 `struc:"uint32,little"`
+	MemoryErrorInformationHandle uint16 `struc:"uint16,little"`
+	NumberOfMemoryDevices        uint16 `struc:"uint16,little"`
+}
+
+type PhysicalMemoryArrayRefSpec27 struct {
+	PhysicalMemoryArrayRefSpec21
+	ExtendedMaximumCapacity uint64 `struc:"uint64,little"`
+}
+
+type PhysicalMemoryArray struct {
+	Handle                       uint16
+	Location                     MemoryArrayLocation
+	Use                          MemoryArrayUse
+	MemoryErrorCorrection        MemoryErrorCorrection
+	MaximumCapacity              uint64
+	MemoryErrorInformationHandle uint16
+	NumberOfMemoryDevices        uint16
+}
+
+func PhysicalMemoryArrayFromSpec21(ref *PhysicalMemoryArrayRefSpec21) *PhysicalMemoryArray {
+	array := &PhysicalMemoryArray{
+		Location:                     memoryArrayLocations[ref.Location],
+		Use:                          memoryArrayUses[ref.Use],
+		MemoryErrorCorrection:        memoryErrorCorrections[ref.MemoryErrorCorrection],
+		MemoryErrorInformationHandle: ref.MemoryErrorInformationHandle,
+		NumberOfMemoryDevices:        ref.NumberOfMemoryDevices,
+	}
+
+	// maximum capacity is specified in kilobytes
+	if ref.MaximumCapacity != CMemoryArrayExtendedCapacity {
+		array.MaximumCapacity = uint64(ref.MaximumCapacity) * 1024
+	}
+
+	return array
+}
+
+func PhysicalMemoryArrayFromSpec27(ref *PhysicalMemoryArrayRefSpec27) *PhysicalMemoryArray {
+	array := PhysicalMemoryArrayFromSpec21(&ref.PhysicalMemoryArrayRefSpec21)
+
+	// extended maximum capacity is specified in bytes
+	if ref.MaximumCapacity == CMemoryArrayExtendedCapacity {
+		array.MaximumCapacity = ref.ExtendedMaximumCapacity
+	}
+
+	return array
+}
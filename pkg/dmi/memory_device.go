@@ -0,0 +1,396 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"fmt"
+)
+
+type MemoryFormFactor string
+
+const (
+	COtherMemoryFormFactor       MemoryFormFactor = "Other"
+	CUnknownMemoryFormFactor     MemoryFormFactor = "Unknown"
+	CSIMMMemoryFormFactor        MemoryFormFactor = "SIMM"
+	CSIPMemoryFormFactor         MemoryFormFactor = "SIP"
+	CChipMemoryFormFactor        MemoryFormFactor = "Chip"
+	CDIPMemoryFormFactor         MemoryFormFactor = "DIP"
+	CZIPMemoryFormFactor         MemoryFormFactor = "ZIP"
+	CProprietaryMemoryFormFactor MemoryFormFactor = "Proprietary Card"
+	CDIMMMemoryFormFactor        MemoryFormFactor = "DIMM"
+	CTSOPMemoryFormFactor        MemoryFormFactor = "TSOP"
+	CRowOfChipsMemoryFormFactor  MemoryFormFactor = "Row of chips"
+	CRIMMMemoryFormFactor        MemoryFormFactor = "RIMM"
+	CSODIMMMemoryFormFactor      MemoryFormFactor = "SODIMM"
+	CSRIMMMemoryFormFactor       MemoryFormFactor = "SRIMM"
+	CFBDIMMMemoryFormFactor      MemoryFormFactor = "FB-DIMM"
+	CDieMemoryFormFactor         MemoryFormFactor = "Die"
+)
+
+var memoryFormFactors = map[byte]MemoryFormFactor{
+	0x01: COtherMemoryFormFactor,
+	0x02: CUnknownMemoryFormFactor,
+	0x03: CSIMMMemoryFormFactor,
+	0x04: CSIPMemoryFormFactor,
+	0x05: CChipMemoryFormFactor,
+	0x06: CDIPMemoryFormFactor,
+	0x07: CZIPMemoryFormFactor,
+	0x08: CProprietaryMemoryFormFactor,
+	0x09: CDIMMMemoryFormFactor,
+	0x0A: CTSOPMemoryFormFactor,
+	0x0B: CRowOfChipsMemoryFormFactor,
+	0x0C: CRIMMMemoryFormFactor,
+	0x0D: CSODIMMMemoryFormFactor,
+	0x0E: CSRIMMMemoryFormFactor,
+	0x0F: CFBDIMMMemoryFormFactor,
+	0x10: CDieMemoryFormFactor,
+}
+
+type MemoryType string
+
+const (
+	COtherMemoryType      MemoryType = "Other"
+	CUnknownMemoryType    MemoryType = "Unknown"
+	CDRAMMemoryType       MemoryType = "DRAM"
+	CEDRAMMemoryType      MemoryType = "EDRAM"
+	CVRAMMemoryType       MemoryType = "VRAM"
+	CSRAMMemoryType       MemoryType = "SRAM"
+	CRAMMemoryType        MemoryType = "RAM"
+	CROMMemoryType        MemoryType = "ROM"
+	CFlashMemoryType      MemoryType = "Flash"
+	CEEPROMMemoryType     MemoryType = "EEPROM"
+	CFEPROMMemoryType     MemoryType = "FEPROM"
+	CEPROMMemoryType      MemoryType = "EPROM"
+	CCDRAMMemoryType      MemoryType = "CDRAM"
+	C3DRAMMemoryType      MemoryType = "3DRAM"
+	CSDRAMMemoryType      MemoryType = "SDRAM"
+	CSGRAMMemoryType      MemoryType = "SGRAM"
+	CRDRAMMemoryType      MemoryType = "RDRAM"
+	CDDRMemoryType        MemoryType = "DDR"
+	CDDR2MemoryType       MemoryType = "DDR2"
+	CDDR2FBDIMMMemoryType MemoryType = "DDR2 FB-DIMM"
+	CDDR3MemoryType       MemoryType = "DDR3"
+	CFBD2MemoryType       MemoryType = "FBD2"
+	CDDR4MemoryType       MemoryType = "DDR4"
+	CLPDDRMemoryType      MemoryType = "LPDDR"
+	CLPDDR2MemoryType     MemoryType = "LPDDR2"
+	CLPDDR3MemoryType     MemoryType = "LPDDR3"
+	CLPDDR4MemoryType     MemoryType = "LPDDR4"
+	CLogicalNVMemoryType  MemoryType = "Logical non-volatile device"
+	CHBMMemoryType        MemoryType = "HBM"
+	CHBM2MemoryType       MemoryType = "HBM2"
+	CDDR5MemoryType       MemoryType = "DDR5"
+	CLPDDR5MemoryType     MemoryType = "LPDDR5"
+	CHBM3MemoryType       MemoryType = "HBM3"
+)
+
+var memoryTypes = map[byte]MemoryType{
+	0x01: COtherMemoryType,
+	0x02: CUnknownMemoryType,
+	0x03: CDRAMMemoryType,
+	0x04: CEDRAMMemoryType,
+	0x05: CVRAMMemoryType,
+	0x06: CSRAMMemoryType,
+	0x07: CRAMMemoryType,
+	0x08: CROMMemoryType,
+	0x09: CFlashMemoryType,
+	0x0A: CEEPROMMemoryType,
+	0x0B: CFEPROMMemoryType,
+	0x0C: CEPROMMemoryType,
+	0x0D: CCDRAMMemoryType,
+	0x0E: C3DRAMMemoryType,
+	0x0F: CSDRAMMemoryType,
+	0x10: CSGRAMMemoryType,
+	0x11: CRDRAMMemoryType,
+	0x12: CDDRMemoryType,
+	0x13: CDDR2MemoryType,
+	0x14: CDDR2FBDIMMMemoryType,
+	0x18: CDDR3MemoryType,
+	0x19: CFBD2MemoryType,
+	0x1A: CDDR4MemoryType,
+	0x1B: CLPDDRMemoryType,
+	0x1C: CLPDDR2MemoryType,
+	0x1D: CLPDDR3MemoryType,
+	0x1E: CLPDDR4MemoryType,
+	0x1F: CLogicalNVMemoryType,
+	0x20: CHBMMemoryType,
+	0x21: CHBM2MemoryType,
+	0x22: CDDR5MemoryType,
+	0x23: CLPDDR5MemoryType,
+	0x24: CHBM3MemoryType,
+}
+
+// CMemoryTypeDetails are bits of type detail field starting from bit 0
+var CMemoryTypeDetails = []string{
+	"Reserved",
+	"Other",
+	"Unknown",
+	"Fast-paged",
+	"Static column",
+	"Pseudo-static",
+	"RAMBUS",
+	"Synchronous",
+	"CMOS",
+	"EDO",
+	"Window DRAM",
+	"Cache DRAM",
+	"Non-volatile",
+	"Registered (Buffered)",
+	"Unbuffered (Unregistered)",
+	"LRDIMM",
+}
+
+type MemoryTechnology string
+
+const (
+	COtherMemoryTechnology   MemoryTechnology = "Other"
+	CUnknownMemoryTechnology MemoryTechnology = "Unknown"
+	CDRAMMemoryTechnology    MemoryTechnology = "DRAM"
+	CNVDIMMNMemoryTechnology MemoryTechnology = "NVDIMM-N"
+	CNVDIMMFMemoryTechnology MemoryTechnology = "NVDIMM-F"
+	CNVDIMMPMemoryTechnology MemoryTechnology = "NVDIMM-P"
+	COptaneMemoryTechnology  MemoryTechnology = "Intel Optane persistent memory"
+)
+
+var memoryTechnologies = map[byte]MemoryTechnology{
+	0x01: COtherMemoryTechnology,
+	0x02: CUnknownMemoryTechnology,
+	0x03: CDRAMMemoryTechnology,
+	0x04: CNVDIMMNMemoryTechnology,
+	0x05: CNVDIMMFMemoryTechnology,
+	0x06: CNVDIMMPMemoryTechnology,
+	0x07: COptaneMemoryTechnology,
+}
+
+const (
+	CMemoryDeviceSizeUnknown  = 0xFFFF
+	CMemoryDeviceSizeExtended = 0x7FFF
+	// CMemoryDeviceSizeKBGranularity bit in size field means
+	// that size is specified in kilobytes instead of megabytes
+	CMemoryDeviceSizeKBGranularity = 0x8000
+
+	CMemoryDeviceSpeedExtended = 0xFFFF
+	CMemoryDeviceWidthUnknown  = 0xFFFF
+	CMemoryDeviceRankMask      = 0x0F
+)
+
+type MemoryDeviceRefSpec21 struct {
+	PhysicalMemoryArrayHandle    uint16 `struc:"uint16,little"`
+	MemoryErrorInformationHandle uint16 `struc:"uint16,little"`
+	TotalWidth                   uint16 `struc:"uint16,little"`
+	DataWidth                    uint16 `struc:"uint16,little"`
+	Size                         uint16 `struc:"uint16,little"`
+	FormFactor                   byte   `struc:"byte"`
+	DeviceSet                    byte   `struc:"byte"`
+	DeviceLocator                byte   `struc:"byte"`
+	BankLocator                  byte   `struc:"byte"`
+	MemoryType                   byte   `struc:"byte"`
+	TypeDetail                   uint16 `struc:"uint16,little"`
+}
+
+type MemoryDeviceRefSpec23 struct {
+	MemoryDeviceRefSpec21
+	Speed        uint16 `struc:"uint16,little"`
+	Manufacturer byte   `struc:"byte"`
+	SerialNumber byte   `struc:"byte"`
+	AssetTag     byte   `struc:"byte"`
+	PartNumber   byte   `struc:"byte"`
+}
+
+type MemoryDeviceRefSpec26 struct {
+	MemoryDeviceRefSpec23
+	Attributes byte `struc:"byte"`
+}
+
+type MemoryDeviceRefSpec27 struct {
+	MemoryDeviceRefSpec26
+	ExtendedSize          uint32 `struc:"uint32,little"`
+	ConfiguredMemorySpeed uint16 `struc:"uint16,little"`
+}
+
+type MemoryDeviceRefSpec28 struct {
+	MemoryDeviceRefSpec27
+	MinimumVoltage    uint16 `struc:"uint16,little"`
+	MaximumVoltage    uint16 `struc:"uint16,little"`
+	ConfiguredVoltage uint16 `struc:"uint16,little"`
+}
+
+type MemoryDeviceRefSpec32 struct {
+	MemoryDeviceRefSpec28
+	MemoryTechnology                        byte   `struc:"byte"`
+	MemoryOperatingModeCapability           uint16 `struc:"uint16,little"`
+	FirmwareVersion                         byte   `struc:"byte"`
+	ModuleManufacturerID                    uint16 `struc:"uint16,little"`
+	ModuleProductID                         uint16 `struc:"uint16,little"`
+	MemorySubsystemControllerManufacturerID uint16 `struc:"uint16,little"`
+	MemorySubsystemControllerProductID      uint16 `struc:"uint16,little"`
+	NonVolatileSize                         uint64 `struc:"uint64,little"`
+	VolatileSize                            uint64 `struc:"uint64,little"`
+	CacheSize                               uint64 `struc:"uint64,little"`
+	LogicalSize                             uint64 `struc:"uint64,little"`
+}
+
+type MemoryDeviceRefSpec33 struct {
+	MemoryDeviceRefSpec32
+	ExtendedSpeed                 uint32 `struc:"uint32,little"`
+	ExtendedConfiguredMemorySpeed uint32 `struc:"uint32,little"`
+}
+
+type MemoryDevice struct {
+	Handle                       uint16
+	PhysicalMemoryArrayHandle    uint16
+	MemoryErrorInformationHandle uint16
+	TotalWidth                   uint16
+	DataWidth                    uint16
+	// Size is 0 if no module is installed in the slot
+	Size          uint64
+	FormFactor    MemoryFormFactor
+	DeviceSet     byte
+	DeviceLocator string
+	BankLocator   string
+	Type          MemoryType
+	TypeDetail    []string
+	// Speed and ConfiguredSpeed are specified in MT/s
+	Speed           uint32
+	Manufacturer    string
+	SerialNumber    string
+	AssetTag        string
+	PartNumber      string
+	Rank            byte
+	ConfiguredSpeed uint32
+	// Voltages are specified in millivolts
+	MinimumVoltage       uint16
+	MaximumVoltage       uint16
+	ConfiguredVoltage    uint16
+	Technology           MemoryTechnology
+	FirmwareVersion      string
+	ModuleManufacturerID string
+	ModuleProductID      string
+	NonVolatileSize      uint64
+	VolatileSize         uint64
+}
+
+// IsPopulated tells whether memory module is installed in the slot
+func (d *MemoryDevice) IsPopulated() bool {
+	return d.Size != 0
+}
+
+func MemoryDeviceFromSpec21(ref *MemoryDeviceRefSpec21, strings []string) *MemoryDevice {
+	device := &MemoryDevice{
+		PhysicalMemoryArrayHandle:    ref.PhysicalMemoryArrayHandle,
+		MemoryErrorInformationHandle: ref.MemoryErrorInformationHandle,
+		FormFactor:                   memoryFormFactors[ref.FormFactor],
+		DeviceSet:                    ref.DeviceSet,
+		DeviceLocator:                emptyStringOrValue(ref.DeviceLocator, strings),
+		BankLocator:                  emptyStringOrValue(ref.BankLocator, strings),
+		Type:                         memoryTypes[ref.MemoryType],
+	}
+
+	if ref.TotalWidth != CMemoryDeviceWidthUnknown {
+		device.TotalWidth = ref.TotalWidth
+	}
+	if ref.DataWidth != CMemoryDeviceWidthUnknown {
+		device.DataWidth = ref.DataWidth
+	}
+
+	// (1)(111111111111111)
+	// first group (1 bit) - granularity, set for kilobytes, unset for megabytes
+	// second group (15 bits) - size
+	if ref.Size != CMemoryDeviceSizeUnknown && ref.Size != CMemoryDeviceSizeExtended {
+		size := uint64(ref.Size &^ CMemoryDeviceSizeKBGranularity)
+		if ref.Size&CMemoryDeviceSizeKBGranularity != 0 {
+			device.Size = size * 1024
+		} else {
+			device.Size = size * 1024 * 1024
+		}
+	}
+
+	device.TypeDetail = make([]string, 0)
+	for i, detail := range CMemoryTypeDetails {
+		idx := uint16(1 << i)
+		enabled := ref.TypeDetail & idx
+		if enabled != 0 {
+			device.TypeDetail = append(device.TypeDetail, detail)
+		}
+	}
+
+	return device
+}
+
+func MemoryDeviceFromSpec23(ref *MemoryDeviceRefSpec23, strings []string) *MemoryDevice {
+	device := MemoryDeviceFromSpec21(&ref.MemoryDeviceRefSpec21, strings)
+
+	if ref.Speed != CMemoryDeviceSpeedExtended {
+		device.Speed = uint32(ref.Speed)
+	}
+	device.Manufacturer = emptyStringOrValue(ref.Manufacturer, strings)
+	device.SerialNumber = emptyStringOrValue(ref.SerialNumber, strings)
+	device.AssetTag = emptyStringOrValue(ref.AssetTag, strings)
+	device.PartNumber = emptyStringOrValue(ref.PartNumber, strings)
+
+	return device
+}
+
+func MemoryDeviceFromSpec26(ref *MemoryDeviceRefSpec26, strings []string) *MemoryDevice {
+	device := MemoryDeviceFromSpec23(&ref.MemoryDeviceRefSpec23, strings)
+
+	device.Rank = ref.Attributes & CMemoryDeviceRankMask
+
+	return device
+}
+
+func MemoryDeviceFromSpec27(ref *MemoryDeviceRefSpec27, strings []string) *MemoryDevice {
+	device := MemoryDeviceFromSpec26(&ref.MemoryDeviceRefSpec26, strings)
+
+	// extended size is specified in megabytes, bit 31 is reserved
+	if ref.Size == CMemoryDeviceSizeExtended {
+		device.Size = uint64(ref.ExtendedSize&0x7FFFFFFF) * 1024 * 1024
+	}
+	if ref.ConfiguredMemorySpeed != CMemoryDeviceSpeedExtended {
+		device.ConfiguredSpeed = uint32(ref.ConfiguredMemorySpeed)
+	}
+
+	return device
+}
+
+func MemoryDeviceFromSpec28(ref *MemoryDeviceRefSpec28, strings []string) *MemoryDevice {
+	device := MemoryDeviceFromSpec27(&ref.MemoryDeviceRefSpec27, strings)
+
+	device.MinimumVoltage = ref.MinimumVoltage
+	device.MaximumVoltage = ref.MaximumVoltage
+	device.ConfiguredVoltage = ref.ConfiguredVoltage
+
+	return device
+}
+
+func MemoryDeviceFromSpec32(ref *MemoryDeviceRefSpec32, strings []string) *MemoryDevice {
+	device := MemoryDeviceFromSpec28(&ref.MemoryDeviceRefSpec28, strings)
+
+	device.Technology = memoryTechnologies[ref.MemoryTechnology]
+	device.FirmwareVersion = emptyStringOrValue(ref.FirmwareVersion, strings)
+	if ref.ModuleManufacturerID != 0 {
+		device.ModuleManufacturerID = fmt.Sprintf("%04x", ref.ModuleManufacturerID)
+	}
+	if ref.ModuleProductID != 0 {
+		device.ModuleProductID = fmt.Sprintf("%04x", ref.ModuleProductID)
+	}
+	device.NonVolatileSize = ref.NonVolatileSize
+	device.VolatileSize = ref.VolatileSize
+
+	return device
+}
+
+func MemoryDeviceFromSpec33(ref *MemoryDeviceRefSpec33, strings []string) *MemoryDevice {
+	device := MemoryDeviceFromSpec32(&ref.MemoryDeviceRefSpec32, strings)
+
+	// extended speeds are used for values above 65534 MT/s, bit 31 is reserved
+	if ref.Speed == CMemoryDeviceSpeedExtended {
+		device.Speed = ref.ExtendedSpeed & 0x7FFFFFFF
+	}
+	if ref.ConfiguredMemorySpeed == CMemoryDeviceSpeedExtended {
+		device.ConfiguredSpeed = ref.ExtendedConfiguredMemorySpeed & 0x7FFFFFFF
+	}
+
+	return device
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMemoryDevice(t *testing.T) {
+	strings := []string{"DIMM_A1", "NODE 1", "Samsung", "12345678", "M393A4K40DB3-CWE", "1.2"}
+	base := MemoryDeviceRefSpec21{
+		PhysicalMemoryArrayHandle:    0x1000,
+		MemoryErrorInformationHandle: CNoMemoryErrorInformation,
+		TotalWidth:                   72,
+		DataWidth:                    64,
+		Size:                         16384,
+		FormFactor:                   0x09,
+		DeviceLocator:                1,
+		BankLocator:                  2,
+		MemoryType:                   0x1A,
+		// synchronous and registered
+		TypeDetail: 1<<7 | 1<<13,
+	}
+
+	tests := []struct {
+		name     string
+		version  *SMBIOSVersion
+		ref      interface{}
+		expected *MemoryDevice
+	}{
+		{
+			name:    "2.3 size in megabytes",
+			version: NewSMBIOSVersion(2, 3, 0),
+			ref: &MemoryDeviceRefSpec23{
+				MemoryDeviceRefSpec21: base,
+				Speed:                 3200,
+				Manufacturer:          3,
+				SerialNumber:          4,
+				PartNumber:            5,
+			},
+			expected: &MemoryDevice{
+				Handle:                       0x1100,
+				PhysicalMemoryArrayHandle:    0x1000,
+				MemoryErrorInformationHandle: CNoMemoryErrorInformation,
+				TotalWidth:                   72,
+				DataWidth:                    64,
+				Size:                         16 << 30,
+				FormFactor:                   CDIMMMemoryFormFactor,
+				DeviceLocator:                "DIMM_A1",
+				BankLocator:                  "NODE 1",
+				Type:                         CDDR4MemoryType,
+				TypeDetail:                   []string{"Synchronous", "Registered (Buffered)"},
+				Speed:                        3200,
+				Manufacturer:                 "Samsung",
+				SerialNumber:                 "12345678",
+				PartNumber:                   "M393A4K40DB3-CWE",
+			},
+		},
+		{
+			name:    "3.3 extended size and speed",
+			version: NewSMBIOSVersion(3, 3, 0),
+			ref: func() *MemoryDeviceRefSpec33 {
+				ref := &MemoryDeviceRefSpec33{}
+				ref.MemoryDeviceRefSpec21 = base
+				ref.Size = CMemoryDeviceSizeExtended
+				ref.MemoryType = 0x22
+				ref.TotalWidth = CMemoryDeviceWidthUnknown
+				ref.Speed = CMemoryDeviceSpeedExtended
+				ref.Attributes = 0x02
+				ref.ExtendedSize = 256 * 1024
+				ref.ConfiguredMemorySpeed = 4800
+				ref.ConfiguredVoltage = 1100
+				ref.MemoryTechnology = 0x03
+				ref.FirmwareVersion = 6
+				ref.ModuleManufacturerID = 0xce80
+				ref.VolatileSize = 256 << 30
+				ref.ExtendedSpeed = 70000
+				return ref
+			}(),
+			expected: &MemoryDevice{
+				Handle:                       0x1100,
+				PhysicalMemoryArrayHandle:    0x1000,
+				MemoryErrorInformationHandle: CNoMemoryErrorInformation,
+				DataWidth:                    64,
+				Size:                         256 << 30,
+				FormFactor:                   CDIMMMemoryFormFactor,
+				DeviceLocator:                "DIMM_A1",
+				BankLocator:                  "NODE 1",
+				Type:                         CDDR5MemoryType,
+				TypeDetail:                   []string{"Synchronous", "Registered (Buffered)"},
+				Speed:                        70000,
+				Rank:                         2,
+				ConfiguredSpeed:              4800,
+				ConfiguredVoltage:            1100,
+				Technology:                   CDRAMMemoryTechnology,
+				FirmwareVersion:              "1.2",
+				ModuleManufacturerID:         "ce80",
+				VolatileSize:                 256 << 30,
+			},
+		},
+		{
+			name:    "empty slot",
+			version: NewSMBIOSVersion(2, 1, 0),
+			ref: &MemoryDeviceRefSpec21{
+				DeviceLocator: 1,
+				FormFactor:    0x09,
+				MemoryType:    0x02,
+			},
+			expected: &MemoryDevice{
+				Handle:        0x1100,
+				FormFactor:    CDIMMMemoryFormFactor,
+				DeviceLocator: "DIMM_A1",
+				Type:          CUnknownMemoryType,
+				TypeDetail:    []string{},
+			},
+		},
+	}
+
+	svc := newTestSvc()
+	for _, test := range tests {
+		structure := packStructure(t, CMemoryDeviceHeaderType, 0x1100, test.ref, strings)
+		device, err := svc.parseMemoryDevice(structure, test.version)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(device, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, device)
+		}
+		if test.expected.Size != 0 != device.IsPopulated() {
+			t.Errorf("%s: unexpected populated state", test.name)
+		}
+	}
+}
+
+func TestParsePhysicalMemoryArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  *SMBIOSVersion
+		ref      interface{}
+		expected *PhysicalMemoryArray
+	}{
+		{
+			name:    "capacity in kilobytes",
+			version: NewSMBIOSVersion(2, 1, 0),
+			ref: &PhysicalMemoryArrayRefSpec21{
+				Location:                     0x03,
+				Use:                          0x03,
+				MemoryErrorCorrection:        0x06,
+				MaximumCapacity:              512 * 1024 * 1024,
+				MemoryErrorInformationHandle: CNoMemoryErrorInformation,
+				NumberOfMemoryDevices:        16,
+			},
+			expected: &PhysicalMemoryArray{
+				Handle:                       0x1000,
+				Location:                     memoryArrayLocations[0x03],
+				Use:                          memoryArrayUses[0x03],
+				MemoryErrorCorrection:        CMultiBitECCMemoryErrorCorrection,
+				MaximumCapacity:              512 << 30,
+				MemoryErrorInformationHandle: CNoMemoryErrorInformation,
+				NumberOfMemoryDevices:        16,
+			},
+		},
+		{
+			name:    "extended capacity",
+			version: NewSMBIOSVersion(2, 7, 0),
+			ref: &PhysicalMemoryArrayRefSpec27{
+				PhysicalMemoryArrayRefSpec21: PhysicalMemoryArrayRefSpec21{
+					Location:              0x03,
+					Use:                   0x03,
+					MemoryErrorCorrection: 0x06,
+					MaximumCapacity:       CMemoryArrayExtendedCapacity,
+					NumberOfMemoryDevices: 32,
+				},
+				ExtendedMaximumCapacity: 12 << 40,
+			},
+			expected: &PhysicalMemoryArray{
+				Handle:                0x1000,
+				Location:              memoryArrayLocations[0x03],
+				Use:                   memoryArrayUses[0x03],
+				MemoryErrorCorrection: CMultiBitECCMemoryErrorCorrection,
+				MaximumCapacity:       12 << 40,
+				NumberOfMemoryDevices: 32,
+			},
+		},
+	}
+
+	svc := newTestSvc()
+	for _, test := range tests {
+		structure := packStructure(t, CPhysicalMemoryArrayHeaderType, 0x1000, test.ref, nil)
+		array, err := svc.parsePhysicalMemoryArray(structure, test.version)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(array, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, array)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+// StringListRefSpec is a layout of both OEM strings
+// and system configuration options structures
+type StringListRefSpec struct {
+	Count byte `struc:"byte"`
+}
+
+func StringListFromSpec(ref *StringListRefSpec, strings []string) []string {
+	list := make([]string, 0, ref.Count)
+	for i := byte(1); i <= ref.Count; i++ {
+		list = append(list, emptyStringOrValue(i, strings))
+	}
+	return list
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseStringList(t *testing.T) {
+	tests := []struct {
+		name       string
+		headerType uint8
+		ref        *StringListRefSpec
+		strings    []string
+		expected   []string
+	}{
+		{
+			name:       "OEM strings",
+			headerType: COEMStringsHeaderType,
+			ref:        &StringListRefSpec{Count: 2},
+			strings:    []string{"rack=R12", "sku=XYZ"},
+			expected:   []string{"rack=R12", "sku=XYZ"},
+		},
+		{
+			name:       "system configuration options",
+			headerType: CSystemConfigurationHeaderType,
+			ref:        &StringListRefSpec{Count: 1},
+			strings:    []string{"JP1: 1-2 clear CMOS"},
+			expected:   []string{"JP1: 1-2 clear CMOS"},
+		},
+		{
+			name:       "count exceeds strings",
+			headerType: COEMStringsHeaderType,
+			ref:        &StringListRefSpec{Count: 2},
+			strings:    []string{"rack=R12"},
+			expected:   []string{"rack=R12", ""},
+		},
+		{
+			name:       "empty",
+			headerType: COEMStringsHeaderType,
+			ref:        &StringListRefSpec{},
+			expected:   []string{},
+		},
+	}
+
+	svc := newTestSvc()
+	for _, test := range tests {
+		structure := packStructure(t, test.headerType, 0x0b00, test.ref, test.strings)
+		list, err := svc.parseStringList(structure)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(list, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, list)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+var onboardDeviceTypes = map[byte]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Video",
+	0x04: "SCSI Controller",
+	0x05: "Ethernet",
+	0x06: "Token Ring",
+	0x07: "Sound",
+	0x08: "PATA Controller",
+	0x09: "SATA Controller",
+	0x0A: "SAS Controller",
+	0x0B: "Wireless LAN",
+	0x0C: "Bluetooth",
+	0x0D: "WWAN",
+	0x0E: "eMMC",
+	0x0F: "NVMe Controller",
+	0x10: "UFS Controller",
+}
+
+const (
+	COnboardDeviceEnabledMask = 0x80
+	COnboardDeviceTypeMask    = 0x7F
+)
+
+type OnboardDeviceRefSpec struct {
+	ReferenceDesignation byte   `struc:"byte"`
+	DeviceType           byte   `struc:"byte"`
+	DeviceTypeInstance   byte   `struc:"byte"`
+	SegmentGroupNumber   uint16 `struc:"uint16,little"`
+	BusNumber            byte   `struc:"byte"`
+	DeviceFunctionNumber byte   `struc:"byte"`
+}
+
+type OnboardDevice struct {
+	Handle               uint16
+	ReferenceDesignation string
+	Type                 string
+	Enabled              bool
+	Instance             byte
+	// PCIAddress is in the same format as in sysfs, e.g. 0000:3b:00.0
+	PCIAddress string
+}
+
+func OnboardDeviceFromSpec(ref *OnboardDeviceRefSpec, strings []string) *OnboardDevice {
+	device := &OnboardDevice{
+		ReferenceDesignation: emptyStringOrValue(ref.ReferenceDesignation, strings),
+		Type:                 onboardDeviceTypes[ref.DeviceType&COnboardDeviceTypeMask],
+		Enabled:              ref.DeviceType&COnboardDeviceEnabledMask != 0,
+		Instance:             ref.DeviceTypeInstance,
+		PCIAddress:           pciAddress(ref.SegmentGroupNumber, ref.BusNumber, ref.DeviceFunctionNumber),
+	}
+
+	return device
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOnboardDevice(t *testing.T) {
+	ref := &OnboardDeviceRefSpec{
+		ReferenceDesignation: 1,
+		// enabled ethernet
+		DeviceType:           COnboardDeviceEnabledMask | 0x05,
+		DeviceTypeInstance:   2,
+		BusNumber:            0x19,
+		DeviceFunctionNumber: 0x01,
+	}
+	expected := &OnboardDevice{
+		Handle:               0x2900,
+		ReferenceDesignation: "Onboard LAN 2",
+		Type:                 "Ethernet",
+		Enabled:              true,
+		Instance:             2,
+		PCIAddress:           "0000:19:00.1",
+	}
+
+	svc := newTestSvc()
+	structure := packStructure(t, COnboardDeviceHeaderType, 0x2900, ref, []string{"Onboard LAN 2"})
+	device, err := svc.parseOnboardDevice(structure, NewSMBIOSVersion(3, 2, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(device, expected) {
+		t.Errorf("expected %+v, got %+v", expected, device)
+	}
+}
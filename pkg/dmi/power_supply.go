@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+type DeviceStatus string
+
+const (
+	COtherDeviceStatus          DeviceStatus = "Other"
+	CUnknownDeviceStatus        DeviceStatus = "Unknown"
+	COKDeviceStatus             DeviceStatus = "OK"
+	CNonCriticalDeviceStatus    DeviceStatus = "Non-critical"
+	CCriticalDeviceStatus       DeviceStatus = "Critical"
+	CNonRecoverableDeviceStatus DeviceStatus = "Non-recoverable"
+)
+
+var deviceStatuses = map[byte]DeviceStatus{
+	0x01: COtherDeviceStatus,
+	0x02: CUnknownDeviceStatus,
+	0x03: COKDeviceStatus,
+	0x04: CNonCriticalDeviceStatus,
+	0x05: CCriticalDeviceStatus,
+	0x06: CNonRecoverableDeviceStatus,
+}
+
+var powerSupplyTypes = map[uint16]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Linear",
+	0x04: "Switching",
+	0x05: "Battery",
+	0x06: "UPS",
+	0x07: "Converter",
+	0x08: "Regulator",
+}
+
+var powerSupplyInputVoltageRangeSwitches = map[uint16]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Manual",
+	0x04: "Auto-switch",
+	0x05: "Wide range",
+	0x06: "Not applicable",
+}
+
+const (
+	// CUnknownValue is used by the spec for unknown
+	// 16 bit measurements, capacities and speeds
+	CUnknownValue = 0x8000
+
+	CPowerSupplyTypeShift          = 10
+	CPowerSupplyTypeMask           = 0x000F
+	CPowerSupplyStatusShift        = 7
+	CPowerSupplyStatusMask         = 0x0007
+	CPowerSupplyVoltageRangeShift  = 3
+	CPowerSupplyVoltageRangeMask   = 0x000F
+	CPowerSupplyUnpluggedMask      = 0x0004
+	CPowerSupplyPresentMask        = 0x0002
+	CPowerSupplyHotReplaceableMask = 0x0001
+)
+
+type SystemPowerSupplyRefSpec struct {
+	PowerUnitGroup             byte   `struc:"byte"`
+	Location                   byte   `struc:"byte"`
+	DeviceName                 byte   `struc:"byte"`
+	Manufacturer               byte   `struc:"byte"`
+	SerialNumber               byte   `struc:"byte"`
+	AssetTag                   byte   `struc:"byte"`
+	ModelPartNumber            byte   `struc:"byte"`
+	RevisionLevel              byte   `struc:"byte"`
+	MaxPowerCapacity           uint16 `struc:"uint16,little"`
+	PowerSupplyCharacteristics uint16 `struc:"uint16,little"`
+	InputVoltageProbeHandle    uint16 `struc:"uint16,little"`
+	CoolingDeviceHandle        uint16 `struc:"uint16,little"`
+	InputCurrentProbeHandle    uint16 `struc:"uint16,little"`
+}
+
+type SystemPowerSupply struct {
+	Handle          uint16
+	PowerUnitGroup  byte
+	Location        string
+	DeviceName      string
+	Manufacturer    string
+	SerialNumber    string
+	AssetTag        string
+	ModelPartNumber string
+	RevisionLevel   string
+	// MaxPowerCapacity is specified in watts, 0 if unknown
+	MaxPowerCapacity        uint16
+	Type                    string
+	Status                  DeviceStatus
+	InputVoltageRangeSwitch string
+	Present                 bool
+	Unplugged               bool
+	HotReplaceable          bool
+	InputVoltageProbeHandle uint16
+	CoolingDeviceHandle     uint16
+	InputCurrentProbeHandle uint16
+}
+
+func SystemPowerSupplyFromSpec(ref *SystemPowerSupplyRefSpec, strings []string) *SystemPowerSupply {
+	characteristics := ref.PowerSupplyCharacteristics
+
+	info := &SystemPowerSupply{
+		PowerUnitGroup:          ref.PowerUnitGroup,
+		Location:                emptyStringOrValue(ref.Location, strings),
+		DeviceName:              emptyStringOrValue(ref.DeviceName, strings),
+		Manufacturer:            emptyStringOrValue(ref.Manufacturer, strings),
+		SerialNumber:            emptyStringOrValue(ref.SerialNumber, strings),
+		AssetTag:                emptyStringOrValue(ref.AssetTag, strings),
+		ModelPartNumber:         emptyStringOrValue(ref.ModelPartNumber, strings),
+		RevisionLevel:           emptyStringOrValue(ref.RevisionLevel, strings),
+		Type:                    powerSupplyTypes[(characteristics>>CPowerSupplyTypeShift)&CPowerSupplyTypeMask],
+		Status:                  deviceStatuses[byte((characteristics>>CPowerSupplyStatusShift)&CPowerSupplyStatusMask)],
+		InputVoltageRangeSwitch: powerSupplyInputVoltageRangeSwitches[(characteristics>>CPowerSupplyVoltageRangeShift)&CPowerSupplyVoltageRangeMask],
+		Present:                 characteristics&CPowerSupplyPresentMask != 0,
+		Unplugged:               characteristics&CPowerSupplyUnpluggedMask != 0,
+		HotReplaceable:          characteristics&CPowerSupplyHotReplaceableMask != 0,
+		InputVoltageProbeHandle: ref.InputVoltageProbeHandle,
+		CoolingDeviceHandle:     ref.CoolingDeviceHandle,
+		InputCurrentProbeHandle: ref.InputCurrentProbeHandle,
+	}
+
+	if ref.MaxPowerCapacity != CUnknownValue {
+		info.MaxPowerCapacity = ref.MaxPowerCapacity
+	}
+
+	return info
+}
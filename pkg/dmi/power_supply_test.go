@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSystemPowerSupply(t *testing.T) {
+	strings := []string{"PSU1", "PWS-1K62A-1R", "Supermicro", "P1K6A12345", "Rev 1.0"}
+
+	tests := []struct {
+		name     string
+		ref      *SystemPowerSupplyRefSpec
+		expected *SystemPowerSupply
+	}{
+		{
+			name: "present hot replaceable switching supply",
+			ref: &SystemPowerSupplyRefSpec{
+				PowerUnitGroup:   1,
+				Location:         1,
+				DeviceName:       2,
+				Manufacturer:     3,
+				SerialNumber:     4,
+				ModelPartNumber:  2,
+				RevisionLevel:    5,
+				MaxPowerCapacity: 1600,
+				// switching, OK, auto-switch, present, hot replaceable
+				PowerSupplyCharacteristics: 0x04<<CPowerSupplyTypeShift | 0x03<<CPowerSupplyStatusShift |
+					0x04<<CPowerSupplyVoltageRangeShift | CPowerSupplyPresentMask | CPowerSupplyHotReplaceableMask,
+				InputVoltageProbeHandle: 0xFFFF,
+				CoolingDeviceHandle:     0x1b00,
+				InputCurrentProbeHandle: 0xFFFF,
+			},
+			expected: &SystemPowerSupply{
+				Handle:                  0x2700,
+				PowerUnitGroup:          1,
+				Location:                "PSU1",
+				DeviceName:              "PWS-1K62A-1R",
+				Manufacturer:            "Supermicro",
+				SerialNumber:            "P1K6A12345",
+				ModelPartNumber:         "PWS-1K62A-1R",
+				RevisionLevel:           "Rev 1.0",
+				MaxPowerCapacity:        1600,
+				Type:                    "Switching",
+				Status:                  COKDeviceStatus,
+				InputVoltageRangeSwitch: "Auto-switch",
+				Present:                 true,
+				HotReplaceable:          true,
+				InputVoltageProbeHandle: 0xFFFF,
+				CoolingDeviceHandle:     0x1b00,
+				InputCurrentProbeHandle: 0xFFFF,
+			},
+		},
+		{
+			name: "unplugged supply with unknown capacity",
+			ref: &SystemPowerSupplyRefSpec{
+				Location:                   1,
+				MaxPowerCapacity:           CUnknownValue,
+				PowerSupplyCharacteristics: CPowerSupplyPresentMask | CPowerSupplyUnpluggedMask,
+			},
+			expected: &SystemPowerSupply{
+				Handle:    0x2700,
+				Location:  "PSU1",
+				Present:   true,
+				Unplugged: true,
+			},
+		},
+	}
+
+	svc := newTestSvc()
+	for _, test := range tests {
+		structure := packStructure(t, CSystemPowerSupplyHeaderType, 0x2700, test.ref, strings)
+		info, err := svc.parseSystemPowerSupply(structure, NewSMBIOSVersion(2, 3, 0))
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(info, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, info)
+		}
+	}
+}
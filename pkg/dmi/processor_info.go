@@ -0,0 +1,445 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ProcessorType string
+
+const (
+	COtherProcessorType   ProcessorType = "Other"
+	CUnknownProcessorType ProcessorType = "Unknown"
+	CCentralProcessorType ProcessorType = "Central Processor"
+	CMathProcessorType    ProcessorType = "Math Processor"
+	CDSPProcessorType     ProcessorType = "DSP Processor"
+	CVideoProcessorType   ProcessorType = "Video Processor"
+)
+
+var processorTypes = map[byte]ProcessorType{
+	0x01: COtherProcessorType,
+	0x02: CUnknownProcessorType,
+	0x03: CCentralProcessorType,
+	0x04: CMathProcessorType,
+	0x05: CDSPProcessorType,
+	0x06: CVideoProcessorType,
+}
+
+type ProcessorStatus string
+
+const (
+	CUnknownProcessorStatus        ProcessorStatus = "Unknown"
+	CEnabledProcessorStatus        ProcessorStatus = "Enabled"
+	CDisabledByUserProcessorStatus ProcessorStatus = "Disabled By User"
+	CDisabledByBIOSProcessorStatus ProcessorStatus = "Disabled By BIOS"
+	CIdleProcessorStatus           ProcessorStatus = "Idle"
+	COtherProcessorStatus          ProcessorStatus = "Other"
+)
+
+var processorStatuses = map[byte]ProcessorStatus{
+	0x00: CUnknownProcessorStatus,
+	0x01: CEnabledProcessorStatus,
+	0x02: CDisabledByUserProcessorStatus,
+	0x03: CDisabledByBIOSProcessorStatus,
+	0x04: CIdleProcessorStatus,
+	0x07: COtherProcessorStatus,
+}
+
+const (
+	CProcessorSocketPopulatedMask = 0x40
+	CProcessorStatusMask          = 0x07
+
+	// CProcessorVoltageModeMask bit in voltage field tells that
+	// the rest of the bits are a voltage multiplied by 10,
+	// otherwise they are flags of supported legacy voltages
+	CProcessorVoltageModeMask = 0x80
+
+	// CProcessorFamily2 in family field means that
+	// value is stored in extended family field
+	CProcessorFamily2 = 0xFE
+	// CProcessorCount2 in core and thread count fields means
+	// that values are stored in extended count fields
+	CProcessorCount2 = 0xFF
+
+	CNoCacheHandle = 0xFFFF
+)
+
+var CProcessorLegacyVoltages = []string{
+	"5.0 V",
+	"3.3 V",
+	"2.9 V",
+}
+
+// CProcessorCharacteristics are bits of characteristics field starting from bit 0
+var CProcessorCharacteristics = []string{
+	"Reserved",
+	"Unknown",
+	"64-bit capable",
+	"Multi-Core",
+	"Hardware Thread",
+	"Execute Protection",
+	"Enhanced Virtualization",
+	"Power/Performance Control",
+	"128-bit Capable",
+	"Arm64 SoC ID",
+}
+
+var processorFamilies = map[uint16]string{
+	0x01:  "Other",
+	0x02:  "Unknown",
+	0x0B:  "Pentium",
+	0x0C:  "Pentium Pro",
+	0x0D:  "Pentium II",
+	0x0E:  "Pentium MMX",
+	0x0F:  "Celeron",
+	0x10:  "Pentium II Xeon",
+	0x11:  "Pentium III",
+	0x14:  "Celeron M",
+	0x15:  "Pentium 4 HT",
+	0x16:  "Intel",
+	0x18:  "Duron",
+	0x1D:  "Athlon",
+	0x28:  "Core Duo",
+	0x29:  "Core Duo Mobile",
+	0x2A:  "Core Solo Mobile",
+	0x2B:  "Atom",
+	0x2C:  "Core M",
+	0x2D:  "Core m3",
+	0x2E:  "Core m5",
+	0x2F:  "Core m7",
+	0x6B:  "Zen",
+	0x82:  "Itanium",
+	0x83:  "Athlon 64",
+	0x84:  "Opteron",
+	0x85:  "Sempron",
+	0x86:  "Turion 64",
+	0x87:  "Dual-Core Opteron",
+	0x88:  "Athlon 64 X2",
+	0x8A:  "Quad-Core Opteron",
+	0x8B:  "Third-Generation Opteron",
+	0x8C:  "Phenom FX",
+	0x8D:  "Phenom X4",
+	0x8E:  "Phenom X2",
+	0x8F:  "Athlon X2",
+	0xB0:  "Pentium III Xeon",
+	0xB2:  "Pentium 4",
+	0xB3:  "Xeon",
+	0xB5:  "Xeon MP",
+	0xB6:  "Athlon XP",
+	0xB7:  "Athlon MP",
+	0xB8:  "Itanium 2",
+	0xB9:  "Pentium M",
+	0xBA:  "Celeron D",
+	0xBB:  "Pentium D",
+	0xBC:  "Pentium EE",
+	0xBD:  "Core Solo",
+	0xBF:  "Core 2 Duo",
+	0xC0:  "Core 2 Solo",
+	0xC1:  "Core 2 Extreme",
+	0xC2:  "Core 2 Quad",
+	0xC6:  "Core i7",
+	0xC7:  "Dual-Core Celeron",
+	0xC8:  "IBM390",
+	0xC9:  "G4",
+	0xCA:  "G5",
+	0xCB:  "ESA/390 G6",
+	0xCC:  "z/Architecture",
+	0xCD:  "Core i5",
+	0xCE:  "Core i3",
+	0xCF:  "Core i9",
+	0x100: "ARMv7",
+	0x101: "ARMv8",
+	0x102: "ARMv9",
+	0x118: "ARM",
+	0x119: "StrongARM",
+	0x200: "RV32",
+	0x201: "RV64",
+	0x202: "RV128",
+	0x258: "LoongArch",
+}
+
+var processorUpgrades = map[byte]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Daughter Board",
+	0x04: "ZIF Socket",
+	0x05: "Replaceable Piggy Back",
+	0x06: "None",
+	0x07: "LIF Socket",
+	0x08: "Slot 1",
+	0x09: "Slot 2",
+	0x0A: "370-pin Socket",
+	0x0B: "Slot A",
+	0x0C: "Slot M",
+	0x0D: "Socket 423",
+	0x0E: "Socket A (Socket 462)",
+	0x0F: "Socket 478",
+	0x10: "Socket 754",
+	0x11: "Socket 940",
+	0x12: "Socket 939",
+	0x13: "Socket mPGA604",
+	0x14: "Socket LGA771",
+	0x15: "Socket LGA775",
+	0x16: "Socket S1",
+	0x17: "Socket AM2",
+	0x18: "Socket F (1207)",
+	0x19: "Socket LGA1366",
+	0x1A: "Socket G34",
+	0x1B: "Socket AM3",
+	0x1C: "Socket C32",
+	0x1D: "Socket LGA1156",
+	0x1E: "Socket LGA1567",
+	0x1F: "Socket PGA988A",
+	0x20: "Socket BGA1288",
+	0x21: "Socket rPGA988B",
+	0x22: "Socket BGA1023",
+	0x23: "Socket BGA1224",
+	0x24: "Socket LGA1155",
+	0x25: "Socket LGA1356",
+	0x26: "Socket LGA2011",
+	0x27: "Socket FS1",
+	0x28: "Socket FS2",
+	0x29: "Socket FM1",
+	0x2A: "Socket FM2",
+	0x2B: "Socket LGA2011-3",
+	0x2C: "Socket LGA1356-3",
+	0x2D: "Socket LGA1150",
+	0x2E: "Socket BGA1168",
+	0x2F: "Socket BGA1234",
+	0x30: "Socket BGA1364",
+	0x31: "Socket AM4",
+	0x32: "Socket LGA1151",
+	0x33: "Socket BGA1356",
+	0x34: "Socket BGA1440",
+	0x35: "Socket BGA1515",
+	0x36: "Socket LGA3647-1",
+	0x37: "Socket SP3",
+	0x38: "Socket SP3r2",
+	0x39: "Socket LGA2066",
+	0x3A: "Socket BGA1392",
+	0x3B: "Socket BGA1510",
+	0x3C: "Socket BGA1528",
+	0x3D: "Socket LGA4189",
+	0x3E: "Socket LGA1200",
+	0x3F: "Socket LGA4677",
+	0x40: "Socket LGA1700",
+	0x41: "Socket BGA1744",
+	0x42: "Socket BGA1781",
+	0x43: "Socket BGA1211",
+	0x44: "Socket BGA2422",
+	0x45: "Socket LGA1211",
+	0x46: "Socket LGA2422",
+	0x47: "Socket LGA5773",
+	0x48: "Socket BGA5773",
+	0x49: "Socket AM5",
+	0x4A: "Socket SP5",
+	0x4B: "Socket SP6",
+	0x4C: "Socket BGA883",
+	0x4D: "Socket BGA1190",
+	0x4E: "Socket BGA4129",
+	0x4F: "Socket LGA4710",
+	0x50: "Socket LGA7529",
+}
+
+type ProcessorInformationRefSpec20 struct {
+	SocketDesignation     byte   `struc:"byte"`
+	ProcessorType         byte   `struc:"byte"`
+	ProcessorFamily       byte   `struc:"byte"`
+	ProcessorManufacturer byte   `struc:"byte"`
+	ProcessorID           []byte `struc:"[8]byte"`
+	ProcessorVersion      byte   `struc:"byte"`
+	Voltage               byte   `struc:"byte"`
+	ExternalClock         uint16 `struc:"uint16,little"`
+	MaxSpeed              uint16 `struc:"uint16,little"`
+	CurrentSpeed          uint16 `struc:"uint16,little"`
+	Status                byte   `struc:"byte"`
+	ProcessorUpgrade      byte   `struc:"byte"`
+}
+
+type ProcessorInformationRefSpec21 struct {
+	ProcessorInformationRefSpec20
+	L1CacheHandle uint16 `struc:"uint16,little"`
+	L2CacheHandle uint16 `struc:"uint16,little"`
+	L3CacheHandle uint16 `struc:"uint16,little"`
+}
+
+type ProcessorInformationRefSpec23 struct {
+	ProcessorInformationRefSpec21
+	SerialNumber byte `struc:"byte"`
+	AssetTag     byte `struc:"byte"`
+	PartNumber   byte `struc:"byte"`
+}
+
+type ProcessorInformationRefSpec25 struct {
+	ProcessorInformationRefSpec23
+	CoreCount                byte   `struc:"byte"`
+	CoreEnabled              byte   `struc:"byte"`
+	ThreadCount              byte   `struc:"byte"`
+	ProcessorCharacteristics uint16 `struc:"uint16,little"`
+}
+
+type ProcessorInformationRefSpec26 struct {
+	ProcessorInformationRefSpec25
+	ProcessorFamily2 uint16 `struc:"uint16,little"`
+}
+
+type ProcessorInformationRefSpec30 struct {
+	ProcessorInformationRefSpec26
+	CoreCount2   uint16 `struc:"uint16,little"`
+	CoreEnabled2 uint16 `struc:"uint16,little"`
+	ThreadCount2 uint16 `struc:"uint16,little"`
+}
+
+type ProcessorInformationRefSpec36 struct {
+	ProcessorInformationRefSpec30
+	ThreadEnabled uint16 `struc:"uint16,little"`
+	SocketType    byte   `struc:"byte"`
+}
+
+type ProcessorInformation struct {
+	Handle            uint16
+	SocketDesignation string
+	Type              ProcessorType
+	Family            string
+	Manufacturer      string
+	ID                string
+	Version           string
+	Voltage           string
+	// ExternalClock, MaxSpeed and CurrentSpeed are specified in MHz
+	ExternalClock   uint16
+	MaxSpeed        uint16
+	CurrentSpeed    uint16
+	SocketPopulated bool
+	Status          ProcessorStatus
+	Upgrade         string
+	L1CacheHandle   uint16
+	L2CacheHandle   uint16
+	L3CacheHandle   uint16
+	SerialNumber    string
+	AssetTag        string
+	PartNumber      string
+	CoreCount       uint16
+	CoreEnabled     uint16
+	ThreadCount     uint16
+	ThreadEnabled   uint16
+	Characteristics []string
+	SocketType      string
+}
+
+func ProcessorInformationFromSpec20(ref *ProcessorInformationRefSpec20, strs []string) *ProcessorInformation {
+	info := &ProcessorInformation{
+		SocketDesignation: emptyStringOrValue(ref.SocketDesignation, strs),
+		Type:              processorTypes[ref.ProcessorType],
+		Family:            processorFamilies[uint16(ref.ProcessorFamily)],
+		Manufacturer:      emptyStringOrValue(ref.ProcessorManufacturer, strs),
+		Version:           strings.TrimSpace(emptyStringOrValue(ref.ProcessorVersion, strs)),
+		ExternalClock:     ref.ExternalClock,
+		MaxSpeed:          ref.MaxSpeed,
+		CurrentSpeed:      ref.CurrentSpeed,
+		SocketPopulated:   ref.Status&CProcessorSocketPopulatedMask != 0,
+		Status:            processorStatuses[ref.Status&CProcessorStatusMask],
+		Upgrade:           processorUpgrades[ref.ProcessorUpgrade],
+		L1CacheHandle:     CNoCacheHandle,
+		L2CacheHandle:     CNoCacheHandle,
+		L3CacheHandle:     CNoCacheHandle,
+	}
+
+	// processor ID is printed byte by byte, the same way dmidecode does
+	id := make([]string, len(ref.ProcessorID))
+	for i, b := range ref.ProcessorID {
+		id[i] = fmt.Sprintf("%02X", b)
+	}
+	info.ID = strings.Join(id, " ")
+
+	if ref.Voltage&CProcessorVoltageModeMask != 0 {
+		voltage := ref.Voltage &^ CProcessorVoltageModeMask
+		info.Voltage = fmt.Sprintf("%d.%d V", voltage/10, voltage%10)
+	} else {
+		voltages := make([]string, 0)
+		for i, v := range CProcessorLegacyVoltages {
+			if ref.Voltage&byte(1<<i) != 0 {
+				voltages = append(voltages, v)
+			}
+		}
+		info.Voltage = strings.Join(voltages, " ")
+	}
+
+	return info
+}
+
+func ProcessorInformationFromSpec21(ref *ProcessorInformationRefSpec21, strs []string) *ProcessorInformation {
+	info := ProcessorInformationFromSpec20(&ref.ProcessorInformationRefSpec20, strs)
+
+	info.L1CacheHandle = ref.L1CacheHandle
+	info.L2CacheHandle = ref.L2CacheHandle
+	info.L3CacheHandle = ref.L3CacheHandle
+
+	return info
+}
+
+func ProcessorInformationFromSpec23(ref *ProcessorInformationRefSpec23, strs []string) *ProcessorInformation {
+	info := ProcessorInformationFromSpec21(&ref.ProcessorInformationRefSpec21, strs)
+
+	info.SerialNumber = strings.TrimSpace(emptyStringOrValue(ref.SerialNumber, strs))
+	info.AssetTag = strings.TrimSpace(emptyStringOrValue(ref.AssetTag, strs))
+	info.PartNumber = strings.TrimSpace(emptyStringOrValue(ref.PartNumber, strs))
+
+	return info
+}
+
+func ProcessorInformationFromSpec25(ref *ProcessorInformationRefSpec25, strs []string) *ProcessorInformation {
+	info := ProcessorInformationFromSpec23(&ref.ProcessorInformationRefSpec23, strs)
+
+	info.CoreCount = uint16(ref.CoreCount)
+	info.CoreEnabled = uint16(ref.CoreEnabled)
+	info.ThreadCount = uint16(ref.ThreadCount)
+
+	info.Characteristics = make([]string, 0)
+	for i, characteristic := range CProcessorCharacteristics {
+		idx := uint16(1 << i)
+		enabled := ref.ProcessorCharacteristics & idx
+		if enabled != 0 {
+			info.Characteristics = append(info.Characteristics, characteristic)
+		}
+	}
+
+	return info
+}
+
+func ProcessorInformationFromSpec26(ref *ProcessorInformationRefSpec26, strs []string) *ProcessorInformation {
+	info := ProcessorInformationFromSpec25(&ref.ProcessorInformationRefSpec25, strs)
+
+	if ref.ProcessorFamily == CProcessorFamily2 {
+		info.Family = processorFamilies[ref.ProcessorFamily2]
+	}
+
+	return info
+}
+
+func ProcessorInformationFromSpec30(ref *ProcessorInformationRefSpec30, strs []string) *ProcessorInformation {
+	info := ProcessorInformationFromSpec26(&ref.ProcessorInformationRefSpec26, strs)
+
+	if ref.CoreCount == CProcessorCount2 {
+		info.CoreCount = ref.CoreCount2
+	}
+	if ref.CoreEnabled == CProcessorCount2 {
+		info.CoreEnabled = ref.CoreEnabled2
+	}
+	if ref.ThreadCount == CProcessorCount2 {
+		info.ThreadCount = ref.ThreadCount2
+	}
+
+	return info
+}
+
+func ProcessorInformationFromSpec36(ref *ProcessorInformationRefSpec36, strs []string) *ProcessorInformation {
+	info := ProcessorInformationFromSpec30(&ref.ProcessorInformationRefSpec30, strs)
+
+	info.ThreadEnabled = ref.ThreadEnabled
+	info.SocketType = emptyStringOrValue(ref.SocketType, strs)
+
+	return info
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProcessorInformation(t *testing.T) {
+	strings := []string{"CPU0", "Intel(R) Corporation", "Intel(R) Xeon(R) Gold 6338 CPU @ 2.00GHz  ", "SN1", "PN1", "LGA4189"}
+	base := ProcessorInformationRefSpec20{
+		SocketDesignation:     1,
+		ProcessorType:         0x03,
+		ProcessorFamily:       CProcessorFamily2,
+		ProcessorManufacturer: 2,
+		ProcessorID:           []byte{0xA6, 0x06, 0x06, 0x00, 0xFF, 0xFB, 0xEB, 0xBF},
+		ProcessorVersion:      3,
+		// voltage mode, 1.6 V
+		Voltage:       0x80 | 16,
+		ExternalClock: 100,
+		MaxSpeed:      4000,
+		CurrentSpeed:  2000,
+		// populated and enabled
+		Status:           0x41,
+		ProcessorUpgrade: 0x3D,
+	}
+
+	tests := []struct {
+		name     string
+		version  *SMBIOSVersion
+		ref      interface{}
+		expected *ProcessorInformation
+	}{
+		{
+			name:    "2.0 legacy voltages without caches",
+			version: NewSMBIOSVersion(2, 0, 0),
+			ref: func() *ProcessorInformationRefSpec20 {
+				ref := base
+				ref.ProcessorFamily = 0xB3
+				// 5.0 and 3.3 V
+				ref.Voltage = 0x03
+				ref.Status = 0x03
+				return &ref
+			}(),
+			expected: &ProcessorInformation{
+				Handle:            0x0400,
+				SocketDesignation: "CPU0",
+				Type:              CCentralProcessorType,
+				Family:            "Xeon",
+				Manufacturer:      "Intel(R) Corporation",
+				ID:                "A6 06 06 00 FF FB EB BF",
+				Version:           "Intel(R) Xeon(R) Gold 6338 CPU @ 2.00GHz",
+				Voltage:           "5.0 V 3.3 V",
+				ExternalClock:     100,
+				MaxSpeed:          4000,
+				CurrentSpeed:      2000,
+				Status:            CDisabledByBIOSProcessorStatus,
+				Upgrade:           "Socket LGA4189",
+				L1CacheHandle:     CNoCacheHandle,
+				L2CacheHandle:     CNoCacheHandle,
+				L3CacheHandle:     CNoCacheHandle,
+			},
+		},
+		{
+			name:    "3.6 extended family, counts and socket type",
+			version: NewSMBIOSVersion(3, 6, 0),
+			ref: func() *ProcessorInformationRefSpec36 {
+				ref := &ProcessorInformationRefSpec36{}
+				ref.ProcessorInformationRefSpec20 = base
+				ref.L1CacheHandle = 0x0700
+				ref.L2CacheHandle = 0x0701
+				ref.L3CacheHandle = 0x0702
+				ref.SerialNumber = 4
+				ref.PartNumber = 5
+				ref.CoreCount = CProcessorCount2
+				ref.CoreEnabled = CProcessorCount2
+				ref.ThreadCount = CProcessorCount2
+				// 64-bit capable, multi-core and hardware thread
+				ref.ProcessorCharacteristics = 1<<2 | 1<<3 | 1<<4
+				ref.ProcessorFamily2 = 0x101
+				ref.CoreCount2 = 256
+				ref.CoreEnabled2 = 256
+				ref.ThreadCount2 = 512
+				ref.ThreadEnabled = 512
+				ref.SocketType = 6
+				return ref
+			}(),
+			expected: &ProcessorInformation{
+				Handle:            0x0400,
+				SocketDesignation: "CPU0",
+				Type:              CCentralProcessorType,
+				Family:            "ARMv8",
+				Manufacturer:      "Intel(R) Corporation",
+				ID:                "A6 06 06 00 FF FB EB BF",
+				Version:           "Intel(R) Xeon(R) Gold 6338 CPU @ 2.00GHz",
+				Voltage:           "1.6 V",
+				ExternalClock:     100,
+				MaxSpeed:          4000,
+				CurrentSpeed:      2000,
+				SocketPopulated:   true,
+				Status:            CEnabledProcessorStatus,
+				Upgrade:           "Socket LGA4189",
+				L1CacheHandle:     0x0700,
+				L2CacheHandle:     0x0701,
+				L3CacheHandle:     0x0702,
+				SerialNumber:      "SN1",
+				PartNumber:        "PN1",
+				CoreCount:         256,
+				CoreEnabled:       256,
+				ThreadCount:       512,
+				ThreadEnabled:     512,
+				Characteristics:   []string{"64-bit capable", "Multi-Core", "Hardware Thread"},
+				SocketType:        "LGA4189",
+			},
+		},
+	}
+
+	svc := newTestSvc()
+	for _, test := range tests {
+		structure := packStructure(t, CProcessorInformationHeaderType, 0x0400, test.ref, strings)
+		info, err := svc.parseProcessorInformation(structure, test.version)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(info, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, info)
+		}
+	}
+}
+
+func TestGetPopulatedSockets(t *testing.T) {
+	dmi := &DMI{
+		Processors: []ProcessorInformation{
+			{SocketDesignation: "CPU0", Type: CCentralProcessorType, SocketPopulated: true},
+			{SocketDesignation: "CPU1", Type: CCentralProcessorType},
+			{SocketDesignation: "GPU", Type: CVideoProcessorType, SocketPopulated: true},
+		},
+	}
+
+	sockets := dmi.GetPopulatedSockets()
+	if len(sockets) != 1 || sockets[0].SocketDesignation != "CPU0" {
+		t.Errorf("expected only CPU0 to be populated, got %+v", sockets)
+	}
+}
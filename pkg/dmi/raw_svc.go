@@ -29,6 +29,9 @@ func sysfsStream(entryPoint, dmi string) (io.ReadCloser, smbios.EntryPoint, erro
 func memoryStream(rs io.ReadSeeker, startAddr, endAddr int) (io.ReadCloser, smbios.EntryPoint, error)
 
 type RawSvc struct {
+	// dumpPath is a path to `dmidecode --dump-bin` file,
+	// if set it is used instead of sysfs and /dev/mem
+	dumpPath             string
 	devMemPath           string
 	sysDMIPath           string
 	sysDMIEntryPointPath string
@@ -42,10 +45,21 @@ func NewRawSvc(basePath string) *RawSvc {
 	}
 }
 
+// NewDumpRawSvc creates raw svc reading SMBIOS data from the dump file
+func NewDumpRawSvc(dumpPath string) *RawSvc {
+	return &RawSvc{
+		dumpPath: dumpPath,
+	}
+}
+
 func (s *RawSvc) GetRaw() (*Raw, error) {
 	var stream io.ReadCloser
 	var ep smbios.EntryPoint
 
+	if s.dumpPath != "" {
+		return s.getDumpRaw()
+	}
+
 	_, err := os.Stat(s.sysDMIEntryPointPath)
 	switch {
 	case err == nil:
@@ -73,3 +87,42 @@ func (s *RawSvc) GetRaw() (*Raw, error) {
 		EntryPoint: ep,
 	}, nil
 }
+
+func (s *RawSvc) getDumpRaw() (*Raw, error) {
+	dump, err := os.Open(s.dumpPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open dump %s", s.dumpPath)
+	}
+	defer dump.Close()
+
+	stream, ep, err := ReadDump(dump)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read dump %s", s.dumpPath)
+	}
+
+	return &Raw{
+		Stream:     stream,
+		EntryPoint: ep,
+	}, nil
+}
+
+// Dump writes SMBIOS entry point and table to the file at dumpPath
+func (s *RawSvc) Dump(dumpPath string) error {
+	raw, err := s.GetRaw()
+	if err != nil {
+		return errors.Wrap(err, "unable to get raw DMI data")
+	}
+	defer raw.Stream.Close()
+
+	dump, err := os.Create(dumpPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create dump %s", dumpPath)
+	}
+	defer dump.Close()
+
+	if err := WriteDump(dump, raw); err != nil {
+		return errors.Wrapf(err, "unable to write dump %s", dumpPath)
+	}
+
+	return nil
+}
@@ -14,21 +14,34 @@ import (
 )
 
 type Svc struct {
-	printer   *printer.Svc
-	RawDMISvc *RawSvc
+	printer       *printer.Svc
+	RawDMISvc     *RawSvc
+	deviceTreeSvc *DeviceTreeSvc
 }
 
-func NewSvc(printer *printer.Svc, rawDMISvc *RawSvc) *Svc {
+// NewSvc creates DMI svc, device tree svc is optional
+// and used if there is no SMBIOS on the machine
+func NewSvc(printer *printer.Svc, rawDMISvc *RawSvc, deviceTreeSvc *DeviceTreeSvc) *Svc {
 	return &Svc{
-		printer:   printer,
-		RawDMISvc: rawDMISvc,
+		printer:       printer,
+		RawDMISvc:     rawDMISvc,
+		deviceTreeSvc: deviceTreeSvc,
 	}
 }
 
 func (s *Svc) GetData() (*DMI, error) {
 	rawDmi, err := s.RawDMISvc.GetRaw()
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to get SMBIOS stream")
+		if s.deviceTreeSvc == nil {
+			return nil, errors.Wrap(err, "unable to get SMBIOS stream")
+		}
+
+		data, dtErr := s.deviceTreeSvc.GetData()
+		if dtErr != nil {
+			s.printer.VErr(errors.Wrap(dtErr, "unable to get device tree data"))
+			return nil, errors.Wrap(err, "unable to get SMBIOS stream")
+		}
+		return data, nil
 	}
 
 	defer rawDmi.Stream.Close()
@@ -41,9 +54,7 @@ func (s *Svc) GetData() (*DMI, error) {
 
 	version := NewSMBIOSVersion(rawDmi.EntryPoint.Version())
 
-	dmi := &DMI{
-		Version: version,
-	}
+	dmi := newDMI(version)
 
 	for _, structure := range structures {
 		switch structure.Header.Type {
@@ -69,6 +80,97 @@ func (s *Svc) GetData() (*DMI, error) {
 				s.printer.VErr(errors.Wrap(err, "unable to parse system info"))
 			}
 			dmi.SystemInformation = systemInfo
+		case CChassisInformationHeaderType:
+			chassisInfo, err := s.parseChassisInformation(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse chassis info"))
+				continue
+			}
+			dmi.ChassisInformation = append(dmi.ChassisInformation, *chassisInfo)
+		case CProcessorInformationHeaderType:
+			processorInfo, err := s.parseProcessorInformation(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse processor info"))
+				continue
+			}
+			dmi.Processors = append(dmi.Processors, *processorInfo)
+		case CCacheInformationHeaderType:
+			cacheInfo, err := s.parseCacheInformation(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse cache info"))
+				continue
+			}
+			dmi.Caches = append(dmi.Caches, *cacheInfo)
+		case CSystemSlotHeaderType:
+			slot, err := s.parseSystemSlot(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse system slot"))
+				continue
+			}
+			dmi.SystemSlots = append(dmi.SystemSlots, *slot)
+		case COnboardDeviceHeaderType:
+			onboardDevice, err := s.parseOnboardDevice(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse onboard device"))
+				continue
+			}
+			dmi.OnboardDevices = append(dmi.OnboardDevices, *onboardDevice)
+		case COEMStringsHeaderType:
+			oemStrings, err := s.parseStringList(structure)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse OEM strings"))
+				continue
+			}
+			dmi.OEMStrings = append(dmi.OEMStrings, oemStrings...)
+		case CSystemConfigurationHeaderType:
+			options, err := s.parseStringList(structure)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse system configuration options"))
+				continue
+			}
+			dmi.SystemConfigurationOptions = append(dmi.SystemConfigurationOptions, options...)
+		case CPhysicalMemoryArrayHeaderType:
+			memoryArray, err := s.parsePhysicalMemoryArray(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse physical memory array"))
+				continue
+			}
+			dmi.MemoryArrays = append(dmi.MemoryArrays, *memoryArray)
+		case CMemoryDeviceHeaderType:
+			memoryDevice, err := s.parseMemoryDevice(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse memory device"))
+				continue
+			}
+			dmi.MemoryDevices = append(dmi.MemoryDevices, *memoryDevice)
+		case CCoolingDeviceHeaderType:
+			coolingDevice, err := s.parseCoolingDevice(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse cooling device"))
+				continue
+			}
+			dmi.CoolingDevices = append(dmi.CoolingDevices, *coolingDevice)
+		case CTemperatureProbeHeaderType:
+			probe, err := s.parseTemperatureProbe(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse temperature probe"))
+				continue
+			}
+			dmi.TemperatureProbes = append(dmi.TemperatureProbes, *probe)
+		case CSystemPowerSupplyHeaderType:
+			powerSupply, err := s.parseSystemPowerSupply(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse system power supply"))
+				continue
+			}
+			dmi.PowerSupplies = append(dmi.PowerSupplies, *powerSupply)
+		case CHostInterfaceHeaderType:
+			hostInterface, err := s.parseHostInterface(structure, version)
+			if err != nil {
+				s.printer.VErr(errors.Wrap(err, "unable to parse management controller host interface"))
+				continue
+			}
+			dmi.HostInterfaces = append(dmi.HostInterfaces, *hostInterface)
 		}
 	}
 
@@ -91,26 +193,28 @@ func (s *Svc) parseBiosInformation(structure *smbios.Structure, version *SMBIOSV
 		return &BIOSInformation{}, nil
 	}
 
+	// Extension byte array has variable length, so layout is chosen
+	// by structure length as well, since firmware often reports newer
+	// SMBIOS version than the one structures are actually built by
+
 	// 3.1+
-	if version.GreaterOrEqual(&SMBIOSVersion{3, 1, 0}) {
+	if version.GreaterOrEqual(&SMBIOSVersion{3, 1, 0}) && structure.Header.Length >= CBIOSInformationLength31 {
 		ref := &BIOSInformationRefSpec31{}
-		// Subtracting base structure offset (12b)
 		// Subtracting 4 bytes going after extension byte array
 		// Subtracting 2 bytes going after extension byte array
-		ref.CharacteristicsExtensionsSize = structure.Header.Length - 0x12 - 0x4 - 0x2
-		if err := struc.Unpack(bytes.NewReader(structure.Formatted), ref); err != nil {
+		ref.CharacteristicsExtensionsSize = biosCharacteristicsExtensionsSize(structure, 0x4+0x2)
+		if err := unpackFormatted(structure, ref); err != nil {
 			return nil, errors.Wrap(err, "unable to unpack structure")
 		}
 		return BIOSInformationFromSpec31(ref, structure.Strings), nil
 	}
 
 	// 2.4+
-	if version.GreaterOrEqual(&SMBIOSVersion{2, 4, 0}) {
+	if version.GreaterOrEqual(&SMBIOSVersion{2, 4, 0}) && structure.Header.Length >= CBIOSInformationLength24 {
 		ref := &BIOSInformationRefSpec24{}
-		// Subtracting base structure offset (12b)
 		// Subtracting 4 bytes going after extension byte array
-		ref.CharacteristicsExtensionsSize = structure.Header.Length - 0x12 - 0x4
-		if err := struc.Unpack(bytes.NewReader(structure.Formatted), ref); err != nil {
+		ref.CharacteristicsExtensionsSize = biosCharacteristicsExtensionsSize(structure, 0x4)
+		if err := unpackFormatted(structure, ref); err != nil {
 			return nil, errors.Wrap(err, "unable to unpack structure")
 		}
 		return BIOSInformationFromSpec24(ref, structure.Strings), nil
@@ -118,14 +222,25 @@ func (s *Svc) parseBiosInformation(structure *smbios.Structure, version *SMBIOSV
 
 	// 2.0+
 	ref := &BIOSInformationRefSpec20{}
-	// Subtracting base structure offset (12b)
-	ref.CharacteristicsExtensionsSize = structure.Header.Length - 0x12
-	if err := struc.Unpack(bytes.NewReader(structure.Formatted), ref); err != nil {
+	ref.CharacteristicsExtensionsSize = biosCharacteristicsExtensionsSize(structure, 0)
+	if err := unpackFormatted(structure, ref); err != nil {
 		return nil, errors.Wrap(err, "unable to unpack structure")
 	}
 	return BIOSInformationFromSpec20(ref, structure.Strings), nil
 }
 
+// biosCharacteristicsExtensionsSize returns size of extension byte array,
+// which takes the rest of the structure except fields going after it.
+// Structure may be shorter than declared SMBIOS version requires,
+// missing fields are treated as zeroes then.
+func biosCharacteristicsExtensionsSize(structure *smbios.Structure, trailing int) byte {
+	size := int(structure.Header.Length) - CBIOSCharacteristicsExtensionsOffset - trailing
+	if size < 0 {
+		return 0
+	}
+	return byte(size)
+}
+
 func (s *Svc) parseSystemInformation(structure *smbios.Structure, version *SMBIOSVersion) (*SystemInformation, error) {
 	// Spec contains info only for 2.0+
 	if version.Lesser(&SMBIOSVersion{2, 0, 0}) {
@@ -157,3 +272,380 @@ func (s *Svc) parseSystemInformation(structure *smbios.Structure, version *SMBIO
 	}
 	return SystemInformationFromSpec20(ref, structure.Strings), nil
 }
+
+func (s *Svc) parsePhysicalMemoryArray(structure *smbios.Structure, version *SMBIOSVersion) (*PhysicalMemoryArray, error) {
+	// Spec contains info only for 2.1+
+	if version.Lesser(&SMBIOSVersion{2, 1, 0}) {
+		return &PhysicalMemoryArray{Handle: structure.Header.Handle}, nil
+	}
+
+	var array *PhysicalMemoryArray
+
+	if version.GreaterOrEqual(&SMBIOSVersion{2, 7, 0}) {
+		ref := &PhysicalMemoryArrayRefSpec27{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		array = PhysicalMemoryArrayFromSpec27(ref)
+	} else {
+		ref := &PhysicalMemoryArrayRefSpec21{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		array = PhysicalMemoryArrayFromSpec21(ref)
+	}
+
+	array.Handle = structure.Header.Handle
+
+	return array, nil
+}
+
+func (s *Svc) parseMemoryDevice(structure *smbios.Structure, version *SMBIOSVersion) (*MemoryDevice, error) {
+	// Spec contains info only for 2.1+
+	if version.Lesser(&SMBIOSVersion{2, 1, 0}) {
+		return &MemoryDevice{Handle: structure.Header.Handle}, nil
+	}
+
+	var device *MemoryDevice
+
+	switch {
+	case version.GreaterOrEqual(&SMBIOSVersion{3, 3, 0}):
+		ref := &MemoryDeviceRefSpec33{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		device = MemoryDeviceFromSpec33(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{3, 2, 0}):
+		ref := &MemoryDeviceRefSpec32{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		device = MemoryDeviceFromSpec32(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 8, 0}):
+		ref := &MemoryDeviceRefSpec28{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		device = MemoryDeviceFromSpec28(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 7, 0}):
+		ref := &MemoryDeviceRefSpec27{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		device = MemoryDeviceFromSpec27(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 6, 0}):
+		ref := &MemoryDeviceRefSpec26{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		device = MemoryDeviceFromSpec26(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 3, 0}):
+		ref := &MemoryDeviceRefSpec23{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		device = MemoryDeviceFromSpec23(ref, structure.Strings)
+	default:
+		ref := &MemoryDeviceRefSpec21{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		device = MemoryDeviceFromSpec21(ref, structure.Strings)
+	}
+
+	device.Handle = structure.Header.Handle
+
+	return device, nil
+}
+
+func (s *Svc) parseChassisInformation(structure *smbios.Structure, version *SMBIOSVersion) (*ChassisInformation, error) {
+	// Spec contains info only for 2.0+
+	if version.Lesser(&SMBIOSVersion{2, 0, 0}) {
+		return &ChassisInformation{Handle: structure.Header.Handle}, nil
+	}
+
+	var info *ChassisInformation
+
+	switch {
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 3, 0}):
+		ref := &ChassisInformationRefSpec23{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ChassisInformationFromSpec23(ref, structure.Strings)
+
+		// 2.7+
+		// SKU number goes right after the contained elements
+		if version.GreaterOrEqual(&SMBIOSVersion{2, 7, 0}) {
+			// Subtracting 4 bytes of the header from the contained elements offset (15h)
+			skuOffset := 0x15 - 0x4 + int(ref.ContainedElementCount)*int(ref.ContainedElementRecordLength)
+			if skuOffset < len(structure.Formatted) {
+				info.SKUNumber = emptyStringOrValue(structure.Formatted[skuOffset], structure.Strings)
+			}
+		}
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 1, 0}):
+		ref := &ChassisInformationRefSpec21{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ChassisInformationFromSpec21(ref, structure.Strings)
+	default:
+		ref := &ChassisInformationRefSpec20{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ChassisInformationFromSpec20(ref, structure.Strings)
+	}
+
+	info.Handle = structure.Header.Handle
+
+	return info, nil
+}
+
+func (s *Svc) parseProcessorInformation(structure *smbios.Structure, version *SMBIOSVersion) (*ProcessorInformation, error) {
+	// Spec contains info only for 2.0+
+	if version.Lesser(&SMBIOSVersion{2, 0, 0}) {
+		return &ProcessorInformation{Handle: structure.Header.Handle}, nil
+	}
+
+	var info *ProcessorInformation
+
+	switch {
+	case version.GreaterOrEqual(&SMBIOSVersion{3, 6, 0}):
+		ref := &ProcessorInformationRefSpec36{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ProcessorInformationFromSpec36(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{3, 0, 0}):
+		ref := &ProcessorInformationRefSpec30{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ProcessorInformationFromSpec30(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 6, 0}):
+		ref := &ProcessorInformationRefSpec26{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ProcessorInformationFromSpec26(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 5, 0}):
+		ref := &ProcessorInformationRefSpec25{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ProcessorInformationFromSpec25(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 3, 0}):
+		ref := &ProcessorInformationRefSpec23{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ProcessorInformationFromSpec23(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 1, 0}):
+		ref := &ProcessorInformationRefSpec21{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ProcessorInformationFromSpec21(ref, structure.Strings)
+	default:
+		ref := &ProcessorInformationRefSpec20{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = ProcessorInformationFromSpec20(ref, structure.Strings)
+	}
+
+	info.Handle = structure.Header.Handle
+
+	return info, nil
+}
+
+func (s *Svc) parseCacheInformation(structure *smbios.Structure, version *SMBIOSVersion) (*CacheInformation, error) {
+	// Spec contains info only for 2.0+
+	if version.Lesser(&SMBIOSVersion{2, 0, 0}) {
+		return &CacheInformation{Handle: structure.Header.Handle}, nil
+	}
+
+	var info *CacheInformation
+
+	switch {
+	case version.GreaterOrEqual(&SMBIOSVersion{3, 1, 0}):
+		ref := &CacheInformationRefSpec31{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = CacheInformationFromSpec31(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 1, 0}):
+		ref := &CacheInformationRefSpec21{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = CacheInformationFromSpec21(ref, structure.Strings)
+	default:
+		ref := &CacheInformationRefSpec20{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = CacheInformationFromSpec20(ref, structure.Strings)
+	}
+
+	info.Handle = structure.Header.Handle
+
+	return info, nil
+}
+
+func (s *Svc) parseSystemSlot(structure *smbios.Structure, version *SMBIOSVersion) (*SystemSlot, error) {
+	// Spec contains info only for 2.0+
+	if version.Lesser(&SMBIOSVersion{2, 0, 0}) {
+		return &SystemSlot{Handle: structure.Header.Handle}, nil
+	}
+
+	var slot *SystemSlot
+
+	switch {
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 6, 0}):
+		ref := &SystemSlotRefSpec26{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		slot = SystemSlotFromSpec26(ref, structure.Strings)
+	case version.GreaterOrEqual(&SMBIOSVersion{2, 1, 0}):
+		ref := &SystemSlotRefSpec21{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		slot = SystemSlotFromSpec21(ref, structure.Strings)
+	default:
+		ref := &SystemSlotRefSpec20{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		slot = SystemSlotFromSpec20(ref, structure.Strings)
+	}
+
+	slot.Handle = structure.Header.Handle
+
+	return slot, nil
+}
+
+func (s *Svc) parseOnboardDevice(structure *smbios.Structure, version *SMBIOSVersion) (*OnboardDevice, error) {
+	// Spec contains info only for 2.6+
+	if version.Lesser(&SMBIOSVersion{2, 6, 0}) {
+		return &OnboardDevice{Handle: structure.Header.Handle}, nil
+	}
+
+	ref := &OnboardDeviceRefSpec{}
+	if err := unpackFormatted(structure, ref); err != nil {
+		return nil, errors.Wrap(err, "unable to unpack structure")
+	}
+
+	device := OnboardDeviceFromSpec(ref, structure.Strings)
+	device.Handle = structure.Header.Handle
+
+	return device, nil
+}
+
+func (s *Svc) parseStringList(structure *smbios.Structure) ([]string, error) {
+	ref := &StringListRefSpec{}
+	if err := unpackFormatted(structure, ref); err != nil {
+		return nil, errors.Wrap(err, "unable to unpack structure")
+	}
+
+	return StringListFromSpec(ref, structure.Strings), nil
+}
+
+func (s *Svc) parseCoolingDevice(structure *smbios.Structure, version *SMBIOSVersion) (*CoolingDevice, error) {
+	// Spec contains info only for 2.2+
+	if version.Lesser(&SMBIOSVersion{2, 2, 0}) {
+		return &CoolingDevice{Handle: structure.Header.Handle}, nil
+	}
+
+	var info *CoolingDevice
+
+	if version.GreaterOrEqual(&SMBIOSVersion{2, 7, 0}) {
+		ref := &CoolingDeviceRefSpec27{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = CoolingDeviceFromSpec27(ref, structure.Strings)
+	} else {
+		ref := &CoolingDeviceRefSpec22{}
+		if err := unpackFormatted(structure, ref); err != nil {
+			return nil, errors.Wrap(err, "unable to unpack structure")
+		}
+		info = CoolingDeviceFromSpec22(ref)
+	}
+
+	info.Handle = structure.Header.Handle
+
+	return info, nil
+}
+
+func (s *Svc) parseTemperatureProbe(structure *smbios.Structure, version *SMBIOSVersion) (*TemperatureProbe, error) {
+	// Spec contains info only for 2.2+
+	if version.Lesser(&SMBIOSVersion{2, 2, 0}) {
+		return &TemperatureProbe{Handle: structure.Header.Handle}, nil
+	}
+
+	ref := &TemperatureProbeRefSpec{}
+	if err := unpackFormatted(structure, ref); err != nil {
+		return nil, errors.Wrap(err, "unable to unpack structure")
+	}
+
+	info := TemperatureProbeFromSpec(ref, structure.Strings)
+	info.Handle = structure.Header.Handle
+
+	return info, nil
+}
+
+func (s *Svc) parseSystemPowerSupply(structure *smbios.Structure, version *SMBIOSVersion) (*SystemPowerSupply, error) {
+	// Spec contains info only for 2.3.1+,
+	// but 2.x entry points do not carry revision
+	if version.Lesser(&SMBIOSVersion{2, 3, 0}) {
+		return &SystemPowerSupply{Handle: structure.Header.Handle}, nil
+	}
+
+	ref := &SystemPowerSupplyRefSpec{}
+	if err := unpackFormatted(structure, ref); err != nil {
+		return nil, errors.Wrap(err, "unable to unpack structure")
+	}
+
+	info := SystemPowerSupplyFromSpec(ref, structure.Strings)
+	info.Handle = structure.Header.Handle
+
+	return info, nil
+}
+
+func (s *Svc) parseHostInterface(structure *smbios.Structure, version *SMBIOSVersion) (*HostInterface, error) {
+	// Spec contains info only for 3.0+
+	if version.Lesser(&SMBIOSVersion{3, 0, 0}) {
+		return &HostInterface{Handle: structure.Header.Handle}, nil
+	}
+
+	info, err := HostInterfaceFromFormatted(structure.Formatted, structure.Strings)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode structure")
+	}
+
+	info.Handle = structure.Header.Handle
+
+	return info, nil
+}
+
+// unpackFormatted unpacks formatted area of the structure into the reference spec.
+// Firmware often reports newer SMBIOS version than the one structures are
+// actually built by, so missing trailing fields are treated as zeroes.
+func unpackFormatted(structure *smbios.Structure, ref interface{}) error {
+	size, err := struc.Sizeof(ref)
+	if err != nil {
+		return errors.Wrap(err, "unable to get reference spec size")
+	}
+
+	formatted := structure.Formatted
+	if len(formatted) < size {
+		formatted = make([]byte, size)
+		copy(formatted, structure.Formatted)
+	}
+
+	return struc.Unpack(bytes.NewReader(formatted), ref)
+}
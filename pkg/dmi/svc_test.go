@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/digitalocean/go-smbios/smbios"
+	"github.com/lunixbochs/struc"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func newTestSvc() *Svc {
+	return NewSvc(printer.NewSvc(false), nil, nil)
+}
+
+// packStructure builds structure with formatted area packed from the reference spec
+func packStructure(t *testing.T, headerType uint8, handle uint16, ref interface{}, strings []string) *smbios.Structure {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	if err := struc.Pack(buf, ref); err != nil {
+		t.Fatal(err)
+	}
+
+	return &smbios.Structure{
+		Header: smbios.Header{
+			Type:   headerType,
+			Length: uint8(buf.Len() + 4),
+			Handle: handle,
+		},
+		Formatted: buf.Bytes(),
+		Strings:   strings,
+	}
+}
+
+func TestUnpackFormatted(t *testing.T) {
+	type refSpec struct {
+		First  uint16 `struc:"uint16,little"`
+		Second byte   `struc:"byte"`
+		Third  uint32 `struc:"uint32,little"`
+	}
+
+	tests := []struct {
+		name      string
+		formatted []byte
+		expected  refSpec
+	}{
+		{
+			name:      "complete",
+			formatted: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07},
+			expected:  refSpec{First: 0x0201, Second: 0x03, Third: 0x07060504},
+		},
+		{
+			name:      "trailing fields missing",
+			formatted: []byte{0x01, 0x02, 0x03},
+			expected:  refSpec{First: 0x0201, Second: 0x03},
+		},
+		{
+			name:      "field cut in the middle",
+			formatted: []byte{0x01, 0x02, 0x03, 0x04},
+			expected:  refSpec{First: 0x0201, Second: 0x03, Third: 0x04},
+		},
+		{
+			name:      "longer than spec",
+			formatted: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+			expected:  refSpec{First: 0x0201, Second: 0x03, Third: 0x07060504},
+		},
+	}
+
+	for _, test := range tests {
+		ref := refSpec{}
+		if err := unpackFormatted(&smbios.Structure{Formatted: test.formatted}, &ref); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if ref != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, ref)
+		}
+	}
+}
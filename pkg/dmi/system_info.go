@@ -79,17 +79,7 @@ func SystemInformationFromSpec20(ref *SystemInformationRefSpec20, strings []stri
 func SystemInformationFromSpec21(ref *SystemInformationRefSpec21, strings []string) *SystemInformation {
 	info := SystemInformationFromSpec20(&ref.SystemInformationRefSpec20, strings)
 
-	// According to SMBIOS spec UUID bytes should be ordered as
-	// 33 22 11 00 55 44 77 66 88 99 AA BB CC DD EE FF
-	// see 7.2.1 System — UUID
-	uuidBytes := make([]byte, len(ref.UUID))
-	copy(uuidBytes, ref.UUID)
-	swapBytesInSlice(uuidBytes, 0, 3)
-	swapBytesInSlice(uuidBytes, 1, 2)
-	swapBytesInSlice(uuidBytes, 4, 5)
-	swapBytesInSlice(uuidBytes, 6, 7)
-
-	info.UUID = fmt.Sprintf("%x-%x-%x-%x-%x", uuidBytes[0:4], uuidBytes[4:6], uuidBytes[6:8], uuidBytes[8:10], uuidBytes[10:])
+	info.UUID = uuidFromSMBIOS(ref.UUID)
 	info.WakeUpType = wakeUpTypes[ref.WakeUpType]
 
 	return info
@@ -106,6 +96,20 @@ func SystemInformationFromSpec24(ref *SystemInformationRefSpec24, strings []stri
 	return info
 }
 
+// According to SMBIOS spec UUID bytes should be ordered as
+// 33 22 11 00 55 44 77 66 88 99 AA BB CC DD EE FF
+// see 7.2.1 System — UUID
+func uuidFromSMBIOS(raw []byte) string {
+	uuidBytes := make([]byte, len(raw))
+	copy(uuidBytes, raw)
+	swapBytesInSlice(uuidBytes, 0, 3)
+	swapBytesInSlice(uuidBytes, 1, 2)
+	swapBytesInSlice(uuidBytes, 4, 5)
+	swapBytesInSlice(uuidBytes, 6, 7)
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuidBytes[0:4], uuidBytes[4:6], uuidBytes[6:8], uuidBytes[8:10], uuidBytes[10:])
+}
+
 func swapBytesInSlice(slice []byte, a int, b int) {
 	tmp := slice[a]
 	slice[a] = slice[b]
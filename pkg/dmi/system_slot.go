@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import "fmt"
+
+var systemSlotTypes = map[byte]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "ISA",
+	0x04: "MCA",
+	0x05: "EISA",
+	0x06: "PCI",
+	0x07: "PC Card (PCMCIA)",
+	0x08: "VL-VESA",
+	0x09: "Proprietary",
+	0x0A: "Processor Card",
+	0x0B: "Proprietary Memory Card",
+	0x0C: "I/O Riser Card",
+	0x0D: "NuBus",
+	0x0E: "PCI-66MHz",
+	0x0F: "AGP",
+	0x10: "AGP 2X",
+	0x11: "AGP 4X",
+	0x12: "PCI-X",
+	0x13: "AGP 8X",
+	0x14: "M.2 Socket 1-DP",
+	0x15: "M.2 Socket 1-SD",
+	0x16: "M.2 Socket 2",
+	0x17: "M.2 Socket 3",
+	0x18: "MXM Type I",
+	0x19: "MXM Type II",
+	0x1A: "MXM Type III",
+	0x1B: "MXM Type III-HE",
+	0x1C: "MXM Type IV",
+	0x1D: "MXM 3.0 Type A",
+	0x1E: "MXM 3.0 Type B",
+	0x1F: "PCI Express Gen 2 SFF-8639 (U.2)",
+	0x20: "PCI Express Gen 3 SFF-8639 (U.2)",
+	0x21: "PCI Express Mini 52-pin with bottom-side keep-outs",
+	0x22: "PCI Express Mini 52-pin without bottom-side keep-outs",
+	0x23: "PCI Express Mini 76-pin",
+	0x24: "PCI Express Gen 4 SFF-8639 (U.2)",
+	0x25: "PCI Express Gen 5 SFF-8639 (U.2)",
+	0x26: "OCP NIC 3.0 Small Form Factor (SFF)",
+	0x27: "OCP NIC 3.0 Large Form Factor (LFF)",
+	0x28: "OCP NIC Prior to 3.0",
+	0x30: "CXL Flexbus 1.0",
+	0xA0: "PC-98/C20",
+	0xA1: "PC-98/C24",
+	0xA2: "PC-98/E",
+	0xA3: "PC-98/Local Bus",
+	0xA4: "PC-98/Card",
+	0xA5: "PCI Express",
+	0xA6: "PCI Express x1",
+	0xA7: "PCI Express x2",
+	0xA8: "PCI Express x4",
+	0xA9: "PCI Express x8",
+	0xAA: "PCI Express x16",
+	0xAB: "PCI Express 2",
+	0xAC: "PCI Express 2 x1",
+	0xAD: "PCI Express 2 x2",
+	0xAE: "PCI Express 2 x4",
+	0xAF: "PCI Express 2 x8",
+	0xB0: "PCI Express 2 x16",
+	0xB1: "PCI Express 3",
+	0xB2: "PCI Express 3 x1",
+	0xB3: "PCI Express 3 x2",
+	0xB4: "PCI Express 3 x4",
+	0xB5: "PCI Express 3 x8",
+	0xB6: "PCI Express 3 x16",
+	0xB8: "PCI Express 4",
+	0xB9: "PCI Express 4 x1",
+	0xBA: "PCI Express 4 x2",
+	0xBB: "PCI Express 4 x4",
+	0xBC: "PCI Express 4 x8",
+	0xBD: "PCI Express 4 x16",
+	0xBE: "PCI Express 5",
+	0xBF: "PCI Express 5 x1",
+	0xC0: "PCI Express 5 x2",
+	0xC1: "PCI Express 5 x4",
+	0xC2: "PCI Express 5 x8",
+	0xC3: "PCI Express 5 x16",
+	0xC4: "PCI Express 6+",
+	0xC5: "EDSFF E1",
+	0xC6: "EDSFF E3",
+}
+
+var systemSlotDataBusWidths = map[byte]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "8 bit",
+	0x04: "16 bit",
+	0x05: "32 bit",
+	0x06: "64 bit",
+	0x07: "128 bit",
+	0x08: "x1",
+	0x09: "x2",
+	0x0A: "x4",
+	0x0B: "x8",
+	0x0C: "x12",
+	0x0D: "x16",
+	0x0E: "x32",
+}
+
+type SlotUsage string
+
+const (
+	COtherSlotUsage       SlotUsage = "Other"
+	CUnknownSlotUsage     SlotUsage = "Unknown"
+	CAvailableSlotUsage   SlotUsage = "Available"
+	CInUseSlotUsage       SlotUsage = "In use"
+	CUnavailableSlotUsage SlotUsage = "Unavailable"
+)
+
+var slotUsages = map[byte]SlotUsage{
+	0x01: COtherSlotUsage,
+	0x02: CUnknownSlotUsage,
+	0x03: CAvailableSlotUsage,
+	0x04: CInUseSlotUsage,
+	0x05: CUnavailableSlotUsage,
+}
+
+var systemSlotLengths = map[byte]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Short Length",
+	0x04: "Long Length",
+	0x05: "2.5\" drive form factor",
+	0x06: "3.5\" drive form factor",
+}
+
+const (
+	// CNoBusAddress in bus and device/function fields
+	// means that slot is not bound to PCI device
+	CNoBusAddress = 0xFF
+
+	CDeviceNumberShift   = 3
+	CFunctionNumberMask  = 0x07
+	CPCIAddressFormat    = "%04x:%02x:%02x.%x"
+	CPCIDeviceAddressLen = len("0000:00:00")
+)
+
+type SystemSlotRefSpec20 struct {
+	SlotDesignation      byte   `struc:"byte"`
+	SlotType             byte   `struc:"byte"`
+	SlotDataBusWidth     byte   `struc:"byte"`
+	CurrentUsage         byte   `struc:"byte"`
+	SlotLength           byte   `struc:"byte"`
+	SlotID               uint16 `struc:"uint16,little"`
+	SlotCharacteristics1 byte   `struc:"byte"`
+}
+
+type SystemSlotRefSpec21 struct {
+	SystemSlotRefSpec20
+	SlotCharacteristics2 byte `struc:"byte"`
+}
+
+type SystemSlotRefSpec26 struct {
+	SystemSlotRefSpec21
+	SegmentGroupNumber   uint16 `struc:"uint16,little"`
+	BusNumber            byte   `struc:"byte"`
+	DeviceFunctionNumber byte   `struc:"byte"`
+}
+
+type SystemSlot struct {
+	Handle       uint16
+	Designation  string
+	Type         string
+	DataBusWidth string
+	CurrentUsage SlotUsage
+	Length       string
+	ID           uint16
+	// PCIAddress is an address of the device in the slot
+	// in the same format as in sysfs, e.g. 0000:3b:00.0
+	PCIAddress string
+}
+
+func SystemSlotFromSpec20(ref *SystemSlotRefSpec20, strings []string) *SystemSlot {
+	slot := &SystemSlot{
+		Designation:  emptyStringOrValue(ref.SlotDesignation, strings),
+		Type:         systemSlotTypes[ref.SlotType],
+		DataBusWidth: systemSlotDataBusWidths[ref.SlotDataBusWidth],
+		CurrentUsage: slotUsages[ref.CurrentUsage],
+		Length:       systemSlotLengths[ref.SlotLength],
+		ID:           ref.SlotID,
+	}
+
+	return slot
+}
+
+func SystemSlotFromSpec21(ref *SystemSlotRefSpec21, strings []string) *SystemSlot {
+	return SystemSlotFromSpec20(&ref.SystemSlotRefSpec20, strings)
+}
+
+func SystemSlotFromSpec26(ref *SystemSlotRefSpec26, strings []string) *SystemSlot {
+	slot := SystemSlotFromSpec21(&ref.SystemSlotRefSpec21, strings)
+
+	slot.PCIAddress = pciAddress(ref.SegmentGroupNumber, ref.BusNumber, ref.DeviceFunctionNumber)
+
+	return slot
+}
+
+// pciAddress formats SMBIOS bus address,
+// device and function numbers share the same byte
+// (11111)(111)
+// first group (5 bits) - device
+// second group (3 bits) - function
+func pciAddress(segment uint16, bus byte, devFn byte) string {
+	if bus == CNoBusAddress && devFn == CNoBusAddress {
+		return ""
+	}
+
+	return fmt.Sprintf(CPCIAddressFormat, segment, bus, devFn>>CDeviceNumberShift, devFn&CFunctionNumberMask)
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSystemSlot(t *testing.T) {
+	base := SystemSlotRefSpec20{
+		SlotDesignation:  1,
+		SlotType:         0xB6,
+		SlotDataBusWidth: 0x0D,
+		CurrentUsage:     0x04,
+		SlotLength:       0x04,
+		SlotID:           2,
+	}
+
+	tests := []struct {
+		name     string
+		version  *SMBIOSVersion
+		ref      interface{}
+		expected *SystemSlot
+	}{
+		{
+			name:    "2.6 with bus address",
+			version: NewSMBIOSVersion(2, 6, 0),
+			ref: &SystemSlotRefSpec26{
+				SystemSlotRefSpec21: SystemSlotRefSpec21{SystemSlotRefSpec20: base},
+				SegmentGroupNumber:  0x0001,
+				BusNumber:           0x3b,
+				// device 2, function 1
+				DeviceFunctionNumber: 2<<CDeviceNumberShift | 1,
+			},
+			expected: &SystemSlot{
+				Handle:       0x0900,
+				Designation:  "PCIE2",
+				Type:         "PCI Express 3 x16",
+				DataBusWidth: "x16",
+				CurrentUsage: CInUseSlotUsage,
+				Length:       "Long Length",
+				ID:           2,
+				PCIAddress:   "0001:3b:02.1",
+			},
+		},
+		{
+			name:    "2.6 not bound to device",
+			version: NewSMBIOSVersion(2, 6, 0),
+			ref: &SystemSlotRefSpec26{
+				SystemSlotRefSpec21:  SystemSlotRefSpec21{SystemSlotRefSpec20: base},
+				BusNumber:            CNoBusAddress,
+				DeviceFunctionNumber: CNoBusAddress,
+			},
+			expected: &SystemSlot{
+				Handle:       0x0900,
+				Designation:  "PCIE2",
+				Type:         "PCI Express 3 x16",
+				DataBusWidth: "x16",
+				CurrentUsage: CInUseSlotUsage,
+				Length:       "Long Length",
+				ID:           2,
+			},
+		},
+		{
+			name:    "2.0 without bus address",
+			version: NewSMBIOSVersion(2, 0, 0),
+			ref:     &base,
+			expected: &SystemSlot{
+				Handle:       0x0900,
+				Designation:  "PCIE2",
+				Type:         "PCI Express 3 x16",
+				DataBusWidth: "x16",
+				CurrentUsage: CInUseSlotUsage,
+				Length:       "Long Length",
+				ID:           2,
+			},
+		},
+	}
+
+	svc := newTestSvc()
+	for _, test := range tests {
+		structure := packStructure(t, CSystemSlotHeaderType, 0x0900, test.ref, []string{"PCIE2"})
+		slot, err := svc.parseSystemSlot(structure, test.version)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(slot, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, slot)
+		}
+	}
+}
+
+func TestGetPCIDesignation(t *testing.T) {
+	dmi := &DMI{
+		SystemSlots: []SystemSlot{
+			{Designation: "PCIE1", PCIAddress: "0000:3b:00.0"},
+			{Designation: "PCIE2"},
+		},
+		OnboardDevices: []OnboardDevice{
+			{ReferenceDesignation: "Onboard LAN 1", PCIAddress: "0000:19:00.0"},
+			{ReferenceDesignation: "Onboard LAN 2", PCIAddress: "0000:19:00.1"},
+		},
+	}
+
+	tests := []struct {
+		address  string
+		expected string
+	}{
+		{address: "0000:19:00.1", expected: "Onboard LAN 2"},
+		// functions of the card in the slot
+		{address: "0000:3b:00.0", expected: "PCIE1"},
+		{address: "0000:3b:00.3", expected: "PCIE1"},
+		{address: "0000:5e:00.0", expected: ""},
+		{address: "", expected: ""},
+	}
+
+	for _, test := range tests {
+		if actual := dmi.GetPCIDesignation(test.address); actual != test.expected {
+			t.Errorf("address %s: expected %q, got %q", test.address, test.expected, actual)
+		}
+	}
+}
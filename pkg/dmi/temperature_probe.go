@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+var temperatureProbeLocations = map[byte]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Processor",
+	0x04: "Disk",
+	0x05: "Peripheral Bay",
+	0x06: "System Management Module",
+	0x07: "Motherboard",
+	0x08: "Memory Module",
+	0x09: "Processor Module",
+	0x0A: "Power Unit",
+	0x0B: "Add-in Card",
+	0x0C: "Front Panel Board",
+	0x0D: "Back Panel Board",
+	0x0E: "Power System Board",
+	0x0F: "Drive Back Plane",
+}
+
+type TemperatureProbeRefSpec struct {
+	Description       byte   `struc:"byte"`
+	LocationAndStatus byte   `struc:"byte"`
+	MaximumValue      uint16 `struc:"uint16,little"`
+	MinimumValue      uint16 `struc:"uint16,little"`
+	Resolution        uint16 `struc:"uint16,little"`
+	Tolerance         uint16 `struc:"uint16,little"`
+	Accuracy          uint16 `struc:"uint16,little"`
+	OEMDefined        uint32 `struc:"uint32,little"`
+	NominalValue      uint16 `struc:"uint16,little"`
+}
+
+// TemperatureProbe values are nil if they are unknown
+type TemperatureProbe struct {
+	Handle      uint16
+	Description string
+	Location    string
+	Status      DeviceStatus
+	// MaximumValue, MinimumValue, Tolerance and NominalValue are specified in degrees C
+	MaximumValue *float64
+	MinimumValue *float64
+	// Resolution is specified in degrees C
+	Resolution *float64
+	Tolerance  *float64
+	// Accuracy is specified in percents
+	Accuracy     *float64
+	NominalValue *float64
+}
+
+func TemperatureProbeFromSpec(ref *TemperatureProbeRefSpec, strings []string) *TemperatureProbe {
+	info := &TemperatureProbe{
+		Description: emptyStringOrValue(ref.Description, strings),
+		Location:    temperatureProbeLocations[ref.LocationAndStatus&CDeviceKindMask],
+		Status:      deviceStatuses[(ref.LocationAndStatus>>CDeviceStatusShift)&CDeviceStatusMask],
+	}
+
+	// temperatures are signed values in 1/10 degrees C,
+	// resolution is in 1/1000 degrees C, accuracy is in 1/100 percents
+	info.MaximumValue = scaledProbeValue(ref.MaximumValue, 10, true)
+	info.MinimumValue = scaledProbeValue(ref.MinimumValue, 10, true)
+	info.Resolution = scaledProbeValue(ref.Resolution, 1000, false)
+	info.Tolerance = scaledProbeValue(ref.Tolerance, 10, true)
+	info.Accuracy = scaledProbeValue(ref.Accuracy, 100, false)
+	info.NominalValue = scaledProbeValue(ref.NominalValue, 10, true)
+
+	return info
+}
+
+func scaledProbeValue(val uint16, divisor float64, signed bool) *float64 {
+	if val == CUnknownValue {
+		return nil
+	}
+
+	var scaled float64
+	if signed {
+		scaled = float64(int16(val)) / divisor
+	} else {
+		scaled = float64(val) / divisor
+	}
+
+	return &scaled
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"testing"
+)
+
+func TestParseTemperatureProbe(t *testing.T) {
+	minimum := int16(-400)
+	ref := &TemperatureProbeRefSpec{
+		Description: 1,
+		// OK processor probe
+		LocationAndStatus: 0x03<<CDeviceStatusShift | 0x03,
+		MaximumValue:      1250,
+		MinimumValue:      uint16(minimum),
+		Resolution:        500,
+		Tolerance:         CUnknownValue,
+		Accuracy:          150,
+		NominalValue:      CUnknownValue,
+	}
+
+	svc := newTestSvc()
+	structure := packStructure(t, CTemperatureProbeHeaderType, 0x1c00, ref, []string{"CPU Temp"})
+	info, err := svc.parseTemperatureProbe(structure, NewSMBIOSVersion(2, 2, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Handle != 0x1c00 || info.Description != "CPU Temp" || info.Location != "Processor" || info.Status != COKDeviceStatus {
+		t.Errorf("unexpected probe %+v", info)
+	}
+
+	values := []struct {
+		name     string
+		actual   *float64
+		expected float64
+	}{
+		{name: "maximum", actual: info.MaximumValue, expected: 125},
+		{name: "minimum", actual: info.MinimumValue, expected: -40},
+		{name: "resolution", actual: info.Resolution, expected: 0.5},
+		{name: "accuracy", actual: info.Accuracy, expected: 1.5},
+	}
+	for _, value := range values {
+		if value.actual == nil || *value.actual != value.expected {
+			t.Errorf("expected %s %v, got %v", value.name, value.expected, value.actual)
+		}
+	}
+	if info.Tolerance != nil || info.NominalValue != nil {
+		t.Error("expected unknown values to be nil")
+	}
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package edac
+
+type Health string
+
+const (
+	CHealthOK       Health = "OK"
+	CHealthDegraded Health = "Degraded"
+	CHealthFailed   Health = "Failed"
+
+	// CDefaultCEThreshold is a number of correctable errors DIMM is considered degraded at
+	CDefaultCEThreshold = 100
+)
+
+// MemoryController is an EDAC memory controller, error counts are since driver load or counter reset
+type MemoryController struct {
+	ID   int
+	Name string
+	// Size is in bytes
+	Size              uint64
+	CECount           uint64
+	UECount           uint64
+	CENoInfoCount     uint64
+	UENoInfoCount     uint64
+	SecondsSinceReset uint64
+	DIMMs             []DIMM
+}
+
+// DIMM is a memory module (or rank for drivers reporting ranks) of EDAC memory controller
+type DIMM struct {
+	// Name is a sysfs directory name, e.g. dimm0, rank3 or csrow0/ch1 for legacy drivers
+	Name string
+	// Label is set by firmware (ghes_edac uses SMBIOS bank and device locators), driver or edac-ctl
+	Label    string
+	Location string
+	// Locator is a SMBIOS device locator the label is matched with
+	Locator string
+	// MemoryDeviceHandle is a SMBIOS handle of matched memory device, valid only if Locator is set,
+	// as device locators are not unique on some boards
+	MemoryDeviceHandle uint16
+	// Size is in bytes
+	Size     uint64
+	MemType  string
+	DevType  string
+	EDACMode string
+	CECount  uint64
+	UECount  uint64
+}
+
+// Health is failed if there are uncorrectable errors and degraded if correctable errors reach the threshold,
+// threshold of 0 disables the latter
+func (d *DIMM) Health(ceThreshold uint64) Health {
+	switch {
+	case d.UECount > 0:
+		return CHealthFailed
+	case ceThreshold > 0 && d.CECount >= ceThreshold:
+		return CHealthDegraded
+	default:
+		return CHealthOK
+	}
+}
@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package edac
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CEDACMemoryControllerPath = "/sys/devices/system/edac/mc"
+
+	CMCNamePath              = "/mc_name"
+	CMCSizePath              = "/size_mb"
+	CMCCECountPath           = "/ce_count"
+	CMCUECountPath           = "/ue_count"
+	CMCCENoInfoCountPath     = "/ce_noinfo_count"
+	CMCUENoInfoCountPath     = "/ue_noinfo_count"
+	CMCSecondsSinceResetPath = "/seconds_since_reset"
+
+	CDIMMLabelPath    = "/dimm_label"
+	CDIMMLocationPath = "/dimm_location"
+	CDIMMSizePath     = "/size"
+	CDIMMMemTypePath  = "/dimm_mem_type"
+	CDIMMDevTypePath  = "/dimm_dev_type"
+	CDIMMEDACModePath = "/dimm_edac_mode"
+	CDIMMCECountPath  = "/dimm_ce_count"
+	CDIMMUECountPath  = "/dimm_ue_count"
+
+	CCSRowSizePath           = "/size_mb"
+	CCSRowMemTypePath        = "/mem_type"
+	CCSRowDevTypePath        = "/dev_type"
+	CCSRowEDACModePath       = "/edac_mode"
+	CCSRowChannelLabelPath   = "/ch%d_dimm_label"
+	CCSRowChannelCECountPath = "/ch%d_ce_count"
+
+	CMBToBytes = 1024 * 1024
+
+	CMemoryControllerDirNamePattern = "^mc([0-9]+)$"
+	CDIMMDirNamePattern             = "^(dimm|rank)([0-9]+)$"
+	CCSRowDirNamePattern            = "^csrow([0-9]+)$"
+)
+
+var CMemoryControllerDirNameRegexp = regexp.MustCompile(CMemoryControllerDirNamePattern)
+var CDIMMDirNameRegexp = regexp.MustCompile(CDIMMDirNamePattern)
+var CCSRowDirNameRegexp = regexp.MustCompile(CCSRowDirNamePattern)
+
+type Svc struct {
+	printer               *printer.Svc
+	memoryControllersPath string
+}
+
+func NewSvc(printer *printer.Svc, basePath string) *Svc {
+	return &Svc{
+		printer:               printer,
+		memoryControllersPath: path.Join(basePath, CEDACMemoryControllerPath),
+	}
+}
+
+func (s *Svc) GetData() ([]MemoryController, error) {
+	entries, err := os.ReadDir(s.memoryControllersPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read memory controllers from %s", s.memoryControllersPath)
+	}
+
+	controllers := make([]MemoryController, 0)
+	for _, entry := range entries {
+		groups := CMemoryControllerDirNameRegexp.FindStringSubmatch(entry.Name())
+		if len(groups) < 2 {
+			continue
+		}
+
+		id, err := strconv.Atoi(groups[1])
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to convert memory controller number %s", groups[1]))
+			continue
+		}
+
+		controller, err := s.getMemoryController(path.Join(s.memoryControllersPath, entry.Name()), id)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get memory controller %d", id))
+			continue
+		}
+		controllers = append(controllers, *controller)
+	}
+
+	sort.Slice(controllers, func(i, j int) bool {
+		return controllers[i].ID < controllers[j].ID
+	})
+
+	return controllers, nil
+}
+
+func (s *Svc) getMemoryController(thePath string, id int) (*MemoryController, error) {
+	name, err := file.ToString(path.Join(thePath, CMCNamePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get name")
+	}
+
+	ceCount, err := file.ToUint64(path.Join(thePath, CMCCECountPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get correctable error count")
+	}
+
+	ueCount, err := file.ToUint64(path.Join(thePath, CMCUECountPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get uncorrectable error count")
+	}
+
+	controller := &MemoryController{
+		ID:      id,
+		Name:    name,
+		CECount: ceCount,
+		UECount: ueCount,
+	}
+
+	if size, err := file.ToUint64(path.Join(thePath, CMCSizePath)); err == nil {
+		controller.Size = size * CMBToBytes
+	}
+	if count, err := file.ToUint64(path.Join(thePath, CMCCENoInfoCountPath)); err == nil {
+		controller.CENoInfoCount = count
+	}
+	if count, err := file.ToUint64(path.Join(thePath, CMCUENoInfoCountPath)); err == nil {
+		controller.UENoInfoCount = count
+	}
+	if seconds, err := file.ToUint64(path.Join(thePath, CMCSecondsSinceResetPath)); err == nil {
+		controller.SecondsSinceReset = seconds
+	}
+
+	dimms, err := s.getDIMMs(thePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get dimms")
+	}
+	controller.DIMMs = dimms
+
+	return controller, nil
+}
+
+// getDIMMs reads dimm* or rank* directories of the current EDAC API,
+// csrow* directories of the legacy one are used only if there are none
+func (s *Svc) getDIMMs(thePath string) ([]DIMM, error) {
+	entries, err := os.ReadDir(thePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read %s", thePath)
+	}
+
+	type indexedDIMM struct {
+		index int
+		dimm  DIMM
+	}
+
+	dimms := make([]indexedDIMM, 0)
+	csRows := make([]indexedDIMM, 0)
+	for _, entry := range entries {
+		if groups := CDIMMDirNameRegexp.FindStringSubmatch(entry.Name()); len(groups) == 3 {
+			index, err := strconv.Atoi(groups[2])
+			if err != nil {
+				s.printer.VErr(errors.Wrapf(err, "unable to convert dimm number %s", groups[2]))
+				continue
+			}
+
+			dimm, err := getDIMM(path.Join(thePath, entry.Name()), entry.Name())
+			if err != nil {
+				s.printer.VErr(errors.Wrapf(err, "unable to get %s", entry.Name()))
+				continue
+			}
+			dimms = append(dimms, indexedDIMM{index: index, dimm: *dimm})
+			continue
+		}
+
+		if groups := CCSRowDirNameRegexp.FindStringSubmatch(entry.Name()); len(groups) == 2 {
+			index, err := strconv.Atoi(groups[1])
+			if err != nil {
+				s.printer.VErr(errors.Wrapf(err, "unable to convert csrow number %s", groups[1]))
+				continue
+			}
+
+			for _, dimm := range getCSRowDIMMs(path.Join(thePath, entry.Name()), entry.Name()) {
+				csRows = append(csRows, indexedDIMM{index: index, dimm: dimm})
+			}
+		}
+	}
+
+	if len(dimms) == 0 {
+		dimms = csRows
+	}
+
+	sort.SliceStable(dimms, func(i, j int) bool {
+		return dimms[i].index < dimms[j].index
+	})
+
+	result := make([]DIMM, 0, len(dimms))
+	for _, dimm := range dimms {
+		result = append(result, dimm.dimm)
+	}
+
+	return result, nil
+}
+
+func getDIMM(thePath string, name string) (*DIMM, error) {
+	ceCount, err := file.ToUint64(path.Join(thePath, CDIMMCECountPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get correctable error count")
+	}
+
+	ueCount, err := file.ToUint64(path.Join(thePath, CDIMMUECountPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get uncorrectable error count")
+	}
+
+	dimm := &DIMM{
+		Name:    name,
+		CECount: ceCount,
+		UECount: ueCount,
+	}
+
+	if label, err := file.ToString(path.Join(thePath, CDIMMLabelPath)); err == nil {
+		dimm.Label = label
+	}
+	if location, err := file.ToString(path.Join(thePath, CDIMMLocationPath)); err == nil {
+		dimm.Location = location
+	}
+	if size, err := file.ToUint64(path.Join(thePath, CDIMMSizePath)); err == nil {
+		dimm.Size = size * CMBToBytes
+	}
+	if memType, err := file.ToString(path.Join(thePath, CDIMMMemTypePath)); err == nil {
+		dimm.MemType = memType
+	}
+	if devType, err := file.ToString(path.Join(thePath, CDIMMDevTypePath)); err == nil {
+		dimm.DevType = devType
+	}
+	if mode, err := file.ToString(path.Join(thePath, CDIMMEDACModePath)); err == nil {
+		dimm.EDACMode = mode
+	}
+
+	return dimm, nil
+}
+
+// getCSRowDIMMs returns a DIMM per channel of legacy chip select row,
+// uncorrectable errors are counted per row only, so they are left in memory controller totals
+func getCSRowDIMMs(thePath string, name string) []DIMM {
+	dimms := make([]DIMM, 0)
+
+	var size uint64
+	if sizeMB, err := file.ToUint64(path.Join(thePath, CCSRowSizePath)); err == nil {
+		size = sizeMB * CMBToBytes
+	}
+	memType, _ := file.ToString(path.Join(thePath, CCSRowMemTypePath))
+	devType, _ := file.ToString(path.Join(thePath, CCSRowDevTypePath))
+	mode, _ := file.ToString(path.Join(thePath, CCSRowEDACModePath))
+
+	// channels are numbered from 0 without gaps
+	for ch := 0; ; ch++ {
+		ceCount, err := file.ToUint64(path.Join(thePath, fmt.Sprintf(CCSRowChannelCECountPath, ch)))
+		if err != nil {
+			break
+		}
+
+		label, _ := file.ToString(path.Join(thePath, fmt.Sprintf(CCSRowChannelLabelPath, ch)))
+		dimms = append(dimms, DIMM{
+			Name:     fmt.Sprintf("%s/ch%d", name, ch),
+			Label:    label,
+			MemType:  memType,
+			DevType:  devType,
+			EDACMode: mode,
+			CECount:  ceCount,
+		})
+	}
+
+	// size is of the whole row
+	if len(dimms) > 0 {
+		for i := range dimms {
+			dimms[i].Size = size / uint64(len(dimms))
+		}
+	}
+
+	return dimms
+}
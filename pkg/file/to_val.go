@@ -101,3 +101,50 @@ func ToBool(path string) (bool, error) {
 
 	return num == 1, nil
 }
+
+// ToIntList reads list in kernel cpulist format, e.g. 0,3,5-8,11-15,
+// empty file results in empty list
+func ToIntList(path string) ([]int, error) {
+	fileString, err := ToString(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read string from file %s ", path)
+	}
+
+	list, err := ParseIntList(fileString)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse list from file %s", path)
+	}
+
+	return list, nil
+}
+
+// ParseIntList parses list in kernel cpulist format
+func ParseIntList(str string) ([]int, error) {
+	list := make([]int, 0)
+	if str == "" {
+		return list, nil
+	}
+
+	for _, element := range strings.Split(str, ",") {
+		first, last, isRange := strings.Cut(element, "-")
+
+		firstNum, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to convert %s to int", first)
+		}
+		if !isRange {
+			list = append(list, firstNum)
+			continue
+		}
+
+		lastNum, err := strconv.Atoi(last)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to convert %s to int", last)
+		}
+		for i := firstNum; i <= lastNum; i++ {
+			list = append(list, i)
+		}
+	}
+
+	return list, nil
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package file
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIntList(t *testing.T) {
+	tests := []struct {
+		str       string
+		expected  []int
+		expectErr bool
+	}{
+		{str: "", expected: []int{}},
+		{str: "3", expected: []int{3}},
+		{str: "0,3,5-8", expected: []int{0, 3, 5, 6, 7, 8}},
+		{str: "11-15,20", expected: []int{11, 12, 13, 14, 15, 20}},
+		{str: "0-", expectErr: true},
+		{str: "a,1", expectErr: true},
+	}
+
+	for _, test := range tests {
+		list, err := ParseIntList(test.str)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%q: expected error, got %v", test.str, list)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", test.str, err)
+			continue
+		}
+		if !reflect.DeepEqual(list, test.expected) {
+			t.Errorf("%q: expected %v, got %v", test.str, test.expected, list)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/spf13/pflag"
 	"k8s.io/client-go/util/homedir"
+
+	"github.com/onmetal/inventory/pkg/edac"
 )
 
 type InventoryFlags struct {
@@ -18,6 +20,19 @@ type InventoryFlags struct {
 	Gateway       string
 	Timeout       string
 	Patch         bool
+	// OEMStringLabels and OEMStringAnnotations are rules in <key>=<pattern> format
+	OEMStringLabels      []string
+	OEMStringAnnotations []string
+	// DMIDump is a path to `dmidecode --dump-bin` file used instead of host SMBIOS data
+	DMIDump string
+	// DMIDumpOut is a path SMBIOS data is dumped to before gathering
+	DMIDumpOut string
+	// IdentitySources is a chain of sources inventory name is taken from
+	IdentitySources []string
+	// BogusUUIDs extend the list of known shared SMBIOS UUIDs
+	BogusUUIDs []string
+	// EDACCEThreshold is a number of correctable errors DIMM is considered degraded at
+	EDACCEThreshold uint64
 }
 
 func NewInventoryFlags() *InventoryFlags {
@@ -34,6 +49,13 @@ func NewInventoryFlags() *InventoryFlags {
 	gateway := pflag.StringP("gateway", "g", "", "gateway address")
 	timeout := pflag.StringP("timeout", "t", "30s", "request timeout, if gateway is used")
 	patch := pflag.BoolP("patch", "p", false, "patch crd object instead of creation")
+	oemStringLabels := pflag.StringArray("oem-string-label", []string{}, "<key>=<pattern> rule promoting matching SMBIOS OEM string into label")
+	oemStringAnnotations := pflag.StringArray("oem-string-annotation", []string{}, "<key>=<pattern> rule promoting matching SMBIOS OEM string into annotation")
+	dmiDump := pflag.String("dmi-dump", "", "path to dmidecode binary dump used instead of host SMBIOS data")
+	dmiDumpOut := pflag.String("dmi-dump-out", "", "path to write SMBIOS data as dmidecode binary dump")
+	identitySources := pflag.StringSlice("identity-source", []string{}, "chain of sources inventory name is taken from, dmi-uuid,chassis-serial,board-serial,mac if not set")
+	bogusUUIDs := pflag.StringArray("bogus-uuid", []string{}, "SMBIOS UUID shared by many machines that should not be used as inventory name")
+	edacCEThreshold := pflag.Uint64("edac-ce-threshold", edac.CDefaultCEThreshold, "number of EDAC correctable errors DIMM is considered degraded at, 0 disables it")
 	pflag.Parse()
 
 	return &InventoryFlags{
@@ -44,5 +66,16 @@ func NewInventoryFlags() *InventoryFlags {
 		Gateway:       *gateway,
 		Timeout:       *timeout,
 		Patch:         *patch,
+
+		OEMStringLabels:      *oemStringLabels,
+		OEMStringAnnotations: *oemStringAnnotations,
+
+		DMIDump:    *dmiDump,
+		DMIDumpOut: *dmiDumpOut,
+
+		IdentitySources: *identitySources,
+		BogusUUIDs:      *bogusUUIDs,
+
+		EDACCEThreshold: *edacCEThreshold,
 	}
 }
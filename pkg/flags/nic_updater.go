@@ -17,6 +17,10 @@ type NICUpdaterFlags struct {
 	KubeNamespace string
 	Gateway       string
 	Timeout       string
+	// IdentitySources is a chain of sources inventory name is taken from
+	IdentitySources []string
+	// BogusUUIDs extend the list of known shared SMBIOS UUIDs
+	BogusUUIDs []string
 }
 
 func NewNICUpdaterFlags() *NICUpdaterFlags {
@@ -32,6 +36,8 @@ func NewNICUpdaterFlags() *NICUpdaterFlags {
 	kubeNamespace := pflag.StringP("namespace", "n", "default", "k8s namespace")
 	gateway := pflag.StringP("gateway", "g", "", "gateway address")
 	timeout := pflag.StringP("timeout", "t", "30s", "request timeout, if gateway is used")
+	identitySources := pflag.StringSlice("identity-source", []string{}, "chain of sources inventory name is taken from, dmi-uuid,chassis-serial,board-serial,mac if not set")
+	bogusUUIDs := pflag.StringArray("bogus-uuid", []string{}, "SMBIOS UUID shared by many machines that should not be used as inventory name")
 	pflag.Parse()
 
 	return &NICUpdaterFlags{
@@ -41,5 +47,8 @@ func NewNICUpdaterFlags() *NICUpdaterFlags {
 		KubeNamespace: *kubeNamespace,
 		Gateway:       *gateway,
 		Timeout:       *timeout,
+
+		IdentitySources: *identitySources,
+		BogusUUIDs:      *bogusUUIDs,
 	}
 }
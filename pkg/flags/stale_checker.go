@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package flags
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/util/homedir"
+)
+
+type StaleCheckerFlags struct {
+	Verbose    bool
+	Kubeconfig string
+	StaleAfter time.Duration
+	Mark       bool
+}
+
+func NewStaleCheckerFlags() *StaleCheckerFlags {
+	var kubeconfigDefaultPath string
+
+	if home := homedir.HomeDir(); home != "" {
+		kubeconfigDefaultPath = filepath.Join(home, ".kube", "config")
+	}
+
+	verbose := pflag.BoolP("verbose", "v", false, "verbose output")
+	kubeconfig := pflag.StringP("kubeconfig", "k", kubeconfigDefaultPath, "path to kubeconfig")
+	staleAfter := pflag.DurationP("stale-after", "s", 24*time.Hour, "time since last refresh after which inventory is considered stale")
+	mark := pflag.BoolP("mark", "m", false, "mark stale inventories with label and condition instead of listing only")
+	pflag.Parse()
+
+	return &StaleCheckerFlags{
+		Verbose:    *verbose,
+		Kubeconfig: *kubeconfig,
+		StaleAfter: *staleAfter,
+		Mark:       *mark,
+	}
+}
@@ -6,22 +6,50 @@ package gatherer
 import (
 	"github.com/onmetal/inventory/pkg/block"
 	"github.com/onmetal/inventory/pkg/cpu"
+	"github.com/onmetal/inventory/pkg/cxl"
 	"github.com/onmetal/inventory/pkg/distro"
 	"github.com/onmetal/inventory/pkg/dmi"
+	"github.com/onmetal/inventory/pkg/edac"
 	"github.com/onmetal/inventory/pkg/host"
 	"github.com/onmetal/inventory/pkg/ipmi"
+	"github.com/onmetal/inventory/pkg/kernel"
 	"github.com/onmetal/inventory/pkg/lldp"
 	"github.com/onmetal/inventory/pkg/mem"
 	"github.com/onmetal/inventory/pkg/mlc"
 	"github.com/onmetal/inventory/pkg/netlink"
 	"github.com/onmetal/inventory/pkg/nic"
 	"github.com/onmetal/inventory/pkg/numa"
+	"github.com/onmetal/inventory/pkg/nvdimm"
 	"github.com/onmetal/inventory/pkg/pci"
 	"github.com/onmetal/inventory/pkg/virt"
 )
 
 type Option func(svc *Svc)
 
+func WithCPUTopology(topologySvc *cpu.TopologySvc) Option {
+	return func(svc *Svc) {
+		svc.cpuTopologySvc = topologySvc
+	}
+}
+
+func WithCPUMicroarch(microarchSvc *cpu.MicroarchSvc) Option {
+	return func(svc *Svc) {
+		svc.cpuMicroarchSvc = microarchSvc
+	}
+}
+
+func WithCPUPower(powerSvc *cpu.PowerSvc) Option {
+	return func(svc *Svc) {
+		svc.cpuPowerSvc = powerSvc
+	}
+}
+
+func WithCPUVulnerabilities(vulnerabilitySvc *cpu.VulnerabilitySvc) Option {
+	return func(svc *Svc) {
+		svc.cpuVulnerabilitySvc = vulnerabilitySvc
+	}
+}
+
 func WithDMI(dmiSvc *dmi.Svc) Option {
 	return func(svc *Svc) {
 		svc.dmiSvc = dmiSvc
@@ -58,6 +86,36 @@ func WithMem(memInfoSvc *mem.InfoSvc) Option {
 	}
 }
 
+func WithHugePages(hugePagesSvc *mem.HugePagesSvc) Option {
+	return func(svc *Svc) {
+		svc.hugePagesSvc = hugePagesSvc
+	}
+}
+
+func WithMemoryBlocks(memoryBlocksSvc *mem.BlocksSvc) Option {
+	return func(svc *Svc) {
+		svc.memoryBlocksSvc = memoryBlocksSvc
+	}
+}
+
+func WithEDAC(edacSvc *edac.Svc) Option {
+	return func(svc *Svc) {
+		svc.edacSvc = edacSvc
+	}
+}
+
+func WithNVDIMM(nvdimmSvc *nvdimm.Svc) Option {
+	return func(svc *Svc) {
+		svc.nvdimmSvc = nvdimmSvc
+	}
+}
+
+func WithCXL(cxlSvc *cxl.Svc) Option {
+	return func(svc *Svc) {
+		svc.cxlSvc = cxlSvc
+	}
+}
+
 func WithMLCPerf(mlcPerfSvc *mlc.PerfSvc) Option {
 	return func(svc *Svc) {
 		svc.mlcPerfSvc = mlcPerfSvc
@@ -105,3 +163,9 @@ func WithDistro(distroSvc *distro.Svc) Option {
 		svc.distroSvc = distroSvc
 	}
 }
+
+func WithKernel(kernelSvc *kernel.Svc) Option {
+	return func(svc *Svc) {
+		svc.kernelSvc = kernelSvc
+	}
+}
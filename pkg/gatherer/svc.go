@@ -4,22 +4,29 @@
 package gatherer
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/onmetal/inventory/pkg/mlc"
 
 	"github.com/onmetal/inventory/pkg/block"
 	"github.com/onmetal/inventory/pkg/cpu"
+	"github.com/onmetal/inventory/pkg/cxl"
 	"github.com/onmetal/inventory/pkg/distro"
 	"github.com/onmetal/inventory/pkg/dmi"
+	"github.com/onmetal/inventory/pkg/edac"
 	"github.com/onmetal/inventory/pkg/host"
 	"github.com/onmetal/inventory/pkg/inventory"
 	"github.com/onmetal/inventory/pkg/ipmi"
+	"github.com/onmetal/inventory/pkg/kernel"
 	"github.com/onmetal/inventory/pkg/lldp"
 	"github.com/onmetal/inventory/pkg/mem"
 	"github.com/onmetal/inventory/pkg/netlink"
 	"github.com/onmetal/inventory/pkg/nic"
 	"github.com/onmetal/inventory/pkg/numa"
+	"github.com/onmetal/inventory/pkg/nvdimm"
 	"github.com/onmetal/inventory/pkg/pci"
 	"github.com/onmetal/inventory/pkg/printer"
 	"github.com/onmetal/inventory/pkg/virt"
@@ -28,20 +35,30 @@ import (
 type Svc struct {
 	printer *printer.Svc
 
-	dmiSvc     *dmi.Svc
-	numaSvc    *numa.Svc
-	blockSvc   *block.Svc
-	pciSvc     *pci.Svc
-	cpuInfoSvc *cpu.InfoSvc
-	memInfoSvc *mem.InfoSvc
-	mlcPerfSvc *mlc.PerfSvc
-	lldpSvc    *lldp.Svc
-	nicSvc     *nic.Svc
-	ipmiSvc    *ipmi.Svc
-	netlinkSvc *netlink.Svc
-	virtSvc    *virt.Svc
-	hostSvc    *host.Svc
-	distroSvc  *distro.Svc
+	dmiSvc              *dmi.Svc
+	numaSvc             *numa.Svc
+	blockSvc            *block.Svc
+	pciSvc              *pci.Svc
+	cpuInfoSvc          *cpu.InfoSvc
+	cpuTopologySvc      *cpu.TopologySvc
+	cpuMicroarchSvc     *cpu.MicroarchSvc
+	cpuPowerSvc         *cpu.PowerSvc
+	cpuVulnerabilitySvc *cpu.VulnerabilitySvc
+	memInfoSvc          *mem.InfoSvc
+	hugePagesSvc        *mem.HugePagesSvc
+	memoryBlocksSvc     *mem.BlocksSvc
+	edacSvc             *edac.Svc
+	nvdimmSvc           *nvdimm.Svc
+	cxlSvc              *cxl.Svc
+	mlcPerfSvc          *mlc.PerfSvc
+	lldpSvc             *lldp.Svc
+	nicSvc              *nic.Svc
+	ipmiSvc             *ipmi.Svc
+	netlinkSvc          *netlink.Svc
+	virtSvc             *virt.Svc
+	hostSvc             *host.Svc
+	distroSvc           *distro.Svc
+	kernelSvc           *kernel.Svc
 }
 
 func NewSvc(printer *printer.Svc, opts ...Option) *Svc {
@@ -60,21 +77,34 @@ func (s *Svc) Gather() *inventory.Inventory {
 	setters := []func(inventory *inventory.Inventory) error{
 		s.SetDMI,
 		s.SetCPUInfo,
+		s.SetCPUTopology,
+		s.SetCPUMicroarch,
+		s.SetCPUPower,
+		s.SetCPUVulnerabilities,
 		s.SetMemInfo,
+		s.SetHugePages,
+		s.SetMemoryBlocks,
+		s.SetEDAC,
 		// TODO Not gathering atm on regular run
 		// mlc binary is not included as a dependency yet
 		// Uncomment if dependency is met and benchmarking is required on regular run
 		// s.SetMlcPerf,
 		s.SetNumaNodes,
+		s.SetNVDIMM,
+		s.SetCXL,
 		s.SetBlockDevices,
 		s.SetPCIBusDevices,
 		s.SetIPMIDevices,
 		s.SetNICs,
+		s.SetSlots,
+		s.SetNUMADevices,
+		s.SetHostInterfaces,
 		s.SetLLDPFrames,
 		s.SetNDPFrames,
 		s.SetVirt,
 		s.SetHost,
 		s.SetDistro,
+		s.SetKernel,
 	}
 
 	return s.GatherInOrder(setters)
@@ -111,6 +141,42 @@ func (s *Svc) SetCPUInfo(inv *inventory.Inventory) error {
 	return nil
 }
 
+func (s *Svc) SetCPUTopology(inv *inventory.Inventory) error {
+	data, err := s.cpuTopologySvc.GetTopology()
+	if err != nil {
+		return errors.Wrap(err, "unable to get cpu topology")
+	}
+	inv.CPUTopology = data
+	return nil
+}
+
+func (s *Svc) SetCPUMicroarch(inv *inventory.Inventory) error {
+	data, err := s.cpuMicroarchSvc.GetMicroarchitecture()
+	if err != nil {
+		return errors.Wrap(err, "unable to get cpu microarchitecture")
+	}
+	inv.CPUMicroarch = data
+	return nil
+}
+
+func (s *Svc) SetCPUPower(inv *inventory.Inventory) error {
+	data, err := s.cpuPowerSvc.GetPower()
+	if err != nil {
+		return errors.Wrap(err, "unable to get cpu power management")
+	}
+	inv.CPUPower = data
+	return nil
+}
+
+func (s *Svc) SetCPUVulnerabilities(inv *inventory.Inventory) error {
+	data, err := s.cpuVulnerabilitySvc.GetVulnerabilities()
+	if err != nil {
+		return errors.Wrap(err, "unable to get cpu vulnerabilities")
+	}
+	inv.CPUVulnerabilities = data
+	return nil
+}
+
 func (s *Svc) SetMemInfo(inv *inventory.Inventory) error {
 	data, err := s.memInfoSvc.GetInfo()
 	if err != nil {
@@ -120,6 +186,98 @@ func (s *Svc) SetMemInfo(inv *inventory.Inventory) error {
 	return nil
 }
 
+func (s *Svc) SetHugePages(inv *inventory.Inventory) error {
+	data, err := s.hugePagesSvc.GetHugePages()
+	if err != nil {
+		return errors.Wrap(err, "unable to get hugepages")
+	}
+	inv.HugePages = data
+	return nil
+}
+
+func (s *Svc) SetMemoryBlocks(inv *inventory.Inventory) error {
+	data, err := s.memoryBlocksSvc.GetBlocks()
+	if err != nil {
+		return errors.Wrap(err, "unable to get memory blocks")
+	}
+	inv.MemoryBlocks = data
+	return nil
+}
+
+func (s *Svc) SetEDAC(inv *inventory.Inventory) error {
+	data, err := s.edacSvc.GetData()
+	if err != nil {
+		return errors.Wrap(err, "unable to get edac data")
+	}
+	inv.EDAC = data
+
+	if inv.DMI == nil {
+		return nil
+	}
+	for i := range inv.EDAC {
+		for j := range inv.EDAC[i].DIMMs {
+			dimm := &inv.EDAC[i].DIMMs[j]
+			if device := dimmMemoryDevice(dimm.Label, inv.DMI.MemoryDevices); device != nil {
+				dimm.Locator = device.DeviceLocator
+				dimm.MemoryDeviceHandle = device.Handle
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Svc) SetNVDIMM(inv *inventory.Inventory) error {
+	data, err := s.nvdimmSvc.GetData()
+	if err != nil {
+		return errors.Wrap(err, "unable to get nvdimm data")
+	}
+	inv.NVDIMM = data
+	return nil
+}
+
+func (s *Svc) SetCXL(inv *inventory.Inventory) error {
+	data, err := s.cxlSvc.GetData()
+	if err != nil {
+		return errors.Wrap(err, "unable to get cxl data")
+	}
+	inv.CXL = data
+	return nil
+}
+
+// dimmMemoryDevice finds SMBIOS memory device EDAC DIMM label refers to,
+// ghes_edac labels are "<bank locator> <device locator>", labels set by edac-ctl are usually device locators
+func dimmMemoryDevice(label string, devices []dmi.MemoryDevice) *dmi.MemoryDevice {
+	if label == "" {
+		return nil
+	}
+
+	for i := range devices {
+		device := &devices[i]
+		if device.BankLocator == "" || device.DeviceLocator == "" {
+			continue
+		}
+		if strings.EqualFold(label, device.BankLocator+" "+device.DeviceLocator) {
+			return device
+		}
+	}
+
+	// device locators are not unique on some boards, e.g. "DIMM 0" in every bank
+	var found *dmi.MemoryDevice
+	for i := range devices {
+		device := &devices[i]
+		if device.DeviceLocator == "" || !strings.EqualFold(label, device.DeviceLocator) {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = device
+	}
+
+	return found
+}
+
 func (s *Svc) SetMlcPerf(inv *inventory.Inventory) error {
 	data, err := s.mlcPerfSvc.GetInfo()
 	if err != nil {
@@ -174,6 +332,192 @@ func (s *Svc) SetNICs(inv *inventory.Inventory) error {
 	return nil
 }
 
+// SetSlots joins PCI devices and NICs with SMBIOS slots and onboard devices.
+// Device behind a bridge gets designation of the nearest upstream device
+// that has one, e.g. function of a card behind its own PCIe switch.
+func (s *Svc) SetSlots(inv *inventory.Inventory) error {
+	if inv.DMI == nil {
+		cause := errors.New("no DMI data")
+		return errors.Wrap(cause, "unable to set slots")
+	}
+
+	for i := range inv.PCIBusDevices {
+		for j := range inv.PCIBusDevices[i].Devices {
+			device := &inv.PCIBusDevices[i].Devices[j]
+			device.Slot = s.getPCIDesignation(inv.DMI, device.Address)
+		}
+	}
+
+	for i := range inv.NICs {
+		nic := &inv.NICs[i]
+		if nic.PCIAddress == "" {
+			continue
+		}
+		nic.Slot = s.getPCIDesignation(inv.DMI, nic.PCIAddress)
+	}
+
+	return nil
+}
+
+// SetNUMADevices lists PCI devices, NICs and block devices attached to every NUMA node.
+// PCI device firmware reports no node for is assigned to a node by its local CPUs,
+// if all of them belong to the same node, NICs are located by their PCI devices.
+func (s *Svc) SetNUMADevices(inv *inventory.Inventory) error {
+	if len(inv.NumaNodes) == 0 {
+		cause := errors.New("no NUMA data")
+		return errors.Wrap(cause, "unable to set numa devices")
+	}
+
+	nodeIdxByID := make(map[int]int, len(inv.NumaNodes))
+	nodeIdxByCPU := make(map[int]int)
+	for i := range inv.NumaNodes {
+		node := &inv.NumaNodes[i]
+		node.PCIDevices = make([]string, 0)
+		node.NICs = make([]string, 0)
+		node.BlockDevices = make([]string, 0)
+
+		nodeIdxByID[node.ID] = i
+		for _, cpu := range node.CPUs {
+			nodeIdxByCPU[cpu] = i
+		}
+	}
+
+	pciNodeIdxByAddress := make(map[string]int)
+	for _, bus := range inv.PCIBusDevices {
+		for _, device := range bus.Devices {
+			idx, ok := nodeIdxByID[device.NUMANode]
+			if !ok {
+				idx, ok = localCPUsNodeIdx(device.LocalCPUs, nodeIdxByCPU)
+			}
+			if !ok {
+				continue
+			}
+			pciNodeIdxByAddress[device.Address] = idx
+			inv.NumaNodes[idx].PCIDevices = append(inv.NumaNodes[idx].PCIDevices, device.Address)
+		}
+	}
+
+	for _, nicDevice := range inv.NICs {
+		if nicDevice.PCIAddress == "" {
+			continue
+		}
+		if idx, ok := pciNodeIdxByAddress[nicDevice.PCIAddress]; ok {
+			inv.NumaNodes[idx].NICs = append(inv.NumaNodes[idx].NICs, nicDevice.Name)
+		}
+	}
+
+	for _, blockDevice := range inv.BlockDevices {
+		if idx, ok := nodeIdxByID[blockDevice.NUMANodeID]; ok {
+			inv.NumaNodes[idx].BlockDevices = append(inv.NumaNodes[idx].BlockDevices, blockDevice.Name)
+		}
+	}
+
+	for i := range inv.NumaNodes {
+		sort.Strings(inv.NumaNodes[i].PCIDevices)
+		sort.Strings(inv.NumaNodes[i].NICs)
+		sort.Strings(inv.NumaNodes[i].BlockDevices)
+	}
+
+	return nil
+}
+
+// localCPUsNodeIdx finds a node all local CPUs of device belong to,
+// local CPUs of device without locality are all CPUs of the system, so they span all nodes
+func localCPUsNodeIdx(cpus []int, nodeIdxByCPU map[int]int) (int, bool) {
+	if len(cpus) == 0 {
+		return 0, false
+	}
+
+	idx, ok := nodeIdxByCPU[cpus[0]]
+	if !ok {
+		return 0, false
+	}
+	for _, cpu := range cpus[1:] {
+		if cpuIdx, ok := nodeIdxByCPU[cpu]; !ok || cpuIdx != idx {
+			return 0, false
+		}
+	}
+
+	return idx, true
+}
+
+func (s *Svc) getPCIDesignation(data *dmi.DMI, address string) string {
+	addresses, err := s.pciSvc.GetUpstreamAddresses(address)
+	if err != nil {
+		s.printer.VErr(errors.Wrapf(err, "unable to get upstream devices of %s", address))
+		addresses = []string{address}
+	}
+
+	for _, addr := range addresses {
+		if designation := data.GetPCIDesignation(addr); designation != "" {
+			return designation
+		}
+	}
+
+	return ""
+}
+
+// SetHostInterfaces marks NICs that are exposed by management controller
+// host interfaces. Interfaces are matched by MAC address and PCI address
+// if structure provides them, otherwise USB interfaces are matched by device
+// vendor and product IDs if exactly one USB NIC has them.
+func (s *Svc) SetHostInterfaces(inv *inventory.Inventory) error {
+	if inv.DMI == nil {
+		cause := errors.New("no DMI data")
+		return errors.Wrap(cause, "unable to set host interfaces")
+	}
+
+	for _, hostInterface := range inv.DMI.HostInterfaces {
+		if hostInterface.Device == nil {
+			continue
+		}
+
+		for _, idx := range hostInterfaceNICIdxs(hostInterface.Device, inv.NICs) {
+			inv.NICs[idx].HostInterface = true
+		}
+	}
+
+	return nil
+}
+
+func hostInterfaceNICIdxs(device *dmi.HostInterfaceDevice, nics []nic.Device) []int {
+	idxs := make([]int, 0)
+	switch {
+	case device.MACAddress != "":
+		for i := range nics {
+			if strings.EqualFold(device.MACAddress, nics[i].Address) {
+				idxs = append(idxs, i)
+			}
+		}
+	case device.PCIAddress != "":
+		for i := range nics {
+			if device.PCIAddress == nics[i].PCIAddress {
+				idxs = append(idxs, i)
+			}
+		}
+	case isUSBHostInterfaceDevice(device) && device.VendorID != "" && device.ProductID != "":
+		// IDs are shared by all devices of the same model,
+		// so NIC is not matched by them if there are several
+		for i := range nics {
+			if nics[i].PCIAddress != "" {
+				continue
+			}
+			if device.VendorID == nics[i].VendorID && device.ProductID == nics[i].ProductID {
+				idxs = append(idxs, i)
+			}
+		}
+		if len(idxs) > 1 {
+			return []int{}
+		}
+	}
+
+	return idxs
+}
+
+func isUSBHostInterfaceDevice(device *dmi.HostInterfaceDevice) bool {
+	return device.Type == dmi.CUSBHostInterfaceDeviceType || device.Type == dmi.CUSBV2HostInterfaceDeviceType
+}
+
 func (s *Svc) SetLLDPFrames(inv *inventory.Inventory) error {
 	data, err := s.lldpSvc.GetData()
 	if err != nil {
@@ -223,3 +567,12 @@ func (s *Svc) SetDistro(inv *inventory.Inventory) error {
 	inv.Distro = distroInfo
 	return nil
 }
+
+func (s *Svc) SetKernel(inv *inventory.Inventory) error {
+	kernelInfo, err := s.kernelSvc.GetData()
+	if err != nil {
+		return errors.Wrap(err, "unable to get kernel info")
+	}
+	inv.Kernel = kernelInfo
+	return nil
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package gatherer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/dmi"
+	"github.com/onmetal/inventory/pkg/nic"
+)
+
+func TestHostInterfaceNICIdxs(t *testing.T) {
+	nics := []nic.Device{
+		{Name: "eno1", Address: "aa:bb:cc:dd:ee:01", PCIAddress: "0000:01:00.0", VendorID: "8086", ProductID: "1521"},
+		{Name: "eno2", Address: "aa:bb:cc:dd:ee:02", PCIAddress: "0000:01:00.1", VendorID: "8086", ProductID: "1521"},
+		{Name: "usb0", Address: "aa:bb:cc:dd:ee:03", VendorID: "046b", ProductID: "ffb0"},
+	}
+
+	tests := []struct {
+		name     string
+		device   *dmi.HostInterfaceDevice
+		expected []int
+	}{
+		{
+			name:     "mac address",
+			device:   &dmi.HostInterfaceDevice{Type: dmi.CUSBV2HostInterfaceDeviceType, MACAddress: "AA:BB:CC:DD:EE:03"},
+			expected: []int{2},
+		},
+		{
+			name:     "pci address",
+			device:   &dmi.HostInterfaceDevice{Type: dmi.CPCIV2HostInterfaceDeviceType, PCIAddress: "0000:01:00.1"},
+			expected: []int{1},
+		},
+		{
+			name:     "single usb nic with ids",
+			device:   &dmi.HostInterfaceDevice{Type: dmi.CUSBHostInterfaceDeviceType, VendorID: "046b", ProductID: "ffb0"},
+			expected: []int{2},
+		},
+		{
+			name:     "pci ids are not matched",
+			device:   &dmi.HostInterfaceDevice{Type: dmi.CPCIHostInterfaceDeviceType, VendorID: "8086", ProductID: "1521"},
+			expected: []int{},
+		},
+	}
+
+	for _, test := range tests {
+		idxs := hostInterfaceNICIdxs(test.device, nics)
+		if !reflect.DeepEqual(idxs, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, idxs)
+		}
+	}
+
+	// two usb nics of the same model
+	nics = append(nics, nic.Device{Name: "usb1", VendorID: "046b", ProductID: "ffb0"})
+	device := &dmi.HostInterfaceDevice{Type: dmi.CUSBHostInterfaceDeviceType, VendorID: "046b", ProductID: "ffb0"}
+	if idxs := hostInterfaceNICIdxs(device, nics); len(idxs) != 0 {
+		t.Errorf("expected no nics matched by ambiguous ids, got %v", idxs)
+	}
+}
+
+func TestDIMMMemoryDevice(t *testing.T) {
+	devices := []dmi.MemoryDevice{
+		{Handle: 0x1100, BankLocator: "P0 CHANNEL A", DeviceLocator: "DIMM 0"},
+		{Handle: 0x1101, BankLocator: "P0 CHANNEL B", DeviceLocator: "DIMM 0"},
+		{Handle: 0x1102, BankLocator: "", DeviceLocator: "CPU1_DIMM_C1"},
+	}
+
+	tests := []struct {
+		label          string
+		expectedHandle uint16
+		expectedFound  bool
+	}{
+		{label: "P0 CHANNEL B DIMM 0", expectedHandle: 0x1101, expectedFound: true},
+		{label: "p0 channel a dimm 0", expectedHandle: 0x1100, expectedFound: true},
+		{label: "CPU1_DIMM_C1", expectedHandle: 0x1102, expectedFound: true},
+		// ambiguous device locator
+		{label: "DIMM 0", expectedFound: false},
+		{label: "CPU2_DIMM_A1", expectedFound: false},
+		{label: "", expectedFound: false},
+	}
+
+	for _, test := range tests {
+		device := dimmMemoryDevice(test.label, devices)
+		if (device != nil) != test.expectedFound {
+			t.Errorf("label %q: expected found %t, got %v", test.label, test.expectedFound, device)
+			continue
+		}
+		if device != nil && device.Handle != test.expectedHandle {
+			t.Errorf("label %q: expected handle %#x, got %#x", test.label, test.expectedHandle, device.Handle)
+		}
+	}
+}
+
+func TestLocalCPUsNodeIdx(t *testing.T) {
+	// node 0 has CPUs 0-1, node 1 has CPUs 2-3
+	nodeIdxByCPU := map[int]int{0: 0, 1: 0, 2: 1, 3: 1}
+
+	tests := []struct {
+		name          string
+		cpus          []int
+		expectedIdx   int
+		expectedFound bool
+	}{
+		{name: "single node", cpus: []int{2, 3}, expectedIdx: 1, expectedFound: true},
+		{name: "single CPU", cpus: []int{1}, expectedIdx: 0, expectedFound: true},
+		{name: "device without locality", cpus: []int{0, 1, 2, 3}},
+		{name: "unknown CPU", cpus: []int{2, 4}},
+		{name: "unknown first CPU", cpus: []int{4}},
+		{name: "no CPUs", cpus: []int{}},
+	}
+
+	for _, test := range tests {
+		idx, found := localCPUsNodeIdx(test.cpus, nodeIdxByCPU)
+		if idx != test.expectedIdx || found != test.expectedFound {
+			t.Errorf("%s: expected %d, %t, got %d, %t", test.name, test.expectedIdx, test.expectedFound, idx, found)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/onmetal/inventory/pkg/file"
+)
+
+const (
+	CHostnamePath      = "/etc/hostname"
+	CHostsPath         = "/etc/hosts"
+	CMachineIDPath     = "/etc/machine-id"
+	CDBusMachineIDPath = "/var/lib/dbus/machine-id"
+	CBootIDPath        = "/proc/sys/kernel/random/boot_id"
+	CUptimePath        = "/proc/uptime"
+	CInitUTSNSPath     = "/proc/1/ns/uts"
+)
+
+// getHostname returns hostname of the inventoried system.
+// If root differs from the current one, os.Hostname would return hostname
+// of the container, so it is taken from UTS namespace of the init process
+// or /etc/hostname from the root file system.
+func (s *Svc) getHostname() (string, error) {
+	if path.Clean(s.basePath) == "/" {
+		return os.Hostname()
+	}
+
+	name, err := getUTSHostname(path.Join(s.basePath, CInitUTSNSPath))
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to get hostname from init UTS namespace"))
+	}
+	if name != "" {
+		return name, nil
+	}
+
+	name, err = file.ToString(path.Join(s.basePath, CHostnamePath))
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to get hostname from root file system"))
+	}
+	if name != "" {
+		return name, nil
+	}
+
+	return os.Hostname()
+}
+
+func getUTSHostname(nsPath string) (string, error) {
+	type result struct {
+		name string
+		err  error
+	}
+
+	resChan := make(chan result, 1)
+
+	go func() {
+		// thread is intentionally not unlocked, so it is terminated together with goroutine
+		// instead of being returned to the scheduler inside the foreign namespace
+		runtime.LockOSThread()
+
+		fd, err := unix.Open(nsPath, unix.O_RDONLY|unix.O_CLOEXEC, 0)
+		if err != nil {
+			resChan <- result{err: errors.Wrapf(err, "unable to open %s", nsPath)}
+			return
+		}
+		defer unix.Close(fd)
+
+		if err := unix.Setns(fd, unix.CLONE_NEWUTS); err != nil {
+			resChan <- result{err: errors.Wrapf(err, "unable to enter UTS namespace %s", nsPath)}
+			return
+		}
+
+		uname := unix.Utsname{}
+		if err := unix.Uname(&uname); err != nil {
+			resChan <- result{err: errors.Wrap(err, "unable to get uname")}
+			return
+		}
+
+		resChan <- result{name: unix.ByteSliceToString(uname.Nodename[:])}
+	}()
+
+	res := <-resChan
+	return res.name, res.err
+}
+
+func (s *Svc) getMachineID() (string, error) {
+	for _, thePath := range []string{CMachineIDPath, CDBusMachineIDPath} {
+		machineID, err := file.ToString(path.Join(s.basePath, thePath))
+		if err == nil && machineID != "" {
+			return machineID, nil
+		}
+	}
+
+	return "", errors.Errorf("neither %s nor %s contains machine id", CMachineIDPath, CDBusMachineIDPath)
+}
+
+func (s *Svc) getBootID() (string, error) {
+	return file.ToString(path.Join(s.basePath, CBootIDPath))
+}
+
+func (s *Svc) getUptime() (time.Duration, error) {
+	uptimePath := path.Join(s.basePath, CUptimePath)
+	str, err := file.ToString(uptimePath)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to read %s", uptimePath)
+	}
+
+	// first value is uptime in seconds, second is idle time
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return 0, errors.Errorf("unexpected uptime format %s", str)
+	}
+
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to parse uptime %s", fields[0])
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// getFQDN looks for the fully qualified name of the host,
+// either set as a hostname or as a canonical name in /etc/hosts
+func (s *Svc) getFQDN(hostname string) (string, error) {
+	if strings.Contains(hostname, ".") {
+		return hostname, nil
+	}
+
+	hostsPath := path.Join(s.basePath, CHostsPath)
+	hostsData, err := os.ReadFile(hostsPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to read %s", hostsPath)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(hostsData))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		// <address> <canonical name> [<aliases>...]
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		for _, name := range fields[1:] {
+			if strings.HasPrefix(name, hostname+".") {
+				return name, nil
+			}
+		}
+	}
+
+	return hostname, nil
+}
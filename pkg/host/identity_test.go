@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func writeFile(t *testing.T, root string, thePath string, data string) {
+	t.Helper()
+	fullPath := path.Join(root, thePath)
+	if err := os.MkdirAll(path.Dir(fullPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetHostname(t *testing.T) {
+	root := t.TempDir()
+	// init UTS namespace is missing under the temporary root
+	writeFile(t, root, CHostnamePath, "node-01\n")
+
+	name, err := NewSvc(printer.NewSvc(false), nil, nil, root).getHostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "node-01" {
+		t.Errorf("expected node-01, got %s", name)
+	}
+
+	// agent's own hostname is used if root has none
+	expected, err := os.Hostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err = NewSvc(printer.NewSvc(false), nil, nil, t.TempDir()).getHostname()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != expected {
+		t.Errorf("expected %s, got %s", expected, name)
+	}
+}
+
+func TestGetMachineID(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name: "etc machine id",
+			files: map[string]string{
+				CMachineIDPath:     "0f1e2d3c4b5a69788796a5b4c3d2e1f0\n",
+				CDBusMachineIDPath: "ffffffffffffffffffffffffffffffff\n",
+			},
+			expected: "0f1e2d3c4b5a69788796a5b4c3d2e1f0",
+		},
+		{
+			name: "empty etc machine id",
+			files: map[string]string{
+				CMachineIDPath:     "\n",
+				CDBusMachineIDPath: "ffffffffffffffffffffffffffffffff\n",
+			},
+			expected: "ffffffffffffffffffffffffffffffff",
+		},
+		{
+			name:     "dbus machine id only",
+			files:    map[string]string{CDBusMachineIDPath: "ffffffffffffffffffffffffffffffff\n"},
+			expected: "ffffffffffffffffffffffffffffffff",
+		},
+		{
+			name:      "no machine id",
+			files:     map[string]string{},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		root := t.TempDir()
+		for thePath, data := range test.files {
+			writeFile(t, root, thePath, data)
+		}
+
+		machineID, err := NewSvc(printer.NewSvc(false), nil, nil, root).getMachineID()
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %s", test.name, machineID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if machineID != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, machineID)
+		}
+	}
+}
+
+func TestGetBootID(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, CBootIDPath, "8d2b7c1e-4c7a-4a8e-9f3e-0b6c5d4e3f21\n")
+
+	bootID, err := NewSvc(printer.NewSvc(false), nil, nil, root).getBootID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bootID != "8d2b7c1e-4c7a-4a8e-9f3e-0b6c5d4e3f21" {
+		t.Errorf("expected 8d2b7c1e-4c7a-4a8e-9f3e-0b6c5d4e3f21, got %s", bootID)
+	}
+}
+
+func TestGetUptime(t *testing.T) {
+	tests := []struct {
+		data      string
+		expected  time.Duration
+		expectErr bool
+	}{
+		{data: "350735.47 234388.90\n", expected: 350735*time.Second + 470*time.Millisecond},
+		{data: "12\n", expected: 12 * time.Second},
+		{data: "\n", expectErr: true},
+		{data: "uptime 12\n", expectErr: true},
+	}
+
+	for _, test := range tests {
+		root := t.TempDir()
+		writeFile(t, root, CUptimePath, test.data)
+
+		uptime, err := NewSvc(printer.NewSvc(false), nil, nil, root).getUptime()
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%q: expected error, got %s", test.data, uptime)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", test.data, err)
+			continue
+		}
+		// float seconds are not exact
+		if diff := uptime - test.expected; diff < -time.Millisecond || diff > time.Millisecond {
+			t.Errorf("%q: expected %s, got %s", test.data, test.expected, uptime)
+		}
+	}
+
+	if _, err := NewSvc(printer.NewSvc(false), nil, nil, t.TempDir()).getUptime(); err == nil {
+		t.Error("missing uptime: expected error")
+	}
+}
+
+func TestGetFQDN(t *testing.T) {
+	const hosts = `127.0.0.1 localhost
+# 10.0.0.2 node-01.old.example.com
+10.0.0.1 node-01.dc1.example.com node-01 # primary
+10.0.0.3 node-010.dc1.example.com node-010
+`
+
+	tests := []struct {
+		hostname string
+		expected string
+	}{
+		{hostname: "node-01", expected: "node-01.dc1.example.com"},
+		{hostname: "node-010", expected: "node-010.dc1.example.com"},
+		{hostname: "node-02", expected: "node-02"},
+		{hostname: "node-03.example.com", expected: "node-03.example.com"},
+	}
+
+	root := t.TempDir()
+	writeFile(t, root, CHostsPath, hosts)
+	svc := NewSvc(printer.NewSvc(false), nil, nil, root)
+
+	for _, test := range tests {
+		fqdn, err := svc.getFQDN(test.hostname)
+		if err != nil {
+			t.Errorf("%s: %v", test.hostname, err)
+			continue
+		}
+		if fqdn != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.hostname, test.expected, fqdn)
+		}
+	}
+
+	if _, err := NewSvc(printer.NewSvc(false), nil, nil, t.TempDir()).getFQDN("node-01"); err == nil {
+		t.Error("missing hosts: expected error")
+	}
+}
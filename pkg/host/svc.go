@@ -4,56 +4,147 @@
 package host
 
 import (
-	"os"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/onmetal/inventory/pkg/dmi"
 	"github.com/onmetal/inventory/pkg/printer"
 	"github.com/onmetal/inventory/pkg/utils"
+	"github.com/onmetal/inventory/pkg/virt"
 )
 
 type Info struct {
-	Type string
-	Name string
+	Type      string
+	NetworkOS string
+	// DetectedBy is a name of the type rule that classified the host
+	DetectedBy string
+	Name       string
+	FQDN       string
+	MachineID  string
+	BootID     string
+	Uptime     time.Duration
+}
+
+// IsSwitch tells whether host is a switch running any network OS
+func (i *Info) IsSwitch() bool {
+	return i.Type == utils.CSwitchType
+}
+
+// IsSONiC tells whether host is a switch running SONiC,
+// which data is partially stored in redis
+func (i *Info) IsSONiC() bool {
+	return i.IsSwitch() && i.NetworkOS == CSONiCNetworkOS
+}
+
+// IsFrontPanelPort tells whether interface is a switch port
+// that is not backed by a PCI device
+func (i *Info) IsFrontPanelPort(name string) bool {
+	if !i.IsSwitch() {
+		return false
+	}
+
+	prefix, ok := CNetworkOSPortPrefixes[i.NetworkOS]
+	if !ok {
+		return false
+	}
+
+	return strings.HasPrefix(name, prefix)
 }
 
 type Svc struct {
 	printer           *printer.Svc
+	dmiSvc            *dmi.Svc
+	virtSvc           *virt.Svc
+	basePath          string
 	switchVersionPath string
+	typeRules         []TypeRule
+	detection         *Detection
+	detectionDMI      *dmi.DMI
+	detectionDMIRead  bool
 }
 
-func NewSvc(printer *printer.Svc, basePath string) *Svc {
-	return &Svc{
+func NewSvc(printer *printer.Svc, dmiSvc *dmi.Svc, virtSvc *virt.Svc, basePath string) *Svc {
+	svc := &Svc{
 		printer:           printer,
+		dmiSvc:            dmiSvc,
+		virtSvc:           virtSvc,
+		basePath:          basePath,
 		switchVersionPath: path.Join(basePath, utils.CVersionFilePath),
 	}
+	svc.typeRules = svc.defaultTypeRules()
+
+	return svc
+}
+
+// SetTypeRules replaces default host type detection rules,
+// rules are applied in order and first match wins
+func (s *Svc) SetTypeRules(rules []TypeRule) {
+	s.typeRules = rules
+	s.detection = nil
 }
 
 func (s *Svc) GetData() (*Info, error) {
-	hostType, err := getHostType(s.switchVersionPath)
-	if err != nil {
-		return nil, errors.Wrap(err, "unable to determine host type")
-	}
+	detection := s.getDetection()
 
 	info := Info{}
-	name, err := os.Hostname()
+	name, err := s.getHostname()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get hostname")
 	}
 	info.Name = name
-	info.Type = hostType
+	info.Type = detection.Type
+	info.NetworkOS = detection.NetworkOS
+	info.DetectedBy = detection.Rule
+
+	if info.FQDN, err = s.getFQDN(name); err != nil {
+		s.printer.VErr(errors.Wrap(err, "failed to get FQDN"))
+	}
+	if info.MachineID, err = s.getMachineID(); err != nil {
+		s.printer.VErr(errors.Wrap(err, "failed to get machine id"))
+	}
+	if info.BootID, err = s.getBootID(); err != nil {
+		s.printer.VErr(errors.Wrap(err, "failed to get boot id"))
+	}
+	if info.Uptime, err = s.getUptime(); err != nil {
+		s.printer.VErr(errors.Wrap(err, "failed to get uptime"))
+	}
+
 	return &info, nil
 }
 
-func getHostType(versionFile string) (string, error) {
-	//todo: determining how to check host type without checking files
-	if _, err := os.Stat(versionFile); err != nil {
-		if !os.IsNotExist(err) {
-			return "", err
-		} else {
-			return utils.CMachineType, nil
+// getDetection runs type rules once, since host type is requested by most of the services
+func (s *Svc) getDetection() *Detection {
+	if s.detection != nil {
+		return s.detection
+	}
+
+	// DMI read by rules is dropped after detection
+	defer func() {
+		s.detectionDMI = nil
+		s.detectionDMIRead = false
+	}()
+
+	for _, rule := range s.typeRules {
+		detection, err := rule.Detect()
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to apply host type rule %s", rule.Name))
+			continue
+		}
+		if detection == nil {
+			continue
 		}
+
+		detection.Rule = rule.Name
+		s.detection = detection
+		return s.detection
+	}
+
+	s.detection = &Detection{
+		Type: utils.CMachineType,
+		Rule: CDefaultTypeRuleName,
 	}
-	return utils.CSwitchType, nil
+	return s.detection
 }
@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/dmi"
+	"github.com/onmetal/inventory/pkg/utils"
+	"github.com/onmetal/inventory/pkg/virt"
+)
+
+const (
+	CSONiCNetworkOS   = "SONiC"
+	CCumulusNetworkOS = "Cumulus Linux"
+	CONLNetworkOS     = "Open Network Linux"
+	CEOSNetworkOS     = "Arista EOS"
+	COPXNetworkOS     = "OpenSwitch OPX"
+	CUnknownNetworkOS = "Unknown"
+
+	CSONiCPortPrefix  = "Ethernet"
+	CSwitchPortPrefix = "swp"
+	CEOSPortPrefix    = "Ethernet"
+	// OPX names ports by unit, slot and port, e.g. e101-001-0
+	COPXPortPrefix = "e101-"
+
+	CDeviceTreeModelPath  = "/proc/device-tree/model"
+	CSystemdContainerPath = "/run/systemd/container"
+	CPodmanContainerPath  = "/run/.containerenv"
+	CDockerContainerPath  = "/.dockerenv"
+
+	CDefaultTypeRuleName = "default"
+
+	CDPUModelsPattern = "(?i)(bluefield|pensando|octeon|mount evans|\\bipu\\b|stingray|fungible|\\bdpu\\b|smartnic)"
+)
+
+var CDPUModelsRegexp = regexp.MustCompile(CDPUModelsPattern)
+
+type NetworkOSFile struct {
+	Path      string
+	NetworkOS string
+}
+
+// CNetworkOSFiles are files known to be shipped only
+// with the specific network OS, checked in order
+var CNetworkOSFiles = []NetworkOSFile{
+	{Path: "/etc/cumulus", NetworkOS: CCumulusNetworkOS},
+	{Path: "/etc/onl/platform", NetworkOS: CONLNetworkOS},
+	{Path: "/etc/Eos-release", NetworkOS: CEOSNetworkOS},
+	{Path: "/etc/opx", NetworkOS: COPXNetworkOS},
+}
+
+// CNetworkOSPortPrefixes contains prefixes of the front panel ports
+// that are not backed by PCI devices on switches
+var CNetworkOSPortPrefixes = map[string]string{
+	CSONiCNetworkOS:   CSONiCPortPrefix,
+	CCumulusNetworkOS: CSwitchPortPrefix,
+	CONLNetworkOS:     CSwitchPortPrefix,
+	CEOSNetworkOS:     CEOSPortPrefix,
+	COPXNetworkOS:     COPXPortPrefix,
+}
+
+type Detection struct {
+	Type      string
+	NetworkOS string
+	Rule      string
+}
+
+// TypeRule checks whether host could be classified by it
+// and returns nil detection if it could not
+type TypeRule struct {
+	Name   string
+	Detect func() (*Detection, error)
+}
+
+func (s *Svc) defaultTypeRules() []TypeRule {
+	return []TypeRule{
+		{Name: "sonic", Detect: s.detectSONiC},
+		{Name: "network-os", Detect: s.detectNetworkOS},
+		{Name: "dpu", Detect: s.detectDPU},
+		// agent itself runs in a container on switches and DPUs,
+		// so container is checked after them and only if root is not the agent's own one
+		{Name: "container", Detect: s.detectContainer},
+		{Name: "dmi-switch", Detect: s.detectDMISwitch},
+		{Name: "virt", Detect: s.detectVirt},
+	}
+}
+
+func (s *Svc) detectContainer() (*Detection, error) {
+	// marker files of the agent's own container are visible under /,
+	// they tell nothing about the inventoried host
+	if path.Clean(s.basePath) == "/" {
+		return nil, nil
+	}
+
+	for _, thePath := range []string{CSystemdContainerPath, CPodmanContainerPath, CDockerContainerPath} {
+		exists, err := pathExists(path.Join(s.basePath, thePath))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to check %s", thePath)
+		}
+		if exists {
+			return &Detection{Type: utils.CContainerType}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Svc) detectSONiC() (*Detection, error) {
+	exists, err := pathExists(s.switchVersionPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to check %s", s.switchVersionPath)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return &Detection{Type: utils.CSwitchType, NetworkOS: CSONiCNetworkOS}, nil
+}
+
+func (s *Svc) detectNetworkOS() (*Detection, error) {
+	for _, networkOSFile := range CNetworkOSFiles {
+		exists, err := pathExists(path.Join(s.basePath, networkOSFile.Path))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to check %s", networkOSFile.Path)
+		}
+		if exists {
+			return &Detection{Type: utils.CSwitchType, NetworkOS: networkOSFile.NetworkOS}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Svc) detectDPU() (*Detection, error) {
+	models := make([]string, 0)
+
+	model, err := s.getDeviceTreeModel()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get device tree model")
+	}
+	if model != "" {
+		models = append(models, model)
+	}
+
+	if data := s.getDMI(); data != nil {
+		if data.SystemInformation != nil {
+			models = append(models, data.SystemInformation.ProductName, data.SystemInformation.Family)
+		}
+		for _, board := range data.BoardInformation {
+			models = append(models, board.Product)
+		}
+	}
+
+	for _, m := range models {
+		if CDPUModelsRegexp.MatchString(m) {
+			return &Detection{Type: utils.CDPUType}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Svc) detectDMISwitch() (*Detection, error) {
+	data := s.getDMI()
+	if data == nil {
+		return nil, nil
+	}
+
+	for _, board := range data.BoardInformation {
+		if board.Type == dmi.CConnectivitySwitchBoardType {
+			return &Detection{Type: utils.CSwitchType, NetworkOS: CUnknownNetworkOS}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Svc) detectVirt() (*Detection, error) {
+	if s.virtSvc == nil {
+		return nil, nil
+	}
+
+	data, err := s.virtSvc.GetData()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get virtualization data")
+	}
+	if data.Type == virt.CTypeNone {
+		return nil, nil
+	}
+
+	return &Detection{Type: utils.CVirtualMachineType}, nil
+}
+
+func (s *Svc) getDeviceTreeModel() (string, error) {
+	data, err := os.ReadFile(path.Join(s.basePath, CDeviceTreeModelPath))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read device tree model")
+	}
+
+	// device tree strings are null terminated
+	return strings.TrimSpace(strings.TrimRight(string(data), "\x00")), nil
+}
+
+// getDMI reads DMI once per detection, as several rules check it
+func (s *Svc) getDMI() *dmi.DMI {
+	if s.dmiSvc == nil || s.detectionDMIRead {
+		return s.detectionDMI
+	}
+	s.detectionDMIRead = true
+
+	data, err := s.dmiSvc.GetData()
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to get dmi data for host type detection"))
+		return nil
+	}
+	s.detectionDMI = data
+
+	return data
+}
+
+func pathExists(thePath string) (bool, error) {
+	_, err := os.Stat(thePath)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+	"github.com/onmetal/inventory/pkg/utils"
+)
+
+func TestGetDetection(t *testing.T) {
+	tests := []struct {
+		name              string
+		files             []string
+		expectedType      string
+		expectedNetworkOS string
+		expectedRule      string
+	}{
+		{
+			name:         "machine",
+			files:        []string{},
+			expectedType: utils.CMachineType,
+			expectedRule: CDefaultTypeRuleName,
+		},
+		{
+			name:         "container",
+			files:        []string{CDockerContainerPath},
+			expectedType: utils.CContainerType,
+			expectedRule: "container",
+		},
+		{
+			name:              "sonic in container",
+			files:             []string{utils.CVersionFilePath, CDockerContainerPath},
+			expectedType:      utils.CSwitchType,
+			expectedNetworkOS: CSONiCNetworkOS,
+			expectedRule:      "sonic",
+		},
+		{
+			name:              "eos in container",
+			files:             []string{"/etc/Eos-release", CPodmanContainerPath},
+			expectedType:      utils.CSwitchType,
+			expectedNetworkOS: CEOSNetworkOS,
+			expectedRule:      "network-os",
+		},
+		{
+			name:              "network os files are checked in order",
+			files:             []string{"/etc/opx", "/etc/cumulus"},
+			expectedType:      utils.CSwitchType,
+			expectedNetworkOS: CCumulusNetworkOS,
+			expectedRule:      "network-os",
+		},
+	}
+
+	for _, test := range tests {
+		root := t.TempDir()
+		for _, file := range test.files {
+			thePath := path.Join(root, file)
+			if err := os.MkdirAll(path.Dir(thePath), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(thePath, []byte{}, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		detection := NewSvc(printer.NewSvc(false), nil, nil, root).getDetection()
+		if detection.Type != test.expectedType || detection.NetworkOS != test.expectedNetworkOS || detection.Rule != test.expectedRule {
+			t.Errorf("%s: expected %s/%s by %s, got %s/%s by %s", test.name,
+				test.expectedType, test.expectedNetworkOS, test.expectedRule,
+				detection.Type, detection.NetworkOS, detection.Rule)
+		}
+	}
+}
+
+func TestDetectContainerUnderOwnRoot(t *testing.T) {
+	// marker files seen under / belong to the agent's own container
+	for _, root := range []string{"/", "//"} {
+		detection, err := NewSvc(printer.NewSvc(false), nil, nil, root).detectContainer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if detection != nil {
+			t.Errorf("root %s: expected no detection, got %+v", root, detection)
+		}
+	}
+}
+
+func TestIsFrontPanelPort(t *testing.T) {
+	tests := []struct {
+		networkOS string
+		name      string
+		expected  bool
+	}{
+		{networkOS: CSONiCNetworkOS, name: "Ethernet0", expected: true},
+		{networkOS: CSONiCNetworkOS, name: "eth0", expected: false},
+		{networkOS: CEOSNetworkOS, name: "Ethernet1", expected: true},
+		{networkOS: CEOSNetworkOS, name: "eth0", expected: false},
+		{networkOS: CCumulusNetworkOS, name: "swp1", expected: true},
+		{networkOS: COPXNetworkOS, name: "e101-001-0", expected: true},
+		{networkOS: COPXNetworkOS, name: "eth0", expected: false},
+		{networkOS: CUnknownNetworkOS, name: "Ethernet0", expected: false},
+	}
+
+	for _, test := range tests {
+		info := &Info{Type: utils.CSwitchType, NetworkOS: test.networkOS}
+		if actual := info.IsFrontPanelPort(test.name); actual != test.expected {
+			t.Errorf("%s port %s: expected %t, got %t", test.networkOS, test.name, test.expected, actual)
+		}
+	}
+
+	machine := &Info{Type: utils.CMachineType}
+	if machine.IsFrontPanelPort("Ethernet0") {
+		t.Error("expected no front panel ports on machine")
+	}
+}
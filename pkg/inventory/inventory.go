@@ -6,33 +6,47 @@ package inventory
 import (
 	"github.com/onmetal/inventory/pkg/block"
 	"github.com/onmetal/inventory/pkg/cpu"
+	"github.com/onmetal/inventory/pkg/cxl"
 	"github.com/onmetal/inventory/pkg/distro"
 	"github.com/onmetal/inventory/pkg/dmi"
+	"github.com/onmetal/inventory/pkg/edac"
 	"github.com/onmetal/inventory/pkg/host"
 	"github.com/onmetal/inventory/pkg/ipmi"
+	"github.com/onmetal/inventory/pkg/kernel"
 	"github.com/onmetal/inventory/pkg/lldp/frame"
 	"github.com/onmetal/inventory/pkg/mem"
 	"github.com/onmetal/inventory/pkg/mlc"
 	"github.com/onmetal/inventory/pkg/netlink"
 	"github.com/onmetal/inventory/pkg/nic"
 	"github.com/onmetal/inventory/pkg/numa"
+	"github.com/onmetal/inventory/pkg/nvdimm"
 	"github.com/onmetal/inventory/pkg/pci"
 	"github.com/onmetal/inventory/pkg/virt"
 )
 
 type Inventory struct {
-	DMI            *dmi.DMI
-	MemInfo        *mem.Info
-	MlcPerf        *mlc.Perf
-	CPUInfo        []cpu.Info
-	NumaNodes      []numa.Node
-	BlockDevices   []block.Device
-	PCIBusDevices  []pci.Bus
-	IPMIDevices    []ipmi.Device
-	NICs           []nic.Device
-	LLDPFrames     []frame.Frame
-	NDPFrames      []netlink.IPv6Neighbour
-	Virtualization *virt.Virtualization
-	Host           *host.Info
-	Distro         *distro.Distro
+	DMI                *dmi.DMI
+	MemInfo            *mem.Info
+	HugePages          []mem.HugePages
+	MemoryBlocks       *mem.Blocks
+	EDAC               []edac.MemoryController
+	MlcPerf            *mlc.Perf
+	CPUInfo            []cpu.Info
+	CPUTopology        *cpu.Topology
+	CPUMicroarch       *cpu.Microarchitecture
+	CPUPower           *cpu.Power
+	CPUVulnerabilities []cpu.Vulnerability
+	NumaNodes          []numa.Node
+	NVDIMM             *nvdimm.NVDIMM
+	CXL                *cxl.CXL
+	BlockDevices       []block.Device
+	PCIBusDevices      []pci.Bus
+	IPMIDevices        []ipmi.Device
+	NICs               []nic.Device
+	LLDPFrames         []frame.Frame
+	NDPFrames          []netlink.IPv6Neighbour
+	Virtualization     *virt.Virtualization
+	Host               *host.Info
+	Distro             *distro.Distro
+	Kernel             *kernel.Kernel
 }
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package kernel
+
+type BootMode string
+
+const (
+	CUEFIBootMode   BootMode = "UEFI"
+	CLegacyBootMode BootMode = "BIOS"
+)
+
+type TaintFlag struct {
+	Bit         uint
+	Letter      string
+	Description string
+}
+
+// CTaintFlags describes bits of /proc/sys/kernel/tainted,
+// see https://docs.kernel.org/admin-guide/tainted-kernels.html
+var CTaintFlags = []TaintFlag{
+	{0, "P", "proprietary module was loaded"},
+	{1, "F", "module was force loaded"},
+	{2, "S", "kernel running on an out of specification system"},
+	{3, "R", "module was force unloaded"},
+	{4, "M", "processor reported a Machine Check Exception"},
+	{5, "B", "bad page referenced or some unexpected page flags"},
+	{6, "U", "taint requested by userspace application"},
+	{7, "D", "kernel died recently, i.e. there was an OOPS or BUG"},
+	{8, "A", "ACPI table overridden by user"},
+	{9, "W", "kernel issued warning"},
+	{10, "C", "staging driver was loaded"},
+	{11, "I", "workaround for bug in platform firmware applied"},
+	{12, "O", "externally-built (out-of-tree) module was loaded"},
+	{13, "E", "unsigned module was loaded"},
+	{14, "L", "soft lockup occurred"},
+	{15, "K", "kernel has been live patched"},
+	{16, "X", "auxiliary taint, defined for and used by distros"},
+	{17, "T", "kernel was built with the struct randomization plugin"},
+	{18, "N", "an in-kernel test has been run"},
+}
+
+type Module struct {
+	Name string
+	Size uint64
+	// RefCount is a number of module users
+	RefCount  uint64
+	DependsOn []string
+	State     string
+	// Taints are letters of taint flags module brings, e.g. O or E
+	Taints     string
+	Version    string
+	SrcVersion string
+}
+
+type Boot struct {
+	Mode       BootMode
+	SecureBoot bool
+	SetupMode  bool
+}
+
+type Kernel struct {
+	Release     string
+	Version     string
+	CommandLine string
+	Modules     []Module
+	Tainted     uint64
+	TaintFlags  []TaintFlag
+	Boot        *Boot
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package kernel
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CKernelReleasePath = "/proc/sys/kernel/osrelease"
+	CKernelVersionPath = "/proc/sys/kernel/version"
+	CCommandLinePath   = "/proc/cmdline"
+	CModulesPath       = "/proc/modules"
+	CSysModulePath     = "/sys/module"
+	CTaintedPath       = "/proc/sys/kernel/tainted"
+	CEFIPath           = "/sys/firmware/efi"
+	CEFIVarsPath       = "/sys/firmware/efi/efivars"
+
+	CModuleVersionPath    = "/version"
+	CModuleSrcVersionPath = "/srcversion"
+
+	CEFIGlobalVariableGUID = "8be4df61-93ca-11d2-aa0d-00e098032b8c"
+	CSecureBootVariable    = "SecureBoot-" + CEFIGlobalVariableGUID
+	CSetupModeVariable     = "SetupMode-" + CEFIGlobalVariableGUID
+
+	// efivarfs files start with 4 bytes of variable attributes
+	CEFIVariableAttributesLength = 4
+)
+
+type Svc struct {
+	printer           *printer.Svc
+	kernelReleasePath string
+	kernelVersionPath string
+	commandLinePath   string
+	modulesPath       string
+	sysModulePath     string
+	taintedPath       string
+	efiPath           string
+	efiVarsPath       string
+}
+
+func NewSvc(printer *printer.Svc, basePath string) *Svc {
+	return &Svc{
+		printer:           printer,
+		kernelReleasePath: path.Join(basePath, CKernelReleasePath),
+		kernelVersionPath: path.Join(basePath, CKernelVersionPath),
+		commandLinePath:   path.Join(basePath, CCommandLinePath),
+		modulesPath:       path.Join(basePath, CModulesPath),
+		sysModulePath:     path.Join(basePath, CSysModulePath),
+		taintedPath:       path.Join(basePath, CTaintedPath),
+		efiPath:           path.Join(basePath, CEFIPath),
+		efiVarsPath:       path.Join(basePath, CEFIVarsPath),
+	}
+}
+
+func (s *Svc) GetData() (*Kernel, error) {
+	release, err := file.ToString(s.kernelReleasePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get kernel release")
+	}
+
+	kernel := &Kernel{
+		Release: release,
+	}
+
+	defs := []func(*Kernel) error{
+		s.setVersion,
+		s.setCommandLine,
+		s.setModules,
+		s.setTainted,
+		s.setBoot,
+	}
+
+	for _, def := range defs {
+		if err := def(kernel); err != nil {
+			s.printer.VErr(errors.Wrap(err, "unable to set kernel property"))
+		}
+	}
+
+	return kernel, nil
+}
+
+func (s *Svc) setVersion(kernel *Kernel) error {
+	version, err := file.ToString(s.kernelVersionPath)
+	if err != nil {
+		return errors.Wrap(err, "unable to get kernel version")
+	}
+
+	kernel.Version = version
+
+	return nil
+}
+
+func (s *Svc) setCommandLine(kernel *Kernel) error {
+	cmdline, err := file.ToString(s.commandLinePath)
+	if err != nil {
+		return errors.Wrap(err, "unable to get kernel command line")
+	}
+
+	kernel.CommandLine = cmdline
+
+	return nil
+}
+
+func (s *Svc) setModules(kernel *Kernel) error {
+	modulesData, err := os.ReadFile(s.modulesPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read modules from %s", s.modulesPath)
+	}
+
+	modules := make([]Module, 0)
+
+	scanner := bufio.NewScanner(bytes.NewReader(modulesData))
+	for scanner.Scan() {
+		// <name> <size> <refcount> <dependencies> <state> <address> [(<taints>)]
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		module := Module{
+			Name:  fields[0],
+			State: fields[4],
+		}
+
+		if module.Size, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to parse size of module %s", module.Name))
+		}
+		if module.RefCount, err = strconv.ParseUint(fields[2], 10, 64); err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to parse ref count of module %s", module.Name))
+		}
+
+		// dependency list is comma terminated, "-" stands for no dependencies
+		for _, dep := range strings.Split(fields[3], ",") {
+			if dep == "" || dep == "-" {
+				continue
+			}
+			module.DependsOn = append(module.DependsOn, dep)
+		}
+
+		if len(fields) > 6 {
+			module.Taints = strings.Trim(fields[6], "()")
+		}
+
+		modulePath := path.Join(s.sysModulePath, module.Name)
+		if version, err := file.ToString(path.Join(modulePath, CModuleVersionPath)); err == nil {
+			module.Version = version
+		}
+		if srcVersion, err := file.ToString(path.Join(modulePath, CModuleSrcVersionPath)); err == nil {
+			module.SrcVersion = srcVersion
+		}
+
+		modules = append(modules, module)
+	}
+
+	kernel.Modules = modules
+
+	return nil
+}
+
+func (s *Svc) setTainted(kernel *Kernel) error {
+	tainted, err := file.ToUint64(s.taintedPath)
+	if err != nil {
+		return errors.Wrap(err, "unable to get kernel taint state")
+	}
+
+	kernel.Tainted = tainted
+	kernel.TaintFlags = make([]TaintFlag, 0)
+	for _, flag := range CTaintFlags {
+		if tainted&(1<<flag.Bit) != 0 {
+			kernel.TaintFlags = append(kernel.TaintFlags, flag)
+		}
+	}
+
+	return nil
+}
+
+func (s *Svc) setBoot(kernel *Kernel) error {
+	boot := &Boot{
+		Mode: CLegacyBootMode,
+	}
+	kernel.Boot = boot
+
+	// efi directory is present only when system was booted by UEFI firmware
+	if _, err := os.Stat(s.efiPath); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "unable to check %s", s.efiPath)
+	}
+	boot.Mode = CUEFIBootMode
+
+	secureBoot, err := s.getEFIBoolVariable(CSecureBootVariable)
+	if err != nil {
+		return errors.Wrap(err, "unable to get secure boot state")
+	}
+	boot.SecureBoot = secureBoot
+
+	setupMode, err := s.getEFIBoolVariable(CSetupModeVariable)
+	if err != nil {
+		return errors.Wrap(err, "unable to get setup mode state")
+	}
+	boot.SetupMode = setupMode
+
+	return nil
+}
+
+func (s *Svc) getEFIBoolVariable(name string) (bool, error) {
+	varPath := path.Join(s.efiVarsPath, name)
+	data, err := os.ReadFile(varPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to read %s", varPath)
+	}
+
+	if len(data) <= CEFIVariableAttributesLength {
+		return false, errors.Errorf("unexpected length %d of EFI variable %s", len(data), name)
+	}
+
+	return data[CEFIVariableAttributesLength] == 1, nil
+}
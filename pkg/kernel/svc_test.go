@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package kernel
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func writeFile(t *testing.T, root string, thePath string, data []byte) {
+	t.Helper()
+	fullPath := path.Join(root, thePath)
+	if err := os.MkdirAll(path.Dir(fullPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetData(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, CKernelReleasePath, []byte("6.1.0-18-amd64\n"))
+	writeFile(t, root, CModulesPath, []byte(
+		"nvidia 56823808 2 nvidia_modeset,nvidia_uvm, Live 0xffffffffc0d4d000 (POE)\n"+
+			"zfs 5640192 6 - Live 0xffffffffc0a00000 (PO)\n"+
+			"e1000e 323584 0 - Live 0xffffffffc0300000\n"+
+			"broken\n"))
+	writeFile(t, root, path.Join(CSysModulePath, "e1000e", CModuleVersionPath), []byte("3.2.6-k\n"))
+	// proprietary module (P), out-of-tree (O) and unsigned (E) taints
+	writeFile(t, root, CTaintedPath, []byte("12289\n"))
+	// attributes followed by the value
+	writeFile(t, root, path.Join(CEFIVarsPath, CSecureBootVariable), []byte{0x06, 0x00, 0x00, 0x00, 0x01})
+
+	kernel, err := NewSvc(printer.NewSvc(false), root).GetData()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedModules := []Module{
+		{Name: "nvidia", Size: 56823808, RefCount: 2, DependsOn: []string{"nvidia_modeset", "nvidia_uvm"}, State: "Live", Taints: "POE"},
+		{Name: "zfs", Size: 5640192, RefCount: 6, State: "Live", Taints: "PO"},
+		{Name: "e1000e", Size: 323584, State: "Live", Version: "3.2.6-k"},
+	}
+	if !reflect.DeepEqual(kernel.Modules, expectedModules) {
+		t.Errorf("expected modules %+v, got %+v", expectedModules, kernel.Modules)
+	}
+
+	letters := ""
+	for _, flag := range kernel.TaintFlags {
+		letters += flag.Letter
+	}
+	if kernel.Tainted != 12289 || letters != "POE" {
+		t.Errorf("expected taint flags POE, got %d %s", kernel.Tainted, letters)
+	}
+
+	if kernel.Boot == nil || kernel.Boot.Mode != CUEFIBootMode || !kernel.Boot.SecureBoot || kernel.Boot.SetupMode {
+		t.Errorf("expected UEFI secure boot, got %+v", kernel.Boot)
+	}
+}
+
+func TestGetDataLegacyBoot(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, CKernelReleasePath, []byte("5.15.0\n"))
+
+	kernel, err := NewSvc(printer.NewSvc(false), root).GetData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kernel.Boot == nil || kernel.Boot.Mode != CLegacyBootMode {
+		t.Errorf("expected legacy boot, got %+v", kernel.Boot)
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/onmetal/inventory/pkg/lldp/frame"
 	"github.com/onmetal/inventory/pkg/printer"
 	"github.com/onmetal/inventory/pkg/redis"
-	"github.com/onmetal/inventory/pkg/utils"
 )
 
 const (
@@ -43,11 +42,10 @@ func (s *Svc) GetData() ([]frame.Frame, error) {
 
 	hostInfo, err := s.hostSvc.GetData()
 	if err != nil {
-		s.printer.VErr(errors.Wrap(err, "failed to collect host info"))
+		return nil, errors.Wrap(err, "failed to collect host info")
 	}
 
-	switch hostInfo.Type {
-	case utils.CMachineType:
+	if !hostInfo.IsSONiC() {
 		frameFiles, err := os.ReadDir(s.lldpPath)
 		if err != nil {
 			return nil, errors.Wrap(err, "unable to get list of frame files")
@@ -63,12 +61,14 @@ func (s *Svc) GetData() ([]frame.Frame, error) {
 			}
 			frameInfos = append(frameInfos, *info)
 		}
-	case utils.CSwitchType:
-		frames, err := s.redisSvc.GetFrames()
-		if err != nil {
-			return nil, errors.Wrap(err, "unable to process redis lldp data")
-		}
-		frameInfos = append(frameInfos, frames...)
+		return frameInfos, nil
+	}
+
+	frames, err := s.redisSvc.GetFrames()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to process redis lldp data")
 	}
+	frameInfos = append(frameInfos, frames...)
+
 	return frameInfos, nil
 }
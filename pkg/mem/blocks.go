@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+const (
+	CBlockStateOnline       = "online"
+	CBlockStateOffline      = "offline"
+	CBlockStateGoingOffline = "going-offline"
+
+	// CNoNode is set for blocks that are not linked to any NUMA node
+	CNoNode = -1
+)
+
+// Blocks are memory hotplug blocks memory is onlined and offlined by
+type Blocks struct {
+	// BlockSize is a size of every block in bytes
+	BlockSize uint64
+	Blocks    []Block
+	Nodes     []NodeBlocks
+}
+
+type Block struct {
+	ID    int
+	State string
+	// Zone is a zone online block belongs to, e.g. Normal or Movable, empty for offline block
+	Zone      string
+	Node      int
+	Removable bool
+}
+
+// NodeBlocks is a summary of memory blocks of NUMA node, sizes are in bytes
+type NodeBlocks struct {
+	Node          int
+	OnlineBlocks  int
+	OfflineBlocks int
+	OnlineSize    uint64
+	OfflineSize   uint64
+	MovableSize   uint64
+	OfflineIDs    []int
+}
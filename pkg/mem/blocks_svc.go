@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CMemoryBlocksPath = "/sys/devices/system/memory"
+
+	// block size is printed in hexadecimal without prefix
+	CBlockSizePath  = "/block_size_bytes"
+	CBlockStatePath = "/state"
+	// valid_zones of online block is a zone it belongs to,
+	// of offline one is a list of zones it could be onlined to
+	CBlockValidZonesPath = "/valid_zones"
+	CBlockRemovablePath  = "/removable"
+
+	CBlockZoneMovable = "Movable"
+
+	CBlockDirNamePattern = "^memory([0-9]+)$"
+	// block directory has a link to its NUMA node, e.g. node0
+	CBlockNodeNamePattern = "^node([0-9]+)$"
+)
+
+var CBlockDirNameRegexp = regexp.MustCompile(CBlockDirNamePattern)
+var CBlockNodeNameRegexp = regexp.MustCompile(CBlockNodeNamePattern)
+
+type BlocksSvc struct {
+	printer    *printer.Svc
+	blocksPath string
+}
+
+func NewBlocksSvc(printer *printer.Svc, basePath string) *BlocksSvc {
+	return &BlocksSvc{
+		printer:    printer,
+		blocksPath: path.Join(basePath, CMemoryBlocksPath),
+	}
+}
+
+func (s *BlocksSvc) GetBlocks() (*Blocks, error) {
+	blockSizePath := path.Join(s.blocksPath, CBlockSizePath)
+	blockSizeString, err := file.ToString(blockSizePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read block size from %s", blockSizePath)
+	}
+	blockSize, err := strconv.ParseUint(blockSizeString, 16, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to convert block size %s to uint64", blockSizeString)
+	}
+
+	entries, err := os.ReadDir(s.blocksPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read memory blocks from %s", s.blocksPath)
+	}
+
+	blocks := make([]Block, 0)
+	for _, entry := range entries {
+		groups := CBlockDirNameRegexp.FindStringSubmatch(entry.Name())
+		if len(groups) < 2 {
+			continue
+		}
+
+		id, err := strconv.Atoi(groups[1])
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to convert block id %s", groups[1]))
+			continue
+		}
+
+		block, err := getBlock(path.Join(s.blocksPath, entry.Name()), id)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get block %s", entry.Name()))
+			continue
+		}
+		blocks = append(blocks, *block)
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].ID < blocks[j].ID
+	})
+
+	return &Blocks{
+		BlockSize: blockSize,
+		Blocks:    blocks,
+		Nodes:     summarizeBlocks(blocks, blockSize),
+	}, nil
+}
+
+func getBlock(thePath string, id int) (*Block, error) {
+	state, err := file.ToString(path.Join(thePath, CBlockStatePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get state")
+	}
+
+	block := &Block{
+		ID:    id,
+		State: state,
+		Node:  CNoNode,
+	}
+
+	// older kernels list all zones of online block, the current one goes first
+	if state == CBlockStateOnline {
+		if zones, err := file.ToString(path.Join(thePath, CBlockValidZonesPath)); err == nil {
+			if fields := strings.Fields(zones); len(fields) > 0 {
+				block.Zone = fields[0]
+			}
+		}
+	}
+
+	// removable is absent on kernels built without memory hot remove
+	if removable, err := file.ToBool(path.Join(thePath, CBlockRemovablePath)); err == nil {
+		block.Removable = removable
+	}
+
+	entries, err := os.ReadDir(thePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read %s", thePath)
+	}
+	for _, entry := range entries {
+		groups := CBlockNodeNameRegexp.FindStringSubmatch(entry.Name())
+		if len(groups) < 2 {
+			continue
+		}
+		if node, err := strconv.Atoi(groups[1]); err == nil {
+			block.Node = node
+			break
+		}
+	}
+
+	return block, nil
+}
+
+func summarizeBlocks(blocks []Block, blockSize uint64) []NodeBlocks {
+	nodesByID := make(map[int]*NodeBlocks)
+	for _, block := range blocks {
+		node, ok := nodesByID[block.Node]
+		if !ok {
+			node = &NodeBlocks{
+				Node:       block.Node,
+				OfflineIDs: make([]int, 0),
+			}
+			nodesByID[block.Node] = node
+		}
+
+		if block.State == CBlockStateOnline {
+			node.OnlineBlocks++
+			node.OnlineSize += blockSize
+			if block.Zone == CBlockZoneMovable {
+				node.MovableSize += blockSize
+			}
+			continue
+		}
+
+		node.OfflineBlocks++
+		node.OfflineSize += blockSize
+		node.OfflineIDs = append(node.OfflineIDs, block.ID)
+	}
+
+	nodes := make([]NodeBlocks, 0, len(nodesByID))
+	for _, node := range nodesByID {
+		nodes = append(nodes, *node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Node < nodes[j].Node
+	})
+
+	return nodes
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func TestSummarizeBlocks(t *testing.T) {
+	const blockSize = 128 << 20
+
+	blocks := []Block{
+		{ID: 0, State: CBlockStateOnline, Zone: "Normal", Node: 1},
+		{ID: 1, State: CBlockStateOnline, Zone: CBlockZoneMovable, Node: 1},
+		{ID: 2, State: CBlockStateOffline, Node: 1},
+		{ID: 3, State: CBlockStateGoingOffline, Node: 1},
+		{ID: 4, State: CBlockStateOnline, Zone: "Normal", Node: 0},
+		{ID: 5, State: CBlockStateOffline, Node: CNoNode},
+	}
+
+	expected := []NodeBlocks{
+		{Node: CNoNode, OfflineBlocks: 1, OfflineSize: blockSize, OfflineIDs: []int{5}},
+		{Node: 0, OnlineBlocks: 1, OnlineSize: blockSize, OfflineIDs: []int{}},
+		{
+			Node:          1,
+			OnlineBlocks:  2,
+			OfflineBlocks: 2,
+			OnlineSize:    2 * blockSize,
+			OfflineSize:   2 * blockSize,
+			MovableSize:   blockSize,
+			OfflineIDs:    []int{2, 3},
+		},
+	}
+
+	actual := summarizeBlocks(blocks, blockSize)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestGetBlocks(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, path.Join(CMemoryBlocksPath, CBlockSizePath), "8000000\n")
+	// older kernels list every zone of online block
+	writeFile(t, root, path.Join(CMemoryBlocksPath, "memory0", CBlockStatePath), "online\n")
+	writeFile(t, root, path.Join(CMemoryBlocksPath, "memory0", CBlockValidZonesPath), "Movable Normal\n")
+	writeFile(t, root, path.Join(CMemoryBlocksPath, "memory0", CBlockRemovablePath), "1\n")
+	writeFile(t, root, path.Join(CMemoryBlocksPath, "memory0", "node1", "cpulist"), "\n")
+	// offline block lists zones it could be onlined to
+	writeFile(t, root, path.Join(CMemoryBlocksPath, "memory10", CBlockStatePath), "offline\n")
+	writeFile(t, root, path.Join(CMemoryBlocksPath, "memory10", CBlockValidZonesPath), "Normal Movable\n")
+	writeFile(t, root, path.Join(CMemoryBlocksPath, "memory10", "node1", "cpulist"), "\n")
+	// block without state is skipped
+	writeFile(t, root, path.Join(CMemoryBlocksPath, "memory2", CBlockRemovablePath), "0\n")
+
+	blocks, err := NewBlocksSvc(printer.NewSvc(false), root).GetBlocks()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Blocks{
+		BlockSize: 128 << 20,
+		Blocks: []Block{
+			{ID: 0, State: CBlockStateOnline, Zone: CBlockZoneMovable, Node: 1, Removable: true},
+			{ID: 10, State: CBlockStateOffline, Node: 1},
+		},
+		Nodes: []NodeBlocks{
+			{
+				Node:          1,
+				OnlineBlocks:  1,
+				OfflineBlocks: 1,
+				OnlineSize:    128 << 20,
+				OfflineSize:   128 << 20,
+				MovableSize:   128 << 20,
+				OfflineIDs:    []int{10},
+			},
+		},
+	}
+	if !reflect.DeepEqual(blocks, expected) {
+		t.Errorf("expected %+v, got %+v", expected, blocks)
+	}
+}
@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+// HugePages is a pool of huge pages of one size, counts are in pages.
+// Reserved and Overcommit are reported only for the system wide pool
+type HugePages struct {
+	// Size is a page size in bytes
+	Size       uint64
+	Total      uint64
+	Free       uint64
+	Reserved   uint64
+	Surplus    uint64
+	Overcommit uint64
+}
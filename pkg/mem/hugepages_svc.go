@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CHugePagesPath = "/sys/kernel/mm/hugepages"
+
+	CHugePagesTotalPath      = "/nr_hugepages"
+	CHugePagesFreePath       = "/free_hugepages"
+	CHugePagesReservedPath   = "/resv_hugepages"
+	CHugePagesSurplusPath    = "/surplus_hugepages"
+	CHugePagesOvercommitPath = "/nr_overcommit_hugepages"
+
+	// hugepages-2048kB
+	CHugePagesDirNamePattern = "^hugepages-([0-9]+)kB$"
+)
+
+var CHugePagesDirNameRegexp = regexp.MustCompile(CHugePagesDirNamePattern)
+
+type HugePagesSvc struct {
+	printer       *printer.Svc
+	hugePagesPath string
+}
+
+func NewHugePagesSvc(printer *printer.Svc, basePath string) *HugePagesSvc {
+	return &HugePagesSvc{
+		printer:       printer,
+		hugePagesPath: path.Join(basePath, CHugePagesPath),
+	}
+}
+
+func (s *HugePagesSvc) GetHugePages() ([]HugePages, error) {
+	return s.GetHugePagesFromDir(s.hugePagesPath)
+}
+
+// GetHugePagesFromDir reads pools of all page sizes from a directory
+// with hugepages-<size>kB subdirectories, either system wide or of NUMA node
+func (s *HugePagesSvc) GetHugePagesFromDir(thePath string) ([]HugePages, error) {
+	entries, err := os.ReadDir(thePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read hugepages from %s", thePath)
+	}
+
+	pools := make([]HugePages, 0)
+	for _, entry := range entries {
+		groups := CHugePagesDirNameRegexp.FindStringSubmatch(entry.Name())
+		if len(groups) < 2 {
+			continue
+		}
+
+		size, err := strconv.ParseUint(groups[1], 10, 64)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to convert hugepage size %s", groups[1]))
+			continue
+		}
+
+		pool, err := getHugePages(path.Join(thePath, entry.Name()), size*CMemInfoValueMultiplier)
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get hugepages of %s", entry.Name()))
+			continue
+		}
+		pools = append(pools, *pool)
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Size < pools[j].Size
+	})
+
+	return pools, nil
+}
+
+func getHugePages(poolPath string, size uint64) (*HugePages, error) {
+	total, err := file.ToUint64(path.Join(poolPath, CHugePagesTotalPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get number of hugepages")
+	}
+
+	free, err := file.ToUint64(path.Join(poolPath, CHugePagesFreePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get number of free hugepages")
+	}
+
+	pool := &HugePages{
+		Size:  size,
+		Total: total,
+		Free:  free,
+	}
+
+	if surplus, err := file.ToUint64(path.Join(poolPath, CHugePagesSurplusPath)); err == nil {
+		pool.Surplus = surplus
+	}
+	// reserved and overcommit are absent in NUMA node pools
+	if reserved, err := file.ToUint64(path.Join(poolPath, CHugePagesReservedPath)); err == nil {
+		pool.Reserved = reserved
+	}
+	if overcommit, err := file.ToUint64(path.Join(poolPath, CHugePagesOvercommitPath)); err == nil {
+		pool.Overcommit = overcommit
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package mem
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func writeFile(t *testing.T, root string, thePath string, data string) {
+	t.Helper()
+	fullPath := path.Join(root, thePath)
+	if err := os.MkdirAll(path.Dir(fullPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetHugePages(t *testing.T) {
+	root := t.TempDir()
+	pool2M := path.Join(CHugePagesPath, "hugepages-2048kB")
+	writeFile(t, root, path.Join(pool2M, CHugePagesTotalPath), "1024\n")
+	writeFile(t, root, path.Join(pool2M, CHugePagesFreePath), "1000\n")
+	writeFile(t, root, path.Join(pool2M, CHugePagesReservedPath), "8\n")
+	writeFile(t, root, path.Join(pool2M, CHugePagesSurplusPath), "0\n")
+	writeFile(t, root, path.Join(pool2M, CHugePagesOvercommitPath), "64\n")
+	pool1G := path.Join(CHugePagesPath, "hugepages-1048576kB")
+	writeFile(t, root, path.Join(pool1G, CHugePagesTotalPath), "16\n")
+	writeFile(t, root, path.Join(pool1G, CHugePagesFreePath), "4\n")
+	// pool without free pages count is skipped
+	writeFile(t, root, path.Join(CHugePagesPath, "hugepages-64kB", CHugePagesTotalPath), "0\n")
+	// unrelated entries are ignored
+	writeFile(t, root, path.Join(CHugePagesPath, "hugepages-2MB", CHugePagesTotalPath), "0\n")
+	writeFile(t, root, path.Join(CHugePagesPath, "README"), "\n")
+
+	pools, err := NewHugePagesSvc(printer.NewSvc(false), root).GetHugePages()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []HugePages{
+		{Size: 2 << 20, Total: 1024, Free: 1000, Reserved: 8, Overcommit: 64},
+		{Size: 1 << 30, Total: 16, Free: 4},
+	}
+	if !reflect.DeepEqual(pools, expected) {
+		t.Errorf("expected %+v, got %+v", expected, pools)
+	}
+
+	if _, err := NewHugePagesSvc(printer.NewSvc(false), t.TempDir()).GetHugePages(); err == nil {
+		t.Error("kernel without hugetlbfs: expected error")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -47,6 +48,13 @@ const (
 	CNICDeviceTransmitQueueLengthPath      = "/tx_queue_len"
 	CNICDeviceTypePath                     = "/type"
 
+	// PCI devices expose IDs in device folder, while for USB devices
+	// it is an interface folder and IDs are in its parent
+	CNICDevicePCIVendorIDPath  = "/device/vendor"
+	CNICDevicePCIProductIDPath = "/device/device"
+	CUSBDeviceVendorIDPath     = "/idVendor"
+	CUSBDeviceProductIDPath    = "/idProduct"
+
 	CPermanentAddressAssignType               = "permanent address"
 	CRandomlyGeneratedAddressAssignType       = "randomly generated"
 	CStolenFromAnotherDeviceAddressAssignType = "stolen from another device"
@@ -93,6 +101,14 @@ var CNameAssignTypes = []NameAssignType{
 type Device struct {
 	Name       string
 	PCIAddress string
+	// Slot is a physical slot or onboard device designation from SMBIOS
+	Slot string
+	// VendorID and ProductID are PCI or USB IDs of the device in hex without prefix
+	VendorID  string
+	ProductID string
+	// HostInterface is set for the host side of management controller
+	// host interface described by SMBIOS, e.g. Redfish USB NIC
+	HostInterface bool
 
 	AddressAssignType   AddressAssignType
 	Address             string
@@ -150,6 +166,40 @@ func (n *Device) defPCIAddress(thePath string) error {
 	return nil
 }
 
+func (n *Device) defDeviceIDs(thePath string) error {
+	devicePath := path.Join(thePath, CNICDevicePCIAddressPath)
+	if _, err := os.Stat(devicePath); os.IsNotExist(err) {
+		// virtual devices have no underlying device
+		return nil
+	}
+
+	vendorIDPath := path.Join(thePath, CNICDevicePCIVendorIDPath)
+	productIDPath := path.Join(thePath, CNICDevicePCIProductIDPath)
+	if n.PCIAddress == "" {
+		// path is resolved to not go back to the net class folder
+		resolvedPath, err := filepath.EvalSymlinks(devicePath)
+		if err != nil {
+			return errors.Wrapf(err, "unable resolve symlink with path %s", devicePath)
+		}
+		vendorIDPath = path.Join(path.Dir(resolvedPath), CUSBDeviceVendorIDPath)
+		productIDPath = path.Join(path.Dir(resolvedPath), CUSBDeviceProductIDPath)
+	}
+
+	vendorID, err := file.ToString(vendorIDPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get vendor id from %s", vendorIDPath)
+	}
+	productID, err := file.ToString(productIDPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get product id from %s", productIDPath)
+	}
+
+	n.VendorID = strings.TrimPrefix(vendorID, "0x")
+	n.ProductID = strings.TrimPrefix(productID, "0x")
+
+	return nil
+}
+
 func (n *Device) defAddressAssignType(thePath string) error {
 	filePath := path.Join(thePath, CNICDeviceAddressAddressAssignTypePath)
 	fileVal, err := file.ToInt(filePath)
@@ -26,6 +26,7 @@ func (s *DeviceSvc) GetDevice(thePath string, name string) (*Device, error) {
 
 	defs := []func(string) error{
 		nic.defPCIAddress,
+		nic.defDeviceIDs,
 		nic.defAddressAssignType,
 		nic.defAddress,
 		nic.defAddressLength,
@@ -14,7 +14,6 @@ import (
 	"github.com/onmetal/inventory/pkg/host"
 	"github.com/onmetal/inventory/pkg/printer"
 	"github.com/onmetal/inventory/pkg/redis"
-	"github.com/onmetal/inventory/pkg/utils"
 )
 
 const (
@@ -43,7 +42,7 @@ func NewSvc(printer *printer.Svc, nicDevSvc *DeviceSvc, hostSvc *host.Svc, redis
 func (s *Svc) GetData() ([]Device, error) {
 	hostInfo, err := s.hostSvc.GetData()
 	if err != nil {
-		s.printer.VErr(errors.Wrap(err, "failed to collect host info"))
+		return nil, errors.Wrap(err, "failed to collect host info")
 	}
 
 	nicFolders, err := os.ReadDir(s.nicDevPath)
@@ -60,7 +59,7 @@ func (s *Svc) GetData() ([]Device, error) {
 			s.printer.VErr(errors.Wrap(err, "unable to collect Device data"))
 			continue
 		}
-		if hostInfo.Type == utils.CSwitchType && strings.HasPrefix(fName, "Ethernet") {
+		if hostInfo.IsSONiC() && hostInfo.IsFrontPanelPort(fName) {
 			info, err := s.redisSvc.GetPortAdditionalInfo(fName)
 			if err != nil {
 				s.printer.VErr(errors.Wrap(err, "unable to collect additional Device data from Redis"))
@@ -13,4 +13,11 @@ type Node struct {
 	Distances []int
 	Memory    *mem.Info
 	Stat      *Stat
+	HugePages []mem.HugePages
+	// MemoryOnly is set for nodes having memory but no CPUs, e.g. CXL or NVDIMM memory onlined as RAM
+	MemoryOnly bool
+	// PCIDevices, NICs and BlockDevices are addresses and names of devices attached to the node
+	PCIDevices   []string
+	NICs         []string
+	BlockDevices []string
 }
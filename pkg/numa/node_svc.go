@@ -19,6 +19,7 @@ const (
 	CNodeCPUListPath  = "/cpulist"
 	CNodeDistancePath = "/distance"
 	CNodeMemInfo      = "/meminfo"
+	CNodeHugePages    = "/hugepages"
 
 	CCPUListTrimPattern = "[^0-9\\-,]"
 )
@@ -26,14 +27,16 @@ const (
 var CCPUListTrimRegexp = regexp.MustCompile(CCPUListTrimPattern)
 
 type NodeSvc struct {
-	memInfoSvc *mem.InfoSvc
-	statSvc    *StatSvc
+	memInfoSvc   *mem.InfoSvc
+	hugePagesSvc *mem.HugePagesSvc
+	statSvc      *StatSvc
 }
 
-func NewNodeSvc(memInfoSvc *mem.InfoSvc, statSvc *StatSvc) *NodeSvc {
+func NewNodeSvc(memInfoSvc *mem.InfoSvc, hugePagesSvc *mem.HugePagesSvc, statSvc *StatSvc) *NodeSvc {
 	return &NodeSvc{
-		memInfoSvc: memInfoSvc,
-		statSvc:    statSvc,
+		memInfoSvc:   memInfoSvc,
+		hugePagesSvc: hugePagesSvc,
+		statSvc:      statSvc,
 	}
 }
 
@@ -69,6 +72,11 @@ func (s *NodeSvc) GetNode(thePath string, nodeId int) (*Node, error) {
 
 	// NUMA CPU list looks like 0,3,5-8,9,11-15
 	for _, element := range cpuListElements {
+		// memory only nodes, e.g. of CXL or NVDIMM memory, have an empty CPU list
+		if element == "" {
+			continue
+		}
+
 		if cpuId, err := strconv.Atoi(element); err == nil {
 			cpuList = append(cpuList, cpuId)
 			continue
@@ -105,11 +113,23 @@ func (s *NodeSvc) GetNode(thePath string, nodeId int) (*Node, error) {
 		return nil, errors.Wrapf(err, "unable to obtain stat for %s", thePath)
 	}
 
+	// hugepages directory is absent if kernel is built without hugetlbfs
+	var hugePages []mem.HugePages
+	hugePagesPath := path.Join(thePath, CNodeHugePages)
+	if _, err := os.Stat(hugePagesPath); err == nil {
+		hugePages, err = s.hugePagesSvc.GetHugePagesFromDir(hugePagesPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to obtain hugepages for %s", thePath)
+		}
+	}
+
 	return &Node{
-		ID:        nodeId,
-		Distances: distances,
-		CPUs:      cpuList,
-		Memory:    memInfo,
-		Stat:      stat,
+		ID:         nodeId,
+		Distances:  distances,
+		CPUs:       cpuList,
+		Memory:     memInfo,
+		Stat:       stat,
+		HugePages:  hugePages,
+		MemoryOnly: len(cpuList) == 0 && memInfo.MemTotal > 0,
 	}, nil
 }
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package numa
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/mem"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func writeFile(t *testing.T, root string, thePath string, data string) {
+	t.Helper()
+	fullPath := path.Join(root, thePath)
+	if err := os.MkdirAll(path.Dir(fullPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestNodeSvc() *NodeSvc {
+	p := printer.NewSvc(false)
+	return NewNodeSvc(mem.NewInfoSvc(p, "/"), mem.NewHugePagesSvc(p, "/"), NewStatSvc(p))
+}
+
+// writeNode writes sysfs files of NUMA node directory
+func writeNode(t *testing.T, nodePath string, cpuList string, memTotalKB string) {
+	t.Helper()
+	writeFile(t, nodePath, CNodeDistancePath, "10 21\n")
+	writeFile(t, nodePath, CNodeCPUListPath, cpuList+"\n")
+	writeFile(t, nodePath, CNodeMemInfo, "Node 0 MemTotal:       "+memTotalKB+" kB\nNode 0 MemFree:        1024 kB\n")
+	writeFile(t, nodePath, CNodeStat, "numa_hit 100\nnuma_miss 0\n")
+}
+
+func TestGetNodeHugePages(t *testing.T) {
+	nodePath := t.TempDir()
+	writeNode(t, nodePath, "0-3,8", "65536")
+	pool := path.Join(CNodeHugePages, "hugepages-2048kB")
+	writeFile(t, nodePath, path.Join(pool, mem.CHugePagesTotalPath), "512\n")
+	writeFile(t, nodePath, path.Join(pool, mem.CHugePagesFreePath), "500\n")
+	writeFile(t, nodePath, path.Join(pool, mem.CHugePagesSurplusPath), "2\n")
+
+	node, err := newTestNodeSvc().GetNode(nodePath, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(node.CPUs, []int{0, 1, 2, 3, 8}) || !reflect.DeepEqual(node.Distances, []int{10, 21}) {
+		t.Errorf("expected CPUs 0-3,8 with distances 10 21, got %v with %v", node.CPUs, node.Distances)
+	}
+	expected := []mem.HugePages{{Size: 2 << 20, Total: 512, Free: 500, Surplus: 2}}
+	if !reflect.DeepEqual(node.HugePages, expected) {
+		t.Errorf("expected %+v, got %+v", expected, node.HugePages)
+	}
+
+	// hugepages directory is absent if kernel is built without hugetlbfs
+	nodePath = t.TempDir()
+	writeNode(t, nodePath, "0", "65536")
+	node, err = newTestNodeSvc().GetNode(nodePath, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.HugePages != nil {
+		t.Errorf("expected no hugepages, got %+v", node.HugePages)
+	}
+}
+
+func TestGetNodeMemoryOnly(t *testing.T) {
+	tests := []struct {
+		name       string
+		cpuList    string
+		memTotalKB string
+		memoryOnly bool
+	}{
+		{name: "cpu-less node with memory", cpuList: "", memTotalKB: "16777216", memoryOnly: true},
+		{name: "node with cpus", cpuList: "0-1", memTotalKB: "16777216", memoryOnly: false},
+		{name: "cpu-less node without memory", cpuList: "", memTotalKB: "0", memoryOnly: false},
+	}
+
+	for _, test := range tests {
+		nodePath := t.TempDir()
+		writeNode(t, nodePath, test.cpuList, test.memTotalKB)
+
+		node, err := newTestNodeSvc().GetNode(nodePath, 2)
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err)
+			continue
+		}
+		if node.MemoryOnly != test.memoryOnly {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.memoryOnly, node.MemoryOnly)
+		}
+		if test.cpuList == "" && len(node.CPUs) != 0 {
+			t.Errorf("%s: expected no CPUs, got %+v", test.name, node.CPUs)
+		}
+	}
+}
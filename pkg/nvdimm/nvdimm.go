@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package nvdimm
+
+type RegionType string
+
+const (
+	CRegionTypePMEM     RegionType = "pmem"
+	CRegionTypeBLK      RegionType = "blk"
+	CRegionTypeVolatile RegionType = "volatile"
+
+	// CNoNUMANode is reported for numa_node and target_node if firmware does not describe them
+	CNoNUMANode = -1
+)
+
+// NVDIMM is a libnvdimm view of persistent memory, devices of all nd buses are merged
+type NVDIMM struct {
+	DIMMs   []DIMM
+	Regions []Region
+}
+
+// DIMM is an nmem device, identification is reported by NFIT firmware interface only
+type DIMM struct {
+	Name   string
+	ID     string
+	Serial string
+	Handle string
+	PhysID string
+	State  string
+	// Flags are NFIT health flags, e.g. save_fail, restore_fail, smart_notify
+	Flags []string
+}
+
+// Region is a range of persistent memory interleaved across DIMMs, sizes are in bytes
+type Region struct {
+	Name              string
+	Type              RegionType
+	Size              uint64
+	AvailableSize     uint64
+	NUMANode          int
+	TargetNode        int
+	PersistenceDomain string
+	ReadOnly          bool
+	// DIMMs are names of nmem devices region is interleaved across
+	DIMMs      []string
+	Namespaces []Namespace
+}
+
+// Namespace is a partition of region, idle namespaces of zero size are skipped
+type Namespace struct {
+	Name string
+	// Mode is raw, sector, fsdax or devdax (memory and dax on older kernels)
+	Mode string
+	Size uint64
+	UUID string
+	// Device is a block device (e.g. pmem0) or a device DAX (e.g. dax0.0) namespace is exposed as
+	Device string
+}
@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package nvdimm
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/onmetal/inventory/pkg/file"
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+const (
+	CNDBusDevicesPath = "/sys/bus/nd/devices"
+
+	CUEventPath            = "/uevent"
+	CUEventDevTypeKey      = "DEVTYPE"
+	CDevTypePrefix         = "nd_"
+	CNUMANodePath          = "/numa_node"
+	CTargetNodePath        = "/target_node"
+	CSizePath              = "/size"
+	CAvailableSizePath     = "/available_size"
+	CPersistenceDomainPath = "/persistence_domain"
+	CReadOnlyPath          = "/read_only"
+	CMappingsPath          = "/mappings"
+	CMappingPath           = "/mapping%d"
+	CModePath              = "/mode"
+	CUUIDPath              = "/uuid"
+	CHolderPath            = "/holder"
+	CBlockPath             = "/block"
+
+	CDIMMStatePath  = "/state"
+	CNFITIDPath     = "/nfit/id"
+	CNFITSerialPath = "/nfit/serial"
+	CNFITHandlePath = "/nfit/handle"
+	CNFITPhysIDPath = "/nfit/phys_id"
+	CNFITFlagsPath  = "/nfit/flags"
+
+	CDIMMDirNamePattern      = "^nmem[0-9]+$"
+	CRegionDirNamePattern    = "^region[0-9]+$"
+	CNamespaceDirNamePattern = "^namespace[0-9]+\\.[0-9]+$"
+	CDAXDirNamePattern       = "^dax[0-9]+\\.[0-9]+$"
+)
+
+var CDIMMDirNameRegexp = regexp.MustCompile(CDIMMDirNamePattern)
+var CRegionDirNameRegexp = regexp.MustCompile(CRegionDirNamePattern)
+var CNamespaceDirNameRegexp = regexp.MustCompile(CNamespaceDirNamePattern)
+var CDAXDirNameRegexp = regexp.MustCompile(CDAXDirNamePattern)
+
+type Svc struct {
+	printer       *printer.Svc
+	ndDevicesPath string
+}
+
+func NewSvc(printer *printer.Svc, basePath string) *Svc {
+	return &Svc{
+		printer:       printer,
+		ndDevicesPath: path.Join(basePath, CNDBusDevicesPath),
+	}
+}
+
+func (s *Svc) GetData() (*NVDIMM, error) {
+	entries, err := os.ReadDir(s.ndDevicesPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read nd devices from %s", s.ndDevicesPath)
+	}
+
+	data := &NVDIMM{
+		DIMMs:   make([]DIMM, 0),
+		Regions: make([]Region, 0),
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		devicePath := path.Join(s.ndDevicesPath, name)
+
+		switch {
+		case CDIMMDirNameRegexp.MatchString(name):
+			data.DIMMs = append(data.DIMMs, getDIMM(devicePath, name))
+		case CRegionDirNameRegexp.MatchString(name):
+			region, err := s.getRegion(devicePath, name)
+			if err != nil {
+				s.printer.VErr(errors.Wrapf(err, "unable to get %s", name))
+				continue
+			}
+			data.Regions = append(data.Regions, *region)
+		}
+	}
+
+	if len(data.DIMMs) == 0 && len(data.Regions) == 0 {
+		return nil, errors.Errorf("no nvdimm devices found in %s", s.ndDevicesPath)
+	}
+
+	sort.Slice(data.DIMMs, func(i, j int) bool {
+		return data.DIMMs[i].Name < data.DIMMs[j].Name
+	})
+	sort.Slice(data.Regions, func(i, j int) bool {
+		return data.Regions[i].Name < data.Regions[j].Name
+	})
+
+	return data, nil
+}
+
+func getDIMM(thePath string, name string) DIMM {
+	dimm := DIMM{
+		Name: name,
+	}
+
+	// all of them are optional, NFIT attributes are absent on non ACPI platforms
+	dimm.State, _ = file.ToString(path.Join(thePath, CDIMMStatePath))
+	dimm.ID, _ = file.ToString(path.Join(thePath, CNFITIDPath))
+	dimm.Serial, _ = file.ToString(path.Join(thePath, CNFITSerialPath))
+	dimm.Handle, _ = file.ToString(path.Join(thePath, CNFITHandlePath))
+	dimm.PhysID, _ = file.ToString(path.Join(thePath, CNFITPhysIDPath))
+	if flags, err := file.ToString(path.Join(thePath, CNFITFlagsPath)); err == nil {
+		dimm.Flags = strings.Fields(flags)
+	}
+
+	return dimm
+}
+
+func (s *Svc) getRegion(thePath string, name string) (*Region, error) {
+	devType, err := getDevType(thePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get device type")
+	}
+
+	size, err := file.ToUint64(path.Join(thePath, CSizePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get size")
+	}
+
+	region := &Region{
+		Name:       name,
+		Type:       RegionType(strings.TrimPrefix(devType, CDevTypePrefix)),
+		Size:       size,
+		NUMANode:   CNoNUMANode,
+		TargetNode: CNoNUMANode,
+		DIMMs:      make([]string, 0),
+		Namespaces: make([]Namespace, 0),
+	}
+
+	if available, err := file.ToUint64(path.Join(thePath, CAvailableSizePath)); err == nil {
+		region.AvailableSize = available
+	}
+	if node, err := file.ToInt(path.Join(thePath, CNUMANodePath)); err == nil {
+		region.NUMANode = node
+	}
+	if node, err := file.ToInt(path.Join(thePath, CTargetNodePath)); err == nil {
+		region.TargetNode = node
+	}
+	region.PersistenceDomain, _ = file.ToString(path.Join(thePath, CPersistenceDomainPath))
+	if readOnly, err := file.ToBool(path.Join(thePath, CReadOnlyPath)); err == nil {
+		region.ReadOnly = readOnly
+	}
+
+	// mapping is <nmem>,<offset>,<length>,<position>
+	if mappings, err := file.ToInt(path.Join(thePath, CMappingsPath)); err == nil {
+		for i := 0; i < mappings; i++ {
+			mapping, err := file.ToString(path.Join(thePath, fmt.Sprintf(CMappingPath, i)))
+			if err != nil {
+				s.printer.VErr(errors.Wrapf(err, "unable to get mapping %d of %s", i, name))
+				continue
+			}
+			dimm, _, _ := strings.Cut(mapping, ",")
+			region.DIMMs = append(region.DIMMs, dimm)
+		}
+	}
+
+	entries, err := os.ReadDir(thePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read %s", thePath)
+	}
+	for _, entry := range entries {
+		if !CNamespaceDirNameRegexp.MatchString(entry.Name()) {
+			continue
+		}
+
+		namespace, err := s.getNamespace(path.Join(thePath, entry.Name()), entry.Name())
+		if err != nil {
+			s.printer.VErr(errors.Wrapf(err, "unable to get %s", entry.Name()))
+			continue
+		}
+		if namespace.Size == 0 {
+			continue
+		}
+		region.Namespaces = append(region.Namespaces, *namespace)
+	}
+
+	sort.Slice(region.Namespaces, func(i, j int) bool {
+		return region.Namespaces[i].Name < region.Namespaces[j].Name
+	})
+
+	return region, nil
+}
+
+func (s *Svc) getNamespace(thePath string, name string) (*Namespace, error) {
+	size, err := file.ToUint64(path.Join(thePath, CSizePath))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get size")
+	}
+
+	namespace := &Namespace{
+		Name: name,
+		Size: size,
+	}
+	namespace.Mode, _ = file.ToString(path.Join(thePath, CModePath))
+	namespace.UUID, _ = file.ToString(path.Join(thePath, CUUIDPath))
+
+	// raw namespace has its own block device, other modes have it under btt, pfn or dax holder
+	devicePaths := []string{thePath}
+	if holder, err := file.ToString(path.Join(thePath, CHolderPath)); err == nil && holder != "" {
+		devicePaths = append(devicePaths, path.Join(s.ndDevicesPath, holder))
+	}
+	for _, devicePath := range devicePaths {
+		if device := getDevice(devicePath); device != "" {
+			namespace.Device = device
+			break
+		}
+	}
+
+	return namespace, nil
+}
+
+func getDevice(thePath string) string {
+	if entries, err := os.ReadDir(path.Join(thePath, CBlockPath)); err == nil && len(entries) > 0 {
+		return entries[0].Name()
+	}
+
+	entries, err := os.ReadDir(thePath)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if CDAXDirNameRegexp.MatchString(entry.Name()) {
+			return entry.Name()
+		}
+	}
+
+	return ""
+}
+
+func getDevType(thePath string) (string, error) {
+	uevent, err := os.ReadFile(path.Join(thePath, CUEventPath))
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read uevent")
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(uevent))
+	for scanner.Scan() {
+		key, val, ok := strings.Cut(scanner.Text(), "=")
+		if ok && key == CUEventDevTypeKey {
+			return val, nil
+		}
+	}
+
+	return "", errors.Errorf("no %s in uevent", CUEventDevTypeKey)
+}
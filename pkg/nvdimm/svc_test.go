@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package nvdimm
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/onmetal/inventory/pkg/printer"
+)
+
+func writeFile(t *testing.T, root string, thePath string, data string) {
+	t.Helper()
+	fullPath := path.Join(root, thePath)
+	if err := os.MkdirAll(path.Dir(fullPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fullPath, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetData(t *testing.T) {
+	root := t.TempDir()
+	devices := func(elem ...string) string {
+		return path.Join(append([]string{CNDBusDevicesPath}, elem...)...)
+	}
+
+	writeFile(t, root, devices("nmem1", CDIMMStatePath), "idle\n")
+	writeFile(t, root, devices("nmem0", CDIMMStatePath), "active\n")
+	writeFile(t, root, devices("nmem0", CNFITIDPath), "8089-a2-1837-00000f2a\n")
+	writeFile(t, root, devices("nmem0", CNFITSerialPath), "0x00000f2a\n")
+	writeFile(t, root, devices("nmem0", CNFITHandlePath), "0x1\n")
+	writeFile(t, root, devices("nmem0", CNFITPhysIDPath), "0x1c\n")
+	writeFile(t, root, devices("nmem0", CNFITFlagsPath), "save_fail smart_notify\n")
+
+	writeFile(t, root, devices("region0", CUEventPath), "DEVTYPE=nd_pmem_region\nMODALIAS=nd:t2\n")
+	writeFile(t, root, devices("region0", CSizePath), "270582939648\n")
+	writeFile(t, root, devices("region0", CAvailableSizePath), "0\n")
+	writeFile(t, root, devices("region0", CNUMANodePath), "0\n")
+	writeFile(t, root, devices("region0", CTargetNodePath), "2\n")
+	writeFile(t, root, devices("region0", CPersistenceDomainPath), "memory_controller\n")
+	writeFile(t, root, devices("region0", CReadOnlyPath), "0\n")
+	writeFile(t, root, devices("region0", CMappingsPath), "2\n")
+	writeFile(t, root, devices("region0", "mapping0"), "nmem0,0,135291469824,0\n")
+	writeFile(t, root, devices("region0", "mapping1"), "nmem1,0,135291469824,1\n")
+	// fsdax namespace has its block device under pfn holder
+	writeFile(t, root, devices("region0", "namespace0.0", CSizePath), "266352984064\n")
+	writeFile(t, root, devices("region0", "namespace0.0", CModePath), "fsdax\n")
+	writeFile(t, root, devices("region0", "namespace0.0", CUUIDPath), "2a8c5f4e-6b1d-4d3e-9f0a-1b2c3d4e5f60\n")
+	writeFile(t, root, devices("region0", "namespace0.0", CHolderPath), "pfn0.1\n")
+	writeFile(t, root, devices("pfn0.1", CBlockPath, "pmem0", "size"), "520220672\n")
+	// namespace seed of zero size is not configured
+	writeFile(t, root, devices("region0", "namespace0.1", CSizePath), "0\n")
+	// devdax namespace is exposed as device DAX
+	writeFile(t, root, devices("region1", CUEventPath), "DEVTYPE=nd_pmem_region\n")
+	writeFile(t, root, devices("region1", CSizePath), "1073741824\n")
+	writeFile(t, root, devices("region1", "namespace1.0", CSizePath), "1054867456\n")
+	writeFile(t, root, devices("region1", "namespace1.0", CModePath), "devdax\n")
+	writeFile(t, root, devices("region1", "namespace1.0", "dax1.0", "size"), "1054867456\n")
+	// region without size is skipped
+	writeFile(t, root, devices("region2", CUEventPath), "DEVTYPE=nd_volatile\n")
+
+	data, err := NewSvc(printer.NewSvc(false), root).GetData()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &NVDIMM{
+		DIMMs: []DIMM{
+			{
+				Name:   "nmem0",
+				ID:     "8089-a2-1837-00000f2a",
+				Serial: "0x00000f2a",
+				Handle: "0x1",
+				PhysID: "0x1c",
+				State:  "active",
+				Flags:  []string{"save_fail", "smart_notify"},
+			},
+			{Name: "nmem1", State: "idle"},
+		},
+		Regions: []Region{
+			{
+				Name:              "region0",
+				Type:              CRegionTypePMEM + "_region",
+				Size:              270582939648,
+				NUMANode:          0,
+				TargetNode:        2,
+				PersistenceDomain: "memory_controller",
+				DIMMs:             []string{"nmem0", "nmem1"},
+				Namespaces: []Namespace{
+					{
+						Name:   "namespace0.0",
+						Mode:   "fsdax",
+						Size:   266352984064,
+						UUID:   "2a8c5f4e-6b1d-4d3e-9f0a-1b2c3d4e5f60",
+						Device: "pmem0",
+					},
+				},
+			},
+			{
+				Name:       "region1",
+				Type:       CRegionTypePMEM + "_region",
+				Size:       1073741824,
+				NUMANode:   CNoNUMANode,
+				TargetNode: CNoNUMANode,
+				DIMMs:      []string{},
+				Namespaces: []Namespace{
+					{Name: "namespace1.0", Mode: "devdax", Size: 1054867456, Device: "dax1.0"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected %+v, got %+v", expected, data)
+	}
+
+	if _, err := NewSvc(printer.NewSvc(false), t.TempDir()).GetData(); err == nil {
+		t.Error("no nd bus: expected error")
+	}
+}
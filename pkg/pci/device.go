@@ -3,6 +3,9 @@
 
 package pci
 
+// CNoNUMANode is reported for numa_node if firmware does not describe device locality
+const CNoNUMANode = -1
+
 type DeviceType struct {
 	ID   string
 	Name string
@@ -42,4 +45,9 @@ type Device struct {
 	Class                *DeviceClass
 	Subclass             *DeviceSubclass
 	ProgrammingInterface *DeviceProgrammingInterface
+	// Slot is a physical slot or onboard device designation from SMBIOS
+	Slot string
+	// NUMANode is a node device is attached to, LocalCPUs are CPUs close to it
+	NUMANode  int
+	LocalCPUs []int
 }
@@ -21,6 +21,9 @@ const (
 
 	CPCIDeviceClassPath = "/class"
 
+	CPCIDeviceNUMANodePath  = "/numa_node"
+	CPCIDeviceLocalCPUsPath = "/local_cpulist"
+
 	CPCIDeviceClassPattern = "0x([[:xdigit:]]{2})([[:xdigit:]]{2})([[:xdigit:]]{2})"
 )
 
@@ -40,7 +43,8 @@ func NewDeviceSvc(printer *printer.Svc, ids *IDs) *DeviceSvc {
 
 func (s *DeviceSvc) GetDevice(basePath string, addr string) (*Device, error) {
 	device := &Device{
-		Address: addr,
+		Address:  addr,
+		NUMANode: CNoNUMANode,
 	}
 
 	err := s.setVendor(device, basePath)
@@ -53,9 +57,32 @@ func (s *DeviceSvc) GetDevice(basePath string, addr string) (*Device, error) {
 		s.printer.VErr(errors.Wrap(err, "unable to resolve class branch"))
 	}
 
+	err = s.setLocality(device, basePath)
+	if err != nil {
+		s.printer.VErr(errors.Wrap(err, "unable to resolve locality"))
+	}
+
 	return device, nil
 }
 
+func (s *DeviceSvc) setLocality(dev *Device, thePath string) error {
+	numaNodePath := path.Join(thePath, CPCIDeviceNUMANodePath)
+	numaNode, err := file.ToInt(numaNodePath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get numa node from %s", numaNodePath)
+	}
+	dev.NUMANode = numaNode
+
+	localCPUsPath := path.Join(thePath, CPCIDeviceLocalCPUsPath)
+	localCPUs, err := file.ToIntList(localCPUsPath)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get local cpus from %s", localCPUsPath)
+	}
+	dev.LocalCPUs = localCPUs
+
+	return nil
+}
+
 func (s *DeviceSvc) setVendor(dev *Device, thePath string) error {
 	vendorPath := path.Join(thePath, CPCIDeviceVendorPath)
 	vendorString, err := file.ToString(vendorPath)
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -14,7 +15,8 @@ import (
 )
 
 const (
-	CDevicesPath = "/sys/devices"
+	CDevicesPath    = "/sys/devices"
+	CBusDevicesPath = "/sys/bus/pci/devices"
 
 	CPCIBusIDPattern = "pci(\\d{4}:\\d{2})"
 )
@@ -22,16 +24,18 @@ const (
 var CPCIBusIDRegexp = regexp.MustCompile(CPCIBusIDPattern)
 
 type Svc struct {
-	printer     *printer.Svc
-	pciBusSvc   *BusSvc
-	devicesPath string
+	printer        *printer.Svc
+	pciBusSvc      *BusSvc
+	devicesPath    string
+	busDevicesPath string
 }
 
 func NewSvc(printer *printer.Svc, pciBusSvc *BusSvc, basePath string) *Svc {
 	return &Svc{
-		printer:     printer,
-		pciBusSvc:   pciBusSvc,
-		devicesPath: path.Join(basePath, CDevicesPath),
+		printer:        printer,
+		pciBusSvc:      pciBusSvc,
+		devicesPath:    path.Join(basePath, CDevicesPath),
+		busDevicesPath: path.Join(basePath, CBusDevicesPath),
 	}
 }
 
@@ -63,3 +67,24 @@ func (s *Svc) GetData() ([]Bus, error) {
 
 	return buses, nil
 }
+
+// GetUpstreamAddresses returns address of the device followed by
+// addresses of the bridges it is connected through, up to the root port
+func (s *Svc) GetUpstreamAddresses(address string) ([]string, error) {
+	linkPath := path.Join(s.busDevicesPath, address)
+	devicePath, err := os.Readlink(linkPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to resolve %s", linkPath)
+	}
+
+	// e.g. ../../../devices/pci0000:3a/0000:3a:00.0/0000:3b:00.0
+	elements := strings.Split(devicePath, "/")
+	addresses := make([]string, 0)
+	for i := len(elements) - 1; i >= 0; i-- {
+		if CPCIDeviceAddressRegexp.MatchString(elements[i]) {
+			addresses = append(addresses, elements[i])
+		}
+	}
+
+	return addresses, nil
+}
@@ -4,7 +4,10 @@
 package utils
 
 const (
-	CVersionFilePath = "/etc/sonic/sonic_version.yml"
-	CMachineType     = "Machine"
-	CSwitchType      = "Switch"
+	CVersionFilePath    = "/etc/sonic/sonic_version.yml"
+	CMachineType        = "Machine"
+	CSwitchType         = "Switch"
+	CDPUType            = "DPU"
+	CVirtualMachineType = "VirtualMachine"
+	CContainerType      = "Container"
 )
@@ -0,0 +1,8 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package version
+
+// Version is set on build with
+// -ldflags "-X github.com/onmetal/inventory/pkg/version.Version=<version>"
+var Version = "dev"